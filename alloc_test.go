@@ -0,0 +1,71 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+// TestValidateAllocs checks that validating valid values doesn't allocate.
+// Invalid values allocate their errors, which are returned to the caller.
+func TestValidateAllocs(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+		Tags []string
+	}
+
+	userSchema := valtor.Object[user]()
+	valtor.FieldOf(userSchema, "name", func(u user) string { return u.Name }, valtor.String().Required().Max(64))
+	valtor.FieldOf(userSchema, "age", func(u user) int { return u.Age }, valtor.Number[int]().Min(18))
+	valtor.FieldOf(userSchema, "tags", func(u user) []string { return u.Tags },
+		valtor.Array[string]().Max(5).ItemsOf(valtor.String().Min(1)))
+
+	mapSchema := valtor.Object[any]().
+		Field("name", func(v any) error { return nil }).
+		RequiredFields("name")
+
+	stringSchema := valtor.String().Required().Min(3).Max(64).HasPrefix("user-").ASCII()
+	numberSchema := valtor.Number[int]().Required().Min(18).Max(120).OneOf(18, 21, 30, 65)
+	arraySchema := valtor.Array[int]().Min(1).Max(10).UniqueItems().ItemsOf(valtor.Number[int]().Min(0))
+
+	value := user{Name: "Gopher", Age: 30, Tags: []string{"go"}}
+	mapValue := map[string]any{"name": "Gopher"}
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{name: "string", validate: func() error { return stringSchema.Validate("user-gopher") }},
+		{name: "number", validate: func() error { return numberSchema.Validate(30) }},
+		{name: "array", validate: func() error { return arraySchema.Validate(items) }},
+		{name: "struct", validate: func() error { return userSchema.Validate(value) }},
+		{name: "map", validate: func() error { return mapSchema.ValidateMap(mapValue) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validate(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if allocs := testing.AllocsPerRun(100, func() { _ = tt.validate() }); allocs != 0 {
+				t.Errorf("expected no allocations, got %v", allocs)
+			}
+		})
+	}
+}
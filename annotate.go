@@ -0,0 +1,94 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"errors"
+	"strings"
+)
+
+// AnnotatedError is returned by a schema with a title or description (see
+// Schema.Annotate). Its message is the message of the wrapped error, so the
+// annotations only show up in errors formatted with Verbose.
+type AnnotatedError struct {
+	Title       string
+	Description string
+	Err         error
+}
+
+func (e *AnnotatedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AnnotatedError) Unwrap() error {
+	return e.Err
+}
+
+// label returns the title and description, e.g. `Age (age in years)`.
+func (e *AnnotatedError) label() string {
+	switch {
+	case e.Description == "":
+		return e.Title
+	case e.Title == "":
+		return e.Description
+	default:
+		return e.Title + " (" + e.Description + ")"
+	}
+}
+
+// Annotate sets a human-readable title and description of the values, e.g.
+// `Age` and `user's age in years`, and returns the schema for chaining. Errors
+// of the schema are wrapped in an AnnotatedError.
+func (s *Schema[T]) Annotate(title, description string) *Schema[T] {
+	s.title = title
+	s.description = description
+	return s
+}
+
+// annotate wraps err in an AnnotatedError if the schema is annotated. The
+// errors of a joined error are wrapped separately.
+func (s *Schema[T]) annotate(err error) error {
+	if s.title == "" && s.description == "" {
+		return err
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		annotated := make([]error, len(errs))
+		for i, err := range errs {
+			annotated[i] = s.annotate(err)
+		}
+		return errors.Join(annotated...)
+	}
+	return &AnnotatedError{Title: s.title, Description: s.description, Err: err}
+}
+
+// Verbose formats a validation error for humans, with one line per error of a
+// joined error (see ObjectSchema.AllErrors). Errors of annotated schemas are
+// prefixed with the title and description of the innermost annotated schema
+// instead of the path of fields, e.g. `Age (user's age in years): value must
+// be at least 0`. Other errors are formatted as is.
+func Verbose(err error) string {
+	errs := flattenErrors(err)
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+
+		var annotated *AnnotatedError
+		for target := err; errors.As(target, &annotated); target = annotated.Err {
+			lines[i] = annotated.label() + ": " + annotated.Err.Error()
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// AnySchema represents a validation schema for interface values, e.g. values
+// decoded from JSON into an `any`, which dispatches values to type-specific
+// validators added with WhenType.
+type AnySchema struct {
+	*Schema[any]
+	branches []typeBranch
+	required bool
+	nilFn    func() error
+	expected string
+}
+
+// typeBranch validates values of a single type.
+type typeBranch struct {
+	typeName    string
+	description Description
+	validateFn  func(ctx context.Context, value any) (matched bool, err error)
+}
+
+// Any creates a new validation schema for interface values.
+func Any(opts ...Option) *AnySchema {
+	return &AnySchema{
+		Schema: New[any](opts...),
+	}
+}
+
+// WhenType adds a branch to the schema that validates values of type T with
+// the given schema, and returns the schema for chaining. Branches are tried in
+// the order in which they were added.
+func WhenType[T any](s *AnySchema, schema Validator[T]) *AnySchema {
+	description := Description{Type: jsonType(reflect.TypeFor[T]())}
+	if describer, ok := schema.(Describer); ok {
+		description = describer.Describe()
+	}
+	s.branches = append(s.branches, typeBranch{
+		typeName:    reflect.TypeFor[T]().String(),
+		description: description,
+		validateFn: func(ctx context.Context, value any) (bool, error) {
+			typedValue, ok := value.(T)
+			if !ok {
+				return false, nil
+			}
+			return true, validateContext(ctx, schema, typedValue)
+		},
+	})
+	return s
+}
+
+// Required will make a value required to not be nil when validated.
+func (s *AnySchema) Required() *AnySchema {
+	s.describe("required", true)
+	s.required = true
+	return s
+}
+
+// WhenNil sets the validator for nil values and returns the schema for
+// chaining. By default, nil values are valid unless the schema is required.
+func (s *AnySchema) WhenNil(fn func() error) *AnySchema {
+	s.nilFn = fn
+	return s
+}
+
+// Expected sets the description of the expected value used in the error for
+// values that match no branch, e.g. `string` for "expected string value, got
+// int", and returns the schema for chaining. It defaults to the types of the
+// branches.
+func (s *AnySchema) Expected(description string) *AnySchema {
+	s.expected = description
+	return s
+}
+
+// Validate validates the value against the schema and returns an error if the value is not valid.
+func (s *AnySchema) Validate(value any) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the value against the first branch that matches
+// its type, followed by any validators added to the schema itself, and returns
+// an error if the value is not valid.
+func (s *AnySchema) ValidateContext(ctx context.Context, value any) error {
+	if value == nil {
+		if s.required {
+			return ErrValueRequired
+		}
+		if s.nilFn != nil {
+			if err := s.nilFn(); err != nil {
+				return err
+			}
+		}
+		return s.Schema.ValidateContext(ctx, value)
+	}
+
+	if len(s.branches) > 0 {
+		matched, err := s.validateBranch(ctx, value)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return constraintError(CodeType, map[string]any{"expected": s.expectedDescription()},
+				"expected %s value, got %T", s.expectedDescription(), value)
+		}
+	}
+
+	return s.Schema.ValidateContext(ctx, value)
+}
+
+func (s *AnySchema) validateBranch(ctx context.Context, value any) (bool, error) {
+	for _, branch := range s.branches {
+		if matched, err := branch.validateFn(ctx, value); matched {
+			return true, err
+		}
+	}
+	return false, nil
+}
+
+func (s *AnySchema) expectedDescription() string {
+	if s.expected != "" {
+		return s.expected
+	}
+	typeNames := make([]string, len(s.branches))
+	for i, branch := range s.branches {
+		typeNames[i] = branch.typeName
+	}
+	return strings.Join(typeNames, " or ")
+}
@@ -15,32 +15,102 @@
 package valtor
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 )
 
 // ArraySchema represents a validation schema for array values.
 type ArraySchema[T any] struct {
 	*Schema[[]T]
-	itemValidator func(T) error
+	itemValidator func(context.Context, T) error
+	itemDescriber Describer // Describes the item schema, if known.
+	allErrors     bool
 }
 
 // Array creates a new validation schema for array values.
-func Array[T any]() *ArraySchema[T] {
-	return &ArraySchema[T]{
-		Schema: New[[]T](),
+func Array[T any](opts ...Option) *ArraySchema[T] {
+	s := &ArraySchema[T]{
+		Schema: New[[]T](opts...),
 	}
+	s.allErrors = s.config.collect
+	return s
 }
 
 // Items adds a validator for each item in the array.
 func (s *ArraySchema[T]) Items(validator func(T) error) *ArraySchema[T] {
+	return s.items(func(_ context.Context, item T) error {
+		return validator(item)
+	})
+}
+
+// ItemsOf adds a validator for each item in the array, like Items, and
+// returns the schema for chaining. Unlike with Items, the item schema is
+// described by Describe, if it implements Describer, and receives the context
+// passed to ValidateContext. Run applies the transformations of the item
+// schema (see Schema.Transform) to a copy of the array.
+func (s *ArraySchema[T]) ItemsOf(schema Validator[T]) *ArraySchema[T] {
+	s.items(contextValidate(schema))
+	s.itemDescriber, _ = schema.(Describer)
+	if t, ok := schema.(transformer[T]); ok {
+		s.TransformContext(func(ctx context.Context, arr []T) []T {
+			if arr == nil || !t.hasTransforms() {
+				return arr
+			}
+			transformed := make([]T, len(arr))
+			for i, item := range arr {
+				transformed[i] = t.transform(ctx, item)
+			}
+			return transformed
+		})
+	}
+	return s
+}
+
+func (s *ArraySchema[T]) items(validator func(context.Context, T) error) *ArraySchema[T] {
 	s.itemValidator = validator
-	s.validators = append(s.validators, func(arr []T) error {
+	s.itemDescriber = nil
+	s.validators = append(s.validators, func(ctx context.Context, arr []T) error {
+		ctx, c := newErrorCollector(ctx, s.allErrors, s.config.maxErrors)
 		for i, item := range arr {
-			if err := validator(item); err != nil {
-				return fmt.Errorf("invalid item at index %d: %w", i, err)
+			if err := validator(ctx, item); err != nil {
+				if c.add(fmt.Errorf("invalid item at index %d: %w", i, err)) {
+					break
+				}
 			}
 		}
+		return c.err()
+	})
+	return s
+}
+
+// PtrItems adds a validator for each item of an array of pointers, which
+// validates the pointed-to value with the item schema (see Ptr), and returns
+// the schema for chaining. Nil items are skipped, unless the item schema
+// implements PresenceValidator.
+func PtrItems[T any](schema *ArraySchema[*T], item Validator[T]) *ArraySchema[*T] {
+	return schema.ItemsOf(Ptr(item))
+}
+
+// AllErrors makes the Items validator report all invalid items instead of only
+// the first one, and returns the schema for chaining. Multiple errors are
+// combined with errors.Join.
+func (s *ArraySchema[T]) AllErrors() *ArraySchema[T] {
+	s.allErrors = true
+	return s
+}
+
+// NonEmpty adds a validator that checks if the array has at least one item
+// and returns the schema for chaining.
+func (s *ArraySchema[T]) NonEmpty() *ArraySchema[T] {
+	s.describe("minItems", 1)
+	s.addValidator(func(arr []T) error {
+		if len(arr) == 0 {
+			return constraintError(CodeNonEmpty, nil, "array must not be empty")
+		}
 		return nil
 	})
 	return s
@@ -48,9 +118,11 @@ func (s *ArraySchema[T]) Items(validator func(T) error) *ArraySchema[T] {
 
 // Min adds a minimum length validator to the schema.
 func (s *ArraySchema[T]) Min(min int) *ArraySchema[T] {
-	s.validators = append(s.validators, func(arr []T) error {
+	s.describe("minItems", min)
+	s.addValidator(func(arr []T) error {
 		if len(arr) < min {
-			return fmt.Errorf("array length must be at least %d", min)
+			return constraintError(CodeMinLength, map[string]any{"min": min, "actual": len(arr)},
+				"array length must be at least %d, got %d", min, len(arr))
 		}
 		return nil
 	})
@@ -59,9 +131,11 @@ func (s *ArraySchema[T]) Min(min int) *ArraySchema[T] {
 
 // Max adds a maximum length validator to the schema.
 func (s *ArraySchema[T]) Max(max int) *ArraySchema[T] {
-	s.validators = append(s.validators, func(arr []T) error {
+	s.describe("maxItems", max)
+	s.addValidator(func(arr []T) error {
 		if len(arr) > max {
-			return fmt.Errorf("array length must be at most %d", max)
+			return constraintError(CodeMaxLength, map[string]any{"max": max, "actual": len(arr)},
+				"array length must be at most %d, got %d", max, len(arr))
 		}
 		return nil
 	})
@@ -70,46 +144,178 @@ func (s *ArraySchema[T]) Max(max int) *ArraySchema[T] {
 
 // Length adds a validator that checks if the array has exactly the specified length.
 func (s *ArraySchema[T]) Length(length int) *ArraySchema[T] {
-	s.validators = append(s.validators, func(arr []T) error {
+	s.describe("minItems", length)
+	s.describe("maxItems", length)
+	s.addValidator(func(arr []T) error {
 		if len(arr) != length {
-			return fmt.Errorf("array length must be exactly %d", length)
+			return constraintError(CodeLength, map[string]any{"length": length, "actual": len(arr)},
+				"array length must be exactly %d, got %d", length, len(arr))
 		}
 		return nil
 	})
 	return s
 }
 
-// UniqueItems adds a validator that checks if all items in the array are unique.
+// UniqueItems adds a validator that checks if all items in the array are
+// unique. Items of comparable types are compared directly; other items are
+// compared by their JSON encoding. Use UniqueBy to compare by a key instead.
 func (s *ArraySchema[T]) UniqueItems() *ArraySchema[T] {
-	s.validators = append(s.validators, func(arr []T) error {
-		seen := make(map[string]struct{})
+	s.describe("uniqueItems", true)
+	return s.uniqueItems()
+}
+
+// uniqueItems adds the validator of UniqueItems.
+func (s *ArraySchema[T]) uniqueItems() *ArraySchema[T] {
+	key := itemKey[T]()
+	s.addValidator(func(arr []T) error {
+		seen := make(map[any]struct{}, len(arr))
 		for i, item := range arr {
-			// Use JSON marshaling to get a string representation for comparison
-			key, err := json.Marshal(item)
+			k, err := key(item)
 			if err != nil {
 				return fmt.Errorf("failed to marshal array item for uniqueness check at index %d: %w", i, err)
 			}
-			keyStr := string(key)
-			if _, exists := seen[keyStr]; exists {
-				return fmt.Errorf("array items must be unique (duplicate found at index %d)", i)
+			if _, exists := seen[k]; exists {
+				return constraintError(CodeUnique, map[string]any{"index": i},
+					"array items must be unique (duplicate found at index %d)", i)
+			}
+			seen[k] = struct{}{}
+		}
+		return nil
+	})
+	return s
+}
+
+// itemKey returns a function that returns the map key of an item: the item
+// itself, if T is strictly comparable, or else its JSON encoding, so that
+// e.g. items of type any that are slices don't make a map panic.
+func itemKey[T any]() func(T) (any, error) {
+	if strictlyComparable(reflect.TypeFor[T]()) {
+		return func(item T) (any, error) {
+			return item, nil
+		}
+	}
+	return func(item T) (any, error) {
+		b, err := json.Marshal(item)
+		return string(b), err
+	}
+}
+
+// UniqueBy adds a validator to the array schema that checks if the key
+// returned by the key function is unique across all items, e.g. to require
+// unique IDs, and returns the schema for chaining.
+func UniqueBy[T any, K comparable](schema *ArraySchema[T], key func(T) K) *ArraySchema[T] {
+	schema.addValidator(func(arr []T) error {
+		seen := make(map[K]struct{}, len(arr))
+		for i, item := range arr {
+			k := key(item)
+			if _, exists := seen[k]; exists {
+				return constraintError(CodeUnique, map[string]any{"index": i},
+					"array items must be unique (duplicate found at index %d)", i)
+			}
+			seen[k] = struct{}{}
+		}
+		return nil
+	})
+	return schema
+}
+
+// Sorted adds a validator that checks if the items are sorted according to the
+// less function, e.g. for a series of timestamps, and returns the schema for
+// chaining. Equal adjacent items are allowed.
+func (s *ArraySchema[T]) Sorted(less func(a, b T) bool) *ArraySchema[T] {
+	s.describe(CodeSorted, true)
+	s.addValidator(func(arr []T) error {
+		for i := 1; i < len(arr); i++ {
+			if less(arr[i], arr[i-1]) {
+				return constraintError(CodeSorted, map[string]any{"index": i},
+					"array items out of order at index %d", i)
 			}
-			seen[keyStr] = struct{}{}
 		}
 		return nil
 	})
 	return s
 }
 
+// SortedAscending adds a validator to the array schema that checks if the
+// items are in ascending order, and returns the schema for chaining.
+func SortedAscending[T cmp.Ordered](schema *ArraySchema[T]) *ArraySchema[T] {
+	return schema.Sorted(cmp.Less[T])
+}
+
+// strictlyComparable reports whether values of the type can be compared
+// without panicking, which excludes types that contain interfaces.
+func strictlyComparable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return strictlyComparable(typ.Elem())
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			if !strictlyComparable(typ.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return typ.Comparable()
+	}
+}
+
+// ValidateChunked validates the items of a large array in chunks of chunkSize
+// items, reporting all invalid items instead of only the first, up to the
+// maximum number of errors of the schema, if any (see WithMaxErrors). After
+// each chunk, progress (if not nil) is called with the number of items
+// validated so far and the errors found so far. Validation is aborted when ctx
+// is done, which is checked before each chunk. Only the item validator set
+// with Items is applied.
+func (s *ArraySchema[T]) ValidateChunked(ctx context.Context, value []T, chunkSize int, progress func(index int, errs []error)) error {
+	if chunkSize <= 0 {
+		chunkSize = len(value)
+	}
+
+	ctx, c := newErrorCollector(ctx, true, s.config.maxErrors)
+	for start := 0; start < len(value); start += chunkSize {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("validation aborted at index %d: %w", start, err)
+		}
+		end := min(start+chunkSize, len(value))
+		if s.itemValidator != nil {
+			for i, item := range value[start:end] {
+				if err := s.itemValidator(ctx, item); err != nil {
+					if c.add(fmt.Errorf("invalid item at index %d: %w", start+i, err)) {
+						end = start + i + 1
+						break
+					}
+				}
+			}
+		}
+		if progress != nil {
+			progress(end, slices.Clip(c.errs))
+		}
+		if c.stopped {
+			break
+		}
+	}
+	return c.err()
+}
+
 // Validate validates the array against the schema and returns an error if the array is not valid.
 func (s *ArraySchema[T]) Validate(value []T) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the array against the schema with the given context
+// and returns an error if the array is not valid.
+func (s *ArraySchema[T]) ValidateContext(ctx context.Context, value []T) error {
 	if value == nil {
 		// Check if Min validator exists and requires a non-empty array
 		for _, validator := range s.validators {
-			if err := validator([]T{}); err != nil {
-				return err
+			if err := validator(ctx, []T{}); err != nil {
+				return s.annotate(s.message(err))
 			}
 		}
 		return nil
 	}
-	return s.Schema.Validate(value)
+	return s.Schema.ValidateContext(ctx, value)
 }
@@ -0,0 +1,97 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"text/tabwriter"
+)
+
+// BatchReport summarizes the validation of a batch of values with ValidateAll,
+// e.g. of the records of a data file in a data quality job or a CI check. It
+// can be encoded as JSON, or rendered as a table with WriteText.
+type BatchReport struct {
+	Total    int            `json:"total"` // Number of validated values.
+	Valid    int            `json:"valid"` // Number of valid values.
+	Failures []BatchFailure `json:"failures,omitempty"`
+	ByField  map[string]int `json:"by_field,omitempty"` // Failures per field path. Failures that are not about a field are not counted.
+	ByCode   map[string]int `json:"by_code,omitempty"`  // Failures per code (see Codes).
+}
+
+// BatchFailure describes a single failed rule of a value in a BatchReport.
+type BatchFailure struct {
+	Input string `json:"input"` // Identifier of the value, e.g. `users.csv:12`.
+	ReportFailure
+}
+
+// ValidateAll validates each value of the sequence, which is keyed by an
+// identifier of the value (e.g. a line number or file name), against the
+// schema with the given context, and returns a report of the failures. Values
+// are validated one by one, so the sequence can be read lazily from a large
+// file.
+func ValidateAll[T any](ctx context.Context, schema Validator[T], values iter.Seq2[string, T]) *BatchReport {
+	report := &BatchReport{
+		ByField: make(map[string]int),
+		ByCode:  make(map[string]int),
+	}
+	for input, value := range values {
+		report.Total++
+		errs := flattenErrors(validateContext(ctx, schema, value))
+		if len(errs) == 0 {
+			report.Valid++
+			continue
+		}
+		for _, err := range errs {
+			failure := ReportFailure{
+				Field:   fieldPath(err),
+				Code:    errorCode(err),
+				Message: err.Error(),
+			}
+			report.Failures = append(report.Failures, BatchFailure{Input: input, ReportFailure: failure})
+			if failure.Field != "" {
+				report.ByField[failure.Field]++
+			}
+			report.ByCode[failure.Code]++
+		}
+	}
+	return report
+}
+
+// WriteText writes the report to w as a table of failures, followed by a
+// summary line, e.g. `2 of 3 values valid, 1 failure (fields: age=1; codes:
+// min=1)`.
+func (r *BatchReport) WriteText(w io.Writer) error {
+	if len(r.Failures) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "INPUT\tFIELD\tCODE\tMESSAGE")
+		for _, f := range r.Failures {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Input, f.Field, f.Code, f.Message)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	noun := "failures"
+	if len(r.Failures) == 1 {
+		noun = "failure"
+	}
+	_, err := fmt.Fprintf(w, "%d of %d values valid, %d %s (fields: %s; codes: %s)\n",
+		r.Valid, r.Total, len(r.Failures), noun, formatCounts(r.ByField), formatCounts(r.ByCode))
+	return err
+}
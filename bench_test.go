@@ -0,0 +1,134 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+func BenchmarkStringSchemaOneOf(b *testing.B) {
+	values := make([]string, 500)
+	for i := range values {
+		values[i] = fmt.Sprintf("value-%d", i)
+	}
+	last := values[len(values)-1]
+
+	b.Run("set", func(b *testing.B) {
+		schema := valtor.String().OneOf(values...)
+		for b.Loop() {
+			_ = schema.Validate(last)
+		}
+	})
+
+	// Linear scan, as a baseline.
+	b.Run("scan", func(b *testing.B) {
+		schema := valtor.String()
+		schema.Custom(func(v string) error {
+			if !slices.Contains(values, v) {
+				return errors.New("value must be one of the allowed values")
+			}
+			return nil
+		})
+		for b.Loop() {
+			_ = schema.Validate(last)
+		}
+	})
+}
+
+// The benchmarks below validate valid values, which should not allocate.
+
+func BenchmarkStringSchema(b *testing.B) {
+	schema := valtor.String().Required().Min(3).Max(64).HasPrefix("user-").ASCII()
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = schema.Validate("user-gopher")
+	}
+}
+
+func BenchmarkNumberSchema(b *testing.B) {
+	schema := valtor.Number[int]().Required().Min(18).Max(120).OneOf(18, 21, 30, 65)
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = schema.Validate(30)
+	}
+}
+
+func BenchmarkArraySchema(b *testing.B) {
+	schema := valtor.Array[int]().Min(1).Max(10).UniqueItems().
+		Items(valtor.Number[int]().Min(0).Validate)
+	value := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = schema.Validate(value)
+	}
+}
+
+func BenchmarkObjectSchema(b *testing.B) {
+	type User struct {
+		Name  string
+		Email string
+		Age   int
+		Tags  []string
+	}
+
+	nameSchema := valtor.String().Required().Max(64)
+	emailSchema := valtor.String().Required().Format("email")
+	ageSchema := valtor.Number[int]().Min(18)
+	tagsSchema := valtor.Array[string]().Max(5).Items(valtor.String().Min(1).Validate)
+
+	schema := valtor.Object[User]()
+	valtor.FieldOf(schema, "name", func(u User) string { return u.Name }, nameSchema)
+	valtor.FieldOf(schema, "email", func(u User) string { return u.Email }, emailSchema)
+	valtor.FieldOf(schema, "age", func(u User) int { return u.Age }, ageSchema)
+	valtor.FieldOf(schema, "tags", func(u User) []string { return u.Tags }, tagsSchema)
+	value := User{Name: "Gopher", Email: "gopher@example.com", Age: 30, Tags: []string{"go"}}
+
+	b.Run("struct", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = schema.Validate(value)
+		}
+	})
+
+	mapSchema := valtor.Object[any]().
+		Field("name", func(v any) error { return nameSchema.Validate(v.(string)) }).
+		Field("age", func(v any) error { return ageSchema.Validate(v.(int)) })
+	mapValue := map[string]any{"name": "Gopher", "age": 30}
+
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = mapSchema.ValidateMap(mapValue)
+		}
+	})
+
+	limitsSchema := valtor.Object[map[string]any]().Limits(valtor.Limits{MaxTotalItems: 1000, MaxStringBytes: 100})
+	limitsValue := make(map[string]any)
+	for i := range 100 {
+		limitsValue[fmt.Sprintf("key-%d", i)] = "value"
+	}
+
+	b.Run("map limits", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = limitsSchema.ValidateMap(limitsValue)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+// BigIntSchema represents a validation schema for arbitrary precision integer
+// values.
+type BigIntSchema struct {
+	*Schema[*big.Int]
+	required bool
+}
+
+// BigInt creates a new validation schema for arbitrary precision integer
+// values. Validators are skipped for nil values.
+func BigInt(opts ...Option) *BigIntSchema {
+	return &BigIntSchema{
+		Schema: New[*big.Int](opts...),
+	}
+}
+
+// Required will make a big integer value required to not be nil when validated.
+func (s *BigIntSchema) Required() *BigIntSchema {
+	s.required = true
+	return s
+}
+
+// Min adds a minimum value validator to the schema and returns the schema for chaining.
+func (s *BigIntSchema) Min(min *big.Int) *BigIntSchema {
+	s.addValidator(func(v *big.Int) error {
+		if v.Cmp(min) < 0 {
+			return constraintError(CodeMin, map[string]any{"min": min, "actual": v}, "value must be at least %v", min)
+		}
+		return nil
+	})
+	return s
+}
+
+// Max adds a maximum value validator to the schema and returns the schema for chaining.
+func (s *BigIntSchema) Max(max *big.Int) *BigIntSchema {
+	s.addValidator(func(v *big.Int) error {
+		if v.Cmp(max) > 0 {
+			return constraintError(CodeMax, map[string]any{"max": max, "actual": v}, "value must be at most %v", max)
+		}
+		return nil
+	})
+	return s
+}
+
+// Validate validates the big integer against the schema and returns an error if the value is not valid.
+func (s *BigIntSchema) Validate(value *big.Int) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the big integer against the schema with the given
+// context and returns an error if the value is not valid.
+func (s *BigIntSchema) ValidateContext(ctx context.Context, value *big.Int) error {
+	if value == nil {
+		if s.required {
+			return ErrValueRequired
+		}
+		return nil
+	}
+	return s.Schema.ValidateContext(ctx, value)
+}
+
+var decimalRegexp = regexp.MustCompile(`^[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][+-]?[0-9]+)?$`)
+
+// parseDecimal parses a decimal number, e.g. `-12.50` or `1e3`, as an exact
+// rational number.
+func parseDecimal(s string) (*big.Rat, bool) {
+	if !decimalRegexp.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// DecimalSchema represents a validation schema for arbitrary precision decimal
+// numbers in string form, e.g. `19.99`. Comparisons are exact, which makes it
+// suitable for financial amounts that cannot be represented as a float64.
+type DecimalSchema struct {
+	*Schema[string]
+	required bool
+}
+
+// Decimal creates a new validation schema for decimal numbers in string form.
+// An empty string is considered missing, and skips all other validators.
+func Decimal(opts ...Option) *DecimalSchema {
+	s := &DecimalSchema{
+		Schema: New[string](opts...),
+	}
+	s.addValidator(func(v string) error {
+		if _, ok := parseDecimal(v); !ok {
+			return constraintError(CodeDecimal, nil, "value must be a decimal number")
+		}
+		return nil
+	})
+	return s
+}
+
+// Required will make a decimal value required to be not empty when validated.
+func (s *DecimalSchema) Required() *DecimalSchema {
+	s.required = true
+	return s
+}
+
+// Min adds a minimum value validator to the schema and returns the schema for
+// chaining. An invalid min is reported when validating.
+func (s *DecimalSchema) Min(min string) *DecimalSchema {
+	minRat, minOK := parseDecimal(min)
+	s.addValidator(func(v string) error {
+		if !minOK {
+			return fmt.Errorf("invalid minimum %q", min)
+		}
+		if r, ok := parseDecimal(v); ok && r.Cmp(minRat) < 0 {
+			return constraintError(CodeMin, map[string]any{"min": min, "actual": v}, "value must be at least %s", min)
+		}
+		return nil
+	})
+	return s
+}
+
+// Max adds a maximum value validator to the schema and returns the schema for
+// chaining. An invalid max is reported when validating.
+func (s *DecimalSchema) Max(max string) *DecimalSchema {
+	maxRat, maxOK := parseDecimal(max)
+	s.addValidator(func(v string) error {
+		if !maxOK {
+			return fmt.Errorf("invalid maximum %q", max)
+		}
+		if r, ok := parseDecimal(v); ok && r.Cmp(maxRat) > 0 {
+			return constraintError(CodeMax, map[string]any{"max": max, "actual": v}, "value must be at most %s", max)
+		}
+		return nil
+	})
+	return s
+}
+
+// Scale adds a validator that checks if the value has at most the specified
+// number of digits after the decimal point, ignoring trailing zeros, and
+// returns the schema for chaining. A scale of 0 requires a whole number.
+func (s *DecimalSchema) Scale(scale int) *DecimalSchema {
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	s.addValidator(func(v string) error {
+		r, ok := parseDecimal(v)
+		if !ok {
+			return nil
+		}
+		if !new(big.Rat).Mul(r, new(big.Rat).SetInt(factor)).IsInt() {
+			return constraintError(CodeScale, map[string]any{"scale": scale},
+				"value must have at most %d digits after the decimal point", scale)
+		}
+		return nil
+	})
+	return s
+}
+
+// Validate validates the decimal against the schema and returns an error if the value is not valid.
+func (s *DecimalSchema) Validate(value string) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the decimal against the schema with the given
+// context and returns an error if the value is not valid.
+func (s *DecimalSchema) ValidateContext(ctx context.Context, value string) error {
+	if value == "" {
+		if s.required {
+			return ErrValueRequired
+		}
+		return nil
+	}
+	return s.Schema.ValidateContext(ctx, value)
+}
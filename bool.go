@@ -15,7 +15,7 @@
 package valtor
 
 import (
-	"fmt"
+	"context"
 )
 
 // BoolSchema represents a validation schema for boolean values.
@@ -24,22 +24,29 @@ type BoolSchema struct {
 }
 
 // Bool creates a new validation schema for boolean values.
-func Bool() *BoolSchema {
+func Bool(opts ...Option) *BoolSchema {
 	return &BoolSchema{
-		Schema: New[bool](),
+		Schema: New[bool](opts...),
 	}
 }
 
 // Validate validates the boolean against the schema and returns an error if the boolean is not valid.
 func (s *BoolSchema) Validate(value bool) error {
-	return s.Schema.Validate(value)
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the boolean against the schema with the given
+// context and returns an error if the boolean is not valid.
+func (s *BoolSchema) ValidateContext(ctx context.Context, value bool) error {
+	return s.Schema.ValidateContext(ctx, value)
 }
 
 // MustBeTrue adds a validator that checks if the boolean value is true.
 func (s *BoolSchema) MustBeTrue() *BoolSchema {
-	s.validators = append(s.validators, func(v bool) error {
+	s.describe("const", true)
+	s.addValidator(func(v bool) error {
 		if !v {
-			return fmt.Errorf("bool value must be true")
+			return constraintError(CodeTrue, nil, "bool value must be true")
 		}
 		return nil
 	})
@@ -48,9 +55,10 @@ func (s *BoolSchema) MustBeTrue() *BoolSchema {
 
 // MustBeFalse adds a validator that checks if the boolean value is false.
 func (s *BoolSchema) MustBeFalse() *BoolSchema {
-	s.validators = append(s.validators, func(v bool) error {
+	s.describe("const", false)
+	s.addValidator(func(v bool) error {
 		if v {
-			return fmt.Errorf("bool value must be false")
+			return constraintError(CodeFalse, nil, "bool value must be false")
 		}
 		return nil
 	})
@@ -0,0 +1,147 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Validatable is implemented by types that validate themselves.
+type Validatable interface {
+	Validate() error
+}
+
+var (
+	validatableType = reflect.TypeFor[Validatable]()
+	errorType       = reflect.TypeFor[error]()
+)
+
+// Check validates a value by convention: values of types implementing
+// Validatable are validated with their Validate method, and values of types
+// with a `Schema() S` method, where S has a `Validate(T) error` method for the
+// type T of the value (e.g. ObjectSchema[T]), are validated against the
+// returned schema. Check walks the value recursively: pointers, exported struct
+// fields, and the elements of slices, arrays and maps are checked as well. It
+// returns the first error, wrapped in a FieldError for struct fields.
+func Check(value any) error {
+	return check(reflect.ValueOf(value), make(map[uintptr]struct{}))
+}
+
+func check(v reflect.Value, visited map[uintptr]struct{}) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Pointer {
+			// Guard against cycles, e.g. in linked structures.
+			if _, ok := visited[v.Pointer()]; ok {
+				return nil
+			}
+			visited[v.Pointer()] = struct{}{}
+		}
+		return check(v.Elem(), visited)
+	}
+
+	if !v.CanAddr() {
+		// Copy the value, so that methods with a pointer receiver can be
+		// called, e.g. for map values.
+		addressable := reflect.New(v.Type()).Elem()
+		addressable.Set(v)
+		v = addressable
+	}
+	if err := checkSelf(v.Addr()); err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if err := check(v.Field(i), visited); err != nil {
+				return &FieldError{Field: field.Name, Err: err}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := check(v.Index(i), visited); err != nil {
+				return fmt.Errorf("invalid item at index %d: %w", i, err)
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := check(iter.Value(), visited); err != nil {
+				return fmt.Errorf("invalid value for key %v: %w", iter.Key(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSelf validates a single value, given as a pointer p to it, by its
+// Validate or Schema method, if any.
+func checkSelf(p reflect.Value) error {
+	if p.Type().Implements(validatableType) {
+		if err := p.Interface().(Validatable).Validate(); err != nil {
+			return err
+		}
+	}
+
+	schemaMethod := p.MethodByName("Schema")
+	if !schemaMethod.IsValid() {
+		return nil
+	}
+	if t := schemaMethod.Type(); t.NumIn() != 0 || t.NumOut() != 1 {
+		return nil
+	}
+	schema := schemaMethod.Call(nil)[0]
+	if (schema.Kind() == reflect.Pointer || schema.Kind() == reflect.Interface) && schema.IsNil() {
+		return nil
+	}
+
+	validateMethod := schema.MethodByName("Validate")
+	if !validateMethod.IsValid() {
+		return nil
+	}
+	t := validateMethod.Type()
+	if t.NumIn() != 1 || t.NumOut() != 1 || t.Out(0) != errorType {
+		return nil
+	}
+
+	// The schema may be for the value or for a pointer to it.
+	arg := p.Elem()
+	switch {
+	case arg.Type().AssignableTo(t.In(0)):
+	case p.Type().AssignableTo(t.In(0)):
+		arg = p
+	default:
+		return nil
+	}
+
+	if err, _ := validateMethod.Call([]reflect.Value{arg})[0].Interface().(error); err != nil {
+		return err
+	}
+	return nil
+}
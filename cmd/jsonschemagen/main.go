@@ -0,0 +1,80 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command jsonschemagen generates Go struct types and valtor schemas from a
+// JSON Schema file, for use with go:generate:
+//
+//	//go:generate go run github.com/dstotijn/valtor/cmd/jsonschemagen -type User -o user_schema.go user.schema.json
+//
+// The package name defaults to $GOPACKAGE, as set by go generate. See
+// valtorjsonschema.Generate for the supported keywords.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/dstotijn/valtor/valtorjsonschema"
+	"github.com/invopop/jsonschema"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("jsonschemagen: ")
+
+	typeName := flag.String("type", "", "name of the generated struct type (required)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "name of the generated package")
+	output := flag.String("o", "", "output file (defaults to stdout)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: jsonschemagen -type <name> [-pkg <package>] [-o <file>] <schema.json>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeName == "" || *pkg == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *output, *pkg, *typeName); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(input, output, pkg, typeName string) error {
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := valtorjsonschema.Generate(&buf, pkg, typeName, schema); err != nil {
+		return fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	if output == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(output, buf.Bytes(), 0o644)
+}
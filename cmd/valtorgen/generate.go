@@ -0,0 +1,357 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// generator generates schema constructors for struct types declared in a
+// single package.
+type generator struct {
+	pkg       string
+	structs   map[string]*ast.StructType
+	named     map[string]bool // Named non-struct types, which are skipped.
+	generated map[string]bool
+	queue     []string
+	regexps   bool
+	current   string   // The field being generated, e.g. `User.Status`.
+	skipped   []string // Diagnostics for skipped fields.
+}
+
+func newGenerator(pkg string, files []*ast.File) *generator {
+	g := &generator{
+		pkg:       pkg,
+		structs:   make(map[string]*ast.StructType),
+		named:     make(map[string]bool),
+		generated: make(map[string]bool),
+	}
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok && spec.TypeParams == nil {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					g.structs[spec.Name.Name] = st
+				} else {
+					g.named[spec.Name.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	return g
+}
+
+// generate returns the formatted source code of the schema constructors for
+// the given types, and for the struct types of the package they refer to.
+func (g *generator) generate(typeNames []string) ([]byte, error) {
+	g.queue = append(g.queue, typeNames...)
+
+	var decls bytes.Buffer
+	for len(g.queue) > 0 {
+		typeName := g.queue[0]
+		g.queue = g.queue[1:]
+		if g.generated[typeName] {
+			continue
+		}
+		g.generated[typeName] = true
+
+		st, ok := g.structs[typeName]
+		if !ok {
+			return nil, fmt.Errorf("struct type %q not found", typeName)
+		}
+		if err := g.structSchema(&decls, typeName, st); err != nil {
+			return nil, fmt.Errorf("invalid struct type %q: %w", typeName, err)
+		}
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by valtorgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", g.pkg)
+	src.WriteString("import (\n")
+	if g.regexps {
+		src.WriteString("\t\"regexp\"\n\n")
+	}
+	src.WriteString("\t\"github.com/dstotijn/valtor\"\n)\n\n")
+	src.Write(decls.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+func (g *generator) structSchema(w *bytes.Buffer, typeName string, st *ast.StructType) error {
+	var validators bytes.Buffer
+
+	for _, field := range st.Fields.List {
+		// Embedded fields are not supported.
+		if len(field.Names) == 0 {
+			g.skipped = append(g.skipped, fmt.Sprintf("embedded field %s of %s: embedded fields are not supported", types.ExprString(field.Type), typeName))
+			continue
+		}
+
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			s, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return fmt.Errorf("invalid tag %s: %w", field.Tag.Value, err)
+			}
+			tag = reflect.StructTag(s)
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			if err := g.field(&validators, typeName, name.Name, field.Type, tag); err != nil {
+				return fmt.Errorf("invalid field %q: %w", name.Name, err)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "// New%sSchema creates a validation schema for %s.\n", typeName, typeName)
+	fmt.Fprintf(w, "func New%sSchema() *valtor.ObjectSchema[%s] {\n", typeName, typeName)
+	if validators.Len() == 0 {
+		fmt.Fprintf(w, "\treturn valtor.Object[%s]()\n}\n\n", typeName)
+		return nil
+	}
+	fmt.Fprintf(w, "\tschema := valtor.Object[%s]()\n%s\treturn schema\n}\n\n", typeName, validators.String())
+	return nil
+}
+
+func (g *generator) field(w *bytes.Buffer, typeName, fieldName string, expr ast.Expr, tag reflect.StructTag) error {
+	jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+	if jsonName == "-" {
+		return nil
+	}
+	if jsonName == "" {
+		jsonName = fieldName
+	}
+
+	keywords := parseKeywords(tag.Get("jsonschema"))
+	if _, ok := keywords["-"]; ok {
+		return nil
+	}
+	_, required := keywords["required"]
+
+	ptr, isPtr := expr.(*ast.StarExpr)
+	if isPtr {
+		expr = ptr.X
+	}
+
+	g.current = typeName + "." + fieldName
+	goType, schemaExpr, err := g.value(expr, keywords, required && !isPtr)
+	if err != nil {
+		return err
+	}
+	if schemaExpr == "" {
+		return nil
+	}
+
+	if isPtr {
+		goType = "*" + goType
+		schemaExpr = "valtor.Ptr(" + schemaExpr + ")"
+		if required {
+			schemaExpr += ".Required()"
+		}
+	}
+	fmt.Fprintf(w, "\tvaltor.FieldOf(schema, %q, func(v %s) %s { return v.%s }, %s)\n",
+		jsonName, typeName, goType, fieldName, schemaExpr)
+	return nil
+}
+
+// value returns the Go type and the expression of the valtor schema for a
+// value of the given type. It returns an empty expression for types that are
+// not supported, such as types from other packages.
+func (g *generator) value(expr ast.Expr, keywords map[string][]string, required bool) (string, string, error) {
+	var sb strings.Builder
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			// Lengths are measured in runes, as by JSON Schema.
+			sb.WriteString("valtor.String().LengthMode(valtor.LengthRunes)")
+			if required {
+				sb.WriteString(".Required()")
+			}
+			for _, kw := range []struct{ name, method string }{{"minLength", "Min"}, {"maxLength", "Max"}} {
+				if err := writeIntKeyword(&sb, keywords, kw.name, kw.method); err != nil {
+					return "", "", err
+				}
+			}
+			if pattern := keyword(keywords, "pattern"); pattern != "" {
+				// Compile the pattern now, as regexp.MustCompile panics when
+				// the generated package is initialized.
+				if _, err := regexp.Compile(pattern); err != nil {
+					return "", "", fmt.Errorf("invalid `pattern` value %q: %w", pattern, err)
+				}
+				g.regexps = true
+				fmt.Fprintf(&sb, ".Regexp(regexp.MustCompile(%s))", strconv.Quote(pattern))
+			}
+			if format := keyword(keywords, "format"); format != "" {
+				fmt.Fprintf(&sb, ".Format(%q)", format)
+			}
+			if enum := keywords["enum"]; len(enum) > 0 {
+				values := make([]string, 0, len(enum))
+				for _, v := range enum {
+					values = append(values, strconv.Quote(v))
+				}
+				fmt.Fprintf(&sb, ".OneOf(%s)", strings.Join(values, ", "))
+			}
+			return t.Name, sb.String(), nil
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			fmt.Fprintf(&sb, "valtor.Number[%s]()", t.Name)
+			for _, kw := range []struct{ name, method string }{{"minimum", "Min"}, {"maximum", "Max"}} {
+				if v := keyword(keywords, kw.name); v != "" {
+					if err := checkNumber(t.Name, v); err != nil {
+						return "", "", fmt.Errorf("invalid `%s` value %q: %w", kw.name, v, err)
+					}
+					fmt.Fprintf(&sb, ".%s(%s)", kw.method, v)
+				}
+			}
+			if enum := keywords["enum"]; len(enum) > 0 {
+				for _, v := range enum {
+					if err := checkNumber(t.Name, v); err != nil {
+						return "", "", fmt.Errorf("invalid `enum` value %q: %w", v, err)
+					}
+				}
+				fmt.Fprintf(&sb, ".OneOf(%s)", strings.Join(enum, ", "))
+			}
+			return t.Name, sb.String(), nil
+		case "bool":
+			return t.Name, "valtor.Bool()", nil
+		}
+		if _, ok := g.structs[t.Name]; ok {
+			g.queue = append(g.queue, t.Name)
+			return t.Name, "New" + t.Name + "Schema()", nil
+		}
+		if g.named[t.Name] {
+			g.skipped = append(g.skipped, fmt.Sprintf("field %s of type %s: only struct types of the package are supported", g.current, t.Name))
+		}
+		return "", "", nil
+	case *ast.ArrayType:
+		if t.Len != nil {
+			// Arrays are not supported, as ArraySchema validates slices.
+			return "", "", nil
+		}
+		itemExpr := t.Elt
+		itemPtr, isPtr := itemExpr.(*ast.StarExpr)
+		if isPtr {
+			itemExpr = itemPtr.X
+		}
+		// Item keywords are not supported, as tags apply to the slice.
+		itemType, itemSchema, err := g.value(itemExpr, nil, false)
+		if err != nil || itemSchema == "" {
+			return "", "", err
+		}
+		if isPtr {
+			fmt.Fprintf(&sb, "valtor.PtrItems(valtor.Array[*%s](), %s)", itemType, itemSchema)
+			itemType = "*" + itemType
+		} else {
+			fmt.Fprintf(&sb, "valtor.Array[%s]().ItemsOf(%s)", itemType, itemSchema)
+		}
+		if required {
+			sb.WriteString(".NonEmpty()")
+		}
+		for _, kw := range []struct{ name, method string }{{"minItems", "Min"}, {"maxItems", "Max"}} {
+			if err := writeIntKeyword(&sb, keywords, kw.name, kw.method); err != nil {
+				return "", "", err
+			}
+		}
+		if _, ok := keywords["uniqueItems"]; ok {
+			sb.WriteString(".UniqueItems()")
+		}
+		return "[]" + itemType, sb.String(), nil
+	default:
+		return "", "", nil
+	}
+}
+
+// checkNumber checks that v is a number literal, optionally signed, that is
+// representable by the numeric Go type, e.g. not `1.5` for an int or `-1` for
+// a uint, so that the generated code compiles.
+func checkNumber(goType, v string) error {
+	expr, err := parser.ParseExpr(v)
+	if err != nil {
+		return errors.New("not a number")
+	}
+	if unary, ok := expr.(*ast.UnaryExpr); ok && (unary.Op == token.SUB || unary.Op == token.ADD) {
+		expr = unary.X
+	}
+	if lit, ok := expr.(*ast.BasicLit); !ok || (lit.Kind != token.INT && lit.Kind != token.FLOAT) {
+		return errors.New("not a number")
+	}
+	if _, err := types.Eval(token.NewFileSet(), nil, token.NoPos, goType+"("+v+")"); err != nil {
+		return fmt.Errorf("not representable by %s", goType)
+	}
+	return nil
+}
+
+// parseKeywords parses a `jsonschema` struct tag, as used by
+// github.com/invopop/jsonschema, e.g. `required,minLength=1,enum=a,enum=b`,
+// into keywords and their values. Commas in values are escaped as `\,`.
+func parseKeywords(tag string) map[string][]string {
+	keywords := make(map[string][]string)
+	if tag == "" {
+		return keywords
+	}
+
+	var parts []string
+	for _, part := range strings.Split(tag, ",") {
+		if n := len(parts); n > 0 && strings.HasSuffix(parts[n-1], `\`) {
+			parts[n-1] = strings.TrimSuffix(parts[n-1], `\`) + "," + part
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	for _, part := range parts {
+		name, value, _ := strings.Cut(part, "=")
+		keywords[name] = append(keywords[name], value)
+	}
+	return keywords
+}
+
+func keyword(keywords map[string][]string, name string) string {
+	if values := keywords[name]; len(values) > 0 {
+		return values[len(values)-1]
+	}
+	return ""
+}
+
+func writeIntKeyword(sb *strings.Builder, keywords map[string][]string, name, method string) error {
+	v := keyword(keywords, name)
+	if v == "" {
+		return nil
+	}
+	if _, err := strconv.Atoi(v); err != nil {
+		return fmt.Errorf("invalid `%s` value %q", name, v)
+	}
+	fmt.Fprintf(sb, ".%s(%s)", method, v)
+	return nil
+}
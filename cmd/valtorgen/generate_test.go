@@ -0,0 +1,123 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "testdata/models/models.go", nil, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatalf("failed to parse file: %v", err)
+	}
+	want, err := os.ReadFile("testdata/models/schema_gen.golden")
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	g := newGenerator("models", []*ast.File{file})
+	got, err := g.generate([]string{"User"})
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generated code does not match testdata/models/schema_gen.golden, got:\n%s", got)
+	}
+
+	wantSkipped := []string{
+		"embedded field Audit of User: embedded fields are not supported",
+		"field User.Status of type Status: only struct types of the package are supported",
+	}
+	if !slices.Equal(g.skipped, wantSkipped) {
+		t.Errorf("expected skipped fields %q, got %q", wantSkipped, g.skipped)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		typeName string
+	}{
+		{
+			name:     "unknown type",
+			src:      "package models\n\ntype User struct{}\n",
+			typeName: "Order",
+		},
+		{
+			name:     "invalid length",
+			src:      "package models\n\ntype User struct {\n\tName string `jsonschema:\"maxLength=ten\"`\n}\n",
+			typeName: "User",
+		},
+		{
+			name:     "invalid minimum",
+			src:      "package models\n\ntype User struct {\n\tAge int `jsonschema:\"minimum=x\"`\n}\n",
+			typeName: "User",
+		},
+		{
+			name:     "fractional minimum of int",
+			src:      "package models\n\ntype User struct {\n\tAge int `jsonschema:\"minimum=1.5\"`\n}\n",
+			typeName: "User",
+		},
+		{
+			name:     "negative maximum of uint",
+			src:      "package models\n\ntype User struct {\n\tAge uint8 `jsonschema:\"maximum=-1\"`\n}\n",
+			typeName: "User",
+		},
+		{
+			name:     "enum value out of range",
+			src:      "package models\n\ntype User struct {\n\tAge int8 `jsonschema:\"enum=1,enum=300\"`\n}\n",
+			typeName: "User",
+		},
+		{
+			name:     "invalid pattern",
+			src:      "package models\n\ntype User struct {\n\tName string `jsonschema:\"pattern=^[a-z$\"`\n}\n",
+			typeName: "User",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parser.ParseFile(token.NewFileSet(), "models.go", tt.src, parser.SkipObjectResolution)
+			if err != nil {
+				t.Fatalf("failed to parse file: %v", err)
+			}
+			if _, err := newGenerator("models", []*ast.File{file}).generate([]string{tt.typeName}); err == nil {
+				t.Error("expected error, got no error")
+			}
+		})
+	}
+}
+
+func TestParseKeywords(t *testing.T) {
+	keywords := parseKeywords(`required,pattern=^[a-z]{1\,3}$,enum=a,enum=b`)
+
+	if _, ok := keywords["required"]; !ok {
+		t.Error("expected `required` keyword")
+	}
+	if got := keyword(keywords, "pattern"); got != "^[a-z]{1,3}$" {
+		t.Errorf("expected pattern %q, got %q", "^[a-z]{1,3}$", got)
+	}
+	if got := keywords["enum"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("expected enum [a b], got %v", got)
+	}
+}
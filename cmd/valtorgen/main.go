@@ -0,0 +1,124 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command valtorgen generates valtor schema constructors for Go struct types,
+// for use with go:generate:
+//
+//	//go:generate go run github.com/dstotijn/valtor/cmd/valtorgen -type User,Order -o schema_gen.go
+//
+// For each type, a `New<Type>Schema` function is generated that returns an
+// ObjectSchema, without reflection at runtime. Field names are read from `json`
+// tags, and constraints from `jsonschema` tags as used by
+// github.com/invopop/jsonschema (`required`, `minLength`, `maxLength`,
+// `pattern`, `format`, `enum`, `minimum`, `maximum`, `minItems`, `maxItems`
+// and `uniqueItems`), so that struct definitions remain the single source of
+// truth for both. Struct types of the same package that are used as fields or
+// slice items get a constructor as well.
+//
+// A required pointer field fails validation when nil. For other fields,
+// `required` means a non-empty string or slice, and is ignored for numbers and
+// booleans. Fields of types from other packages are not validated. Embedded
+// fields and fields of named non-struct types of the package, e.g.
+// `type Status string`, are skipped with a diagnostic. Patterns and numbers in
+// tags are checked, so that the generated code compiles and initializes.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("valtorgen: ")
+
+	types := flag.String("type", "", "comma-separated list of struct type names (required)")
+	output := flag.String("o", "", "output file (defaults to stdout)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: valtorgen -type <names> [-o <file>] [dir]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *types == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	if err := run(dir, *output, strings.Split(*types, ",")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(dir, output string, typeNames []string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var (
+		fset  = token.NewFileSet()
+		files []*ast.File
+		pkg   string
+	)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if output != "" && filepath.Clean(path) == filepath.Clean(output) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse file: %w", err)
+		}
+		if pkg == "" {
+			pkg = file.Name.Name
+		}
+		if file.Name.Name == pkg {
+			files = append(files, file)
+		}
+	}
+	if pkg == "" {
+		return fmt.Errorf("no Go files found in %q", dir)
+	}
+
+	g := newGenerator(pkg, files)
+	src, err := g.generate(typeNames)
+	if err != nil {
+		return err
+	}
+	for _, skipped := range g.skipped {
+		log.Printf("skipped %s", skipped)
+	}
+
+	if output == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(output, src, 0o644)
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type User struct {
+	Audit
+	ID        string            `json:"id" jsonschema:"required,format=uuid"`
+	Name      string            `json:"name" jsonschema:"required,minLength=1,maxLength=50"`
+	Nickname  *string           `json:"nickname,omitempty" jsonschema:"pattern=^[a-z]+$"`
+	Age       *int              `json:"age" jsonschema:"required,minimum=18,maximum=120"`
+	Role      string            `json:"role" jsonschema:"enum=admin,enum=member"`
+	Tags      []string          `json:"tags" jsonschema:"maxItems=5,uniqueItems"`
+	Address   Address           `json:"address"`
+	Previous  []*Address        `json:"previous_addresses"`
+	Active    bool              `json:"active"`
+	Status    Status            `json:"status"`
+	CreatedAt time.Time         `json:"created_at"`
+	Meta      map[string]string `json:"meta"`
+	Internal  string            `json:"-"`
+	secret    string
+}
+
+type Status string
+
+type Audit struct {
+	Version int `json:"version"`
+}
+
+type Address struct {
+	Street string `json:"street" jsonschema:"required"`
+	Zip    string `jsonschema:"pattern=^[0-9]{4}[A-Z]{2}$"`
+}
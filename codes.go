@@ -0,0 +1,92 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import "slices"
+
+// Codes of the built-in constraints, as used for ConstraintError.Code and by
+// Summarize and NewReport. They are stable across releases, so clients can
+// branch on them instead of on error messages.
+const (
+	CodeRequired      = "required"       // ErrValueRequired.
+	CodeFormat        = "format"         // Invalid format, see formats.ErrInvalidFormat.
+	CodeInvalid       = "invalid"        // Errors without a code, e.g. of custom validators.
+	CodeType          = "type"           // Value of an unexpected type.
+	CodeJSON          = "json"           // Invalid JSON.
+	CodeUnknownField  = "unknown_field"  // ErrUnknownField.
+	CodeReadOnly      = "read_only"      // ErrReadOnly.
+	CodeWriteOnly     = "write_only"     // ErrWriteOnly.
+	CodeDeprecated    = "deprecated"     // ErrDeprecated.
+	CodeMinLength     = "min_length"     // Length of a string or array below the minimum.
+	CodeMaxLength     = "max_length"     // Length of a string or array above the maximum.
+	CodeLength        = "length"         // Length of a string or array other than required.
+	CodeMin           = "min"            // Number below the minimum.
+	CodeMax           = "max"            // Number above the maximum.
+	CodeBetween       = "between"        // Number outside of a range.
+	CodeNotBetween    = "not_between"    // Number inside of an excluded range.
+	CodePositive      = "positive"       // Number that is not positive.
+	CodeNegative      = "negative"       // Number that is not negative.
+	CodeNonNegative   = "non_negative"   // Negative number.
+	CodeNonZero       = "non_zero"       // Zero number.
+	CodeFinite        = "finite"         // Infinite or NaN number.
+	CodeInteger       = "integer"        // Number with a fraction.
+	CodeDecimal       = "decimal"        // Invalid decimal number.
+	CodeScale         = "scale"          // Decimal number with too many digits after the decimal point.
+	CodePattern       = "pattern"        // String that doesn't match a pattern.
+	CodeContains      = "contains"       // String or set that doesn't contain a value.
+	CodeNotContains   = "not_contains"   // String that contains a forbidden substring.
+	CodePrefix        = "prefix"         // String without the required prefix.
+	CodeSuffix        = "suffix"         // String without the required suffix.
+	CodeAlphanumeric  = "alphanumeric"   // String with other characters than letters and digits.
+	CodeASCII         = "ascii"          // String with non-ASCII characters.
+	CodeControlChars  = "control_chars"  // String with control characters.
+	CodeOneOf         = "one_of"         // Value that is not one of the allowed values.
+	CodeTrue          = "true"           // Bool that must be true.
+	CodeFalse         = "false"          // Bool that must be false.
+	CodeNonEmpty      = "non_empty"      // Empty array.
+	CodeUnique        = "unique"         // Array with duplicate items.
+	CodeSorted        = "sorted"         // Array with items out of order.
+	CodeMinProperties = "min_properties" // Map with too few properties.
+	CodeMaxProperties = "max_properties" // Map with too many properties.
+	CodeDiscriminator = "discriminator"  // Unknown discriminator of a raw message.
+	CodeDeliverable   = "deliverable"    // Email address whose domain can't receive mail, see ErrUndeliverable.
+	CodeLimit         = "limit"          // Payload that exceeds a safety limit, see ErrLimitExceeded.
+	CodeHTML          = "html"           // String with HTML markup.
+	CodeSQLMeta       = "sql_meta"       // String with SQL metacharacters.
+	CodeFilename      = "filename"       // String that is not a safe file name.
+	CodeDenied        = "denied"         // Value that is on a denylist.
+	CodeDeniedWord    = "denied_word"    // String with a word that is on a denylist.
+	CodeExpression    = "expression"     // Value for which an expression, e.g. of package valtorcel, doesn't hold.
+)
+
+// codes are all codes, in sorted order.
+var codes = slices.Sorted(slices.Values([]string{
+	CodeRequired, CodeFormat, CodeInvalid, CodeType, CodeJSON, CodeUnknownField,
+	CodeReadOnly, CodeWriteOnly, CodeDeprecated, CodeMinLength, CodeMaxLength,
+	CodeLength, CodeMin, CodeMax, CodeBetween, CodeNotBetween, CodePositive,
+	CodeNegative, CodeNonNegative, CodeNonZero, CodeFinite, CodeInteger,
+	CodeDecimal, CodeScale, CodePattern, CodeContains, CodeNotContains,
+	CodePrefix, CodeSuffix, CodeAlphanumeric, CodeASCII, CodeControlChars,
+	CodeOneOf, CodeTrue, CodeFalse, CodeNonEmpty, CodeUnique, CodeSorted,
+	CodeMinProperties, CodeMaxProperties, CodeDiscriminator, CodeDeliverable,
+	CodeLimit, CodeHTML, CodeSQLMeta, CodeFilename, CodeDenied, CodeDeniedWord,
+	CodeExpression,
+}))
+
+// Codes returns the codes of all built-in constraints, in sorted order, e.g.
+// to generate documentation or client code.
+func Codes() []string {
+	return slices.Clone(codes)
+}
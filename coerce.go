@@ -0,0 +1,175 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// number is the constraint of the types that CoerceNumber converts to.
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// CoerceMode determines how CoerceNumberMode handles values that don't fit
+// the target type exactly. The modes are ordered from strict to lenient.
+type CoerceMode int
+
+const (
+	// CoerceStrict fails on fractional values for integer types and on
+	// values out of the range of the type. This is the mode of CoerceNumber.
+	CoerceStrict CoerceMode = iota
+	// CoerceTruncate drops the fractional part of values for integer types,
+	// e.g. 1.9 becomes 1 and -1.9 becomes -1, and fails on values out of the
+	// range of the type.
+	CoerceTruncate
+	// CoerceClamp is like CoerceTruncate, but saturates values out of the
+	// range of the type, including infinities, to its minimum or maximum.
+	CoerceClamp
+)
+
+// CoerceNumber converts a value of any numeric type, e.g. a float64 decoded
+// from JSON, to numeric type T. It fails if the value is not a number, if it
+// is out of the range of T, or if it has a fractional part and T is an integer
+// type. A json.Number (as decoded with json.Decoder.UseNumber) is parsed
+// exactly when it is an integer.
+func CoerceNumber[T number](value any) (T, error) {
+	return CoerceNumberMode[T](value, CoerceStrict)
+}
+
+// CoerceNumberMode is like CoerceNumber, but handles fractional values for
+// integer types and values out of the range of T according to mode. NaN and
+// values that are not numbers always fail.
+func CoerceNumberMode[T number](value any, mode CoerceMode) (T, error) {
+	if n, ok := value.(json.Number); ok {
+		return coerceJSONNumber[T](n, mode)
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := v.Int()
+		if t := T(i); int64(t) == i && (t < 0) == (i < 0) {
+			return t, nil
+		}
+		if mode == CoerceClamp {
+			return bound[T](i < 0), nil
+		}
+		return 0, outOfRange[T](value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := v.Uint()
+		if t := T(u); uint64(t) == u && t >= 0 {
+			return t, nil
+		}
+		if mode == CoerceClamp {
+			return bound[T](false), nil
+		}
+		return 0, outOfRange[T](value)
+	case reflect.Float32, reflect.Float64:
+		return coerceFloat[T](v.Float(), mode)
+	default:
+		return 0, fmt.Errorf("expected numeric value, got %T", value)
+	}
+}
+
+func coerceFloat[T number](f float64, mode CoerceMode) (T, error) {
+	half := 0.5
+	if T(half) != 0 {
+		// T is a float type; only float32 can overflow.
+		if math.IsInf(f, 0) || math.IsNaN(f) || !math.IsInf(float64(T(f)), 0) {
+			return T(f), nil
+		}
+		if mode == CoerceClamp {
+			return bound[T](f < 0), nil
+		}
+		return 0, outOfRange[T](f)
+	}
+
+	if math.IsNaN(f) || (math.IsInf(f, 0) && mode != CoerceClamp) {
+		return 0, fmt.Errorf("expected integer value, got %v", f)
+	}
+	if f != math.Trunc(f) {
+		if mode == CoerceStrict {
+			return 0, fmt.Errorf("expected integer value, got %v", f)
+		}
+		f = math.Trunc(f)
+	}
+	// Conversions of out of range floats are implementation-specific, so the
+	// bounds are checked by converting back, which is exact for whole numbers
+	// within range.
+	if t := T(f); float64(t) == f && f >= -math.MaxInt64-1 && f < math.MaxUint64 {
+		return t, nil
+	}
+	if mode == CoerceClamp {
+		return bound[T](f < 0), nil
+	}
+	return 0, outOfRange[T](f)
+}
+
+func coerceJSONNumber[T number](n json.Number, mode CoerceMode) (T, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return CoerceNumberMode[T](i, mode)
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return CoerceNumberMode[T](u, mode)
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if errors.Is(err, strconv.ErrRange) {
+		if mode != CoerceClamp {
+			return 0, outOfRange[T](n)
+		}
+		// f is infinite on overflow, and zero on underflow.
+		return coerceFloat[T](f, mode)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("expected numeric value, got %q", n)
+	}
+	return coerceFloat[T](f, mode)
+}
+
+// bound returns the minimum value of T if negative, or else its maximum value.
+func bound[T number](negative bool) T {
+	t := reflect.TypeFor[T]()
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		maxFloat := math.MaxFloat64
+		if t.Kind() == reflect.Float32 {
+			maxFloat = math.MaxFloat32
+		}
+		if negative {
+			return T(-maxFloat)
+		}
+		return T(maxFloat)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if negative {
+			return 0
+		}
+		return T(uint64(1)<<t.Bits() - 1)
+	default:
+		if negative {
+			return T(int64(-1) << (t.Bits() - 1))
+		}
+		return T(int64(1)<<(t.Bits()-1) - 1)
+	}
+}
+
+func outOfRange[T any](value any) error {
+	return fmt.Errorf("value %v is out of range for %v", value, reflect.TypeFor[T]())
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// Constraint describes a built-in constraint of a schema, e.g. to document it.
+// The keyword is that of JSON Schema if there is one, e.g. `minLength` for
+// StringSchema.Min, or else the constraint code, e.g. `prefix` (see Codes).
+// Custom validators are not described.
+type Constraint struct {
+	Keyword string `json:"keyword"`
+	Value   any    `json:"value"`
+}
+
+// Describer is implemented by schemas that describe their built-in
+// constraints, such as StringSchema and NumberSchema, and those of the schemas
+// nested in them (see Description).
+type Describer interface {
+	Constraints() []Constraint
+	Describe() Description
+}
+
+// Constraints returns the built-in constraints of the schema, in the order in
+// which they were added.
+func (s *Schema[T]) Constraints() []Constraint {
+	return slices.Clone(s.constraints)
+}
+
+// describe records a built-in constraint of the schema.
+func (s *Schema[T]) describe(keyword string, value any) {
+	s.constraints = append(s.constraints, Constraint{Keyword: keyword, Value: value})
+}
+
+// FieldOf adds a field validator for the value returned by getter, like Field
+// with ValidateField, and returns the schema for chaining. Unlike with Field,
+// the constraints of the field's schema are known to the object schema (see
+// FieldConstraints), if it implements Describer, and the context passed to
+// ValidateContext is passed on to the field's schema.
+//
+// As a getter can't change the field, Run fails with ErrTransformNotApplied
+// if the field's schema has transformations (see Schema.Transform). Use
+// FieldRef for such fields.
+func FieldOf[T any, F any](s *ObjectSchema[T], fieldName string, getter func(T) F, schema Validator[F]) *ObjectSchema[T] {
+	t, _ := schema.(transformer[F])
+	validate := contextValidate(schema)
+	fieldOf(s, fieldName, schema, func(ctx context.Context, value T) error {
+		if t != nil && t.hasTransforms() && ctx.Value(runKey{}) != nil {
+			return ErrTransformNotApplied
+		}
+		return validate(ctx, getter(value))
+	})
+	return s
+}
+
+// FieldPtrOf is like FieldOf for an optional pointer field: the pointed-to
+// value is validated with schema when it is not nil. See Ptr for how nil
+// values are handled.
+func FieldPtrOf[T any, F any](s *ObjectSchema[T], fieldName string, getter func(T) *F, schema Validator[F]) *ObjectSchema[T] {
+	return FieldOf(s, fieldName, getter, Ptr(schema))
+}
+
+// RequiredFieldPtrOf is like FieldPtrOf, but a nil pointer fails with
+// ErrValueRequired.
+func RequiredFieldPtrOf[T any, F any](s *ObjectSchema[T], fieldName string, getter func(T) *F, schema Validator[F]) *ObjectSchema[T] {
+	return FieldOf(s, fieldName, getter, Ptr(schema).Required())
+}
+
+// fieldOf adds the field validator of a field with the given schema, whose
+// constraints are known to the object schema if it implements Describer.
+func fieldOf[T any](s *ObjectSchema[T], fieldName string, schema any, validateFn func(context.Context, T) error) {
+	s.FieldContext(fieldName, validateFn)
+	if describer, ok := schema.(Describer); ok {
+		s.fields[s.fieldIndex(fieldName)].describer = describer
+	}
+}
+
+// FieldConstraints returns an iterator over the field names and the
+// constraints of the fields, in the order in which the fields were added. The
+// constraints of fields that were not added with FieldOf are unknown and nil,
+// except that fields added with RequiredFields are described as `required`.
+func (s *ObjectSchema[T]) FieldConstraints() iter.Seq2[string, []Constraint] {
+	return func(yield func(string, []Constraint) bool) {
+		for _, field := range s.fields {
+			if !yield(field.name, s.fieldConstraints(field)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ValidateJSON decodes a JSON document from r into a value of type T and
+// validates it against the schema. Numbers are decoded as json.Number when T
+// (or one of its fields) is an interface type, so that no precision is lost.
+// Input that cannot be decoded fails with a DecodeError.
+func (s *Schema[T]) ValidateJSON(r io.Reader) error {
+	return validateJSON[T](context.Background(), s, nil, r)
+}
+
+// ValidateJSONBytes decodes a JSON document into a value of type T and
+// validates it against the schema. See ValidateJSON.
+func (s *Schema[T]) ValidateJSONBytes(data []byte) error {
+	return validateJSON[T](context.Background(), s, nil, bytes.NewReader(data))
+}
+
+// ValidateJSON decodes a JSON document from r into a value of type T and
+// validates it against the schema. See Schema.ValidateJSON.
+func (s *ObjectSchema[T]) ValidateJSON(r io.Reader) error {
+	return validateJSON[T](context.Background(), s, nil, r)
+}
+
+// ValidateJSONBytes decodes a JSON document into a value of type T and
+// validates it against the schema. See Schema.ValidateJSON.
+func (s *ObjectSchema[T]) ValidateJSONBytes(data []byte) error {
+	return validateJSON[T](context.Background(), s, nil, bytes.NewReader(data))
+}
+
+// validateJSON decodes a JSON document from r and validates it. If d is not
+// nil, or the validator has safety limits (see Limits), the tokens of the
+// document are checked first (see ValidateTokens), so that documents that
+// exceed a limit or maximum length are rejected without being decoded.
+func validateJSON[T any](ctx context.Context, validator Validator[T], d *Description, r io.Reader) error {
+	var limits Limits
+	if l, ok := validator.(limiter); ok {
+		limits = l.payloadLimits()
+	}
+	if d != nil || limits != (Limits{}) {
+		var buf bytes.Buffer
+		c := &tokenChecker{dec: json.NewDecoder(io.TeeReader(r, &buf)), limits: limitChecker{limits: limits}}
+		var decodeErr *DecodeError
+		if err := c.check(d, "", 0); err != nil && !errors.As(err, &decodeErr) {
+			return err
+		}
+		// Decode the buffered document and whatever follows it, so that
+		// malformed documents and trailing data are reported as usual.
+		r = io.MultiReader(&buf, r)
+	}
+
+	value, err := DecodeJSON[T](r)
+	if err != nil {
+		return err
+	}
+	return validateContext(ctx, validator, value)
+}
+
+// DecodeJSON decodes a single JSON document from r into a value of type T, as
+// ValidateJSON does, e.g. to validate payloads with other validators than
+// schemas. Numbers are decoded as json.Number when T (or one of its fields) is
+// an interface type. Input that cannot be decoded, or that has data after the
+// document, fails with a DecodeError with the offset and, for type
+// mismatches, the path of the value.
+func DecodeJSON[T any](r io.Reader) (T, error) {
+	var value T
+
+	cr := &countingReader{r: r}
+	dec := json.NewDecoder(cr)
+	dec.UseNumber()
+
+	if err := dec.Decode(&value); err != nil {
+		var (
+			syntaxErr *json.SyntaxError
+			typeErr   *json.UnmarshalTypeError
+		)
+		switch {
+		case errors.As(err, &syntaxErr):
+			return value, &DecodeError{Offset: syntaxErr.Offset, Err: err}
+		case errors.As(err, &typeErr):
+			return value, &DecodeError{Offset: typeErr.Offset, Path: typeErr.Field, Err: err}
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			// The input is empty or truncated.
+			return value, &DecodeError{Offset: cr.n, Err: io.ErrUnexpectedEOF}
+		default:
+			return value, &DecodeError{Offset: dec.InputOffset(), Err: err}
+		}
+	}
+
+	offset := dec.InputOffset()
+	if _, err := dec.Token(); err != io.EOF {
+		return value, &DecodeError{Offset: offset, Err: errors.New("unexpected data after top-level value")}
+	}
+
+	return value, nil
+}
+
+// countingReader counts the bytes read, to report the offset of truncated
+// input.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Config holds the default configuration of new schemas (see SetDefaults).
+// Options passed to a constructor take precedence over it.
+type Config struct {
+	// FailFast stops validation at the first error. Without it, schemas
+	// return all errors, as with WithErrorCollector.
+	FailFast bool
+	// MaxErrors is the maximum number of errors that schemas collect if they
+	// don't fail fast (see WithMaxErrors), or 0 for no maximum.
+	MaxErrors int
+	// LengthMode is how string schemas measure length.
+	LengthMode LengthMode
+	// Locale selects the catalog registered with RegisterMessages for the
+	// messages of constraint errors, e.g. `de`. Without a catalog for the
+	// locale, the built-in messages are used.
+	Locale string
+}
+
+// defaults is the default configuration of new schemas, if it was changed
+// with SetDefaults. It is loaded lazily, as package-level schemas are created
+// before init functions run.
+var defaults atomic.Pointer[Config]
+
+// Defaults returns the default configuration of new schemas. It is
+// `Config{FailFast: true}` unless it was changed with SetDefaults.
+func Defaults() Config {
+	if cfg := defaults.Load(); cfg != nil {
+		return *cfg
+	}
+	return Config{FailFast: true}
+}
+
+// SetDefaults sets the default configuration of schemas created afterwards,
+// e.g. once at program start, so that it doesn't have to be repeated with
+// options on every schema. Existing schemas are not affected. Start from
+// Defaults to change a single setting, as the zero value of Config doesn't
+// fail fast. It is safe for concurrent use.
+func SetDefaults(cfg Config) {
+	defaults.Store(&cfg)
+}
+
+// WithFailFast makes a schema stop validation at the first error, e.g. to
+// override a default configuration that doesn't fail fast.
+func WithFailFast() Option {
+	return func(cfg *config) {
+		cfg.collect = false
+	}
+}
+
+// WithLocale sets the locale of which the catalog registered with
+// RegisterMessages provides the messages of constraint errors. A catalog set
+// with WithMessages takes precedence.
+func WithLocale(locale string) Option {
+	return func(cfg *config) {
+		cfg.locale = locale
+	}
+}
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = make(map[string]MessageCatalog)
+)
+
+// RegisterMessages registers the message catalog for a locale, e.g. `de`, to
+// be used by schemas with the locale (see WithLocale and Config.Locale). It
+// replaces a catalog that was registered earlier for the locale. Schemas look
+// up the catalog when they fail, so it may be registered after they were
+// created.
+func RegisterMessages(locale string, catalog MessageCatalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalogs[locale] = catalog
+}
+
+// catalog returns the message catalog of a schema configuration, if any.
+func (cfg config) catalog() MessageCatalog {
+	if cfg.messages != nil || cfg.locale == "" {
+		return cfg.messages
+	}
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	return catalogs[cfg.locale]
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"encoding/json"
+	"iter"
+	"math/big"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Description is a structured description of a schema and the schemas nested
+// in it, e.g. to generate documentation, admin UIs or client-side hints. Only
+// built-in constraints are described, and nested schemas only if they were
+// added with FieldOf, ArraySchema.ItemsOf or WhenType.
+type Description struct {
+	// Type is the JSON type of the values, e.g. `string` or `object`, or
+	// empty if values can be of any type.
+	Type        string             `json:"type,omitempty"`
+	Constraints []Constraint       `json:"constraints,omitempty"`
+	Fields      []FieldDescription `json:"fields,omitempty"`
+	Items       *Description       `json:"items,omitempty"`
+	// Variants are the descriptions of the branches of an AnySchema, of
+	// which values must match one.
+	Variants []Description `json:"variants,omitempty"`
+}
+
+// FieldDescription is the description of a field of an object schema.
+type FieldDescription struct {
+	Name string `json:"name"`
+	Description
+}
+
+// Describe returns a description of the schema.
+func (s *Schema[T]) Describe() Description {
+	return Description{
+		Type:        jsonType(reflect.TypeFor[T]()),
+		Constraints: s.Constraints(),
+	}
+}
+
+// Describe returns a description of the schema and its fields, in the order in
+// which they were added.
+func (s *ObjectSchema[T]) Describe() Description {
+	d := s.Schema.Describe()
+	d.Type = "object"
+	for _, field := range s.fields {
+		fd := FieldDescription{Name: field.name}
+		if field.describer != nil {
+			fd.Description = field.describer.Describe()
+		}
+		fd.Constraints = s.fieldConstraints(field)
+		d.Fields = append(d.Fields, fd)
+	}
+	return d
+}
+
+// Describe returns a description of the schema and its items.
+func (s *ArraySchema[T]) Describe() Description {
+	d := s.Schema.Describe()
+	if s.itemDescriber != nil {
+		items := s.itemDescriber.Describe()
+		d.Items = &items
+	}
+	return d
+}
+
+// Describe returns a description of the schema and its branches, in the order
+// in which they were added. Branches whose schema doesn't implement Describer
+// are described by their type only.
+func (s *AnySchema) Describe() Description {
+	d := s.Schema.Describe()
+	for _, branch := range s.branches {
+		d.Variants = append(d.Variants, branch.description)
+	}
+	return d
+}
+
+// Describe returns a description of the schema.
+func (s *BigIntSchema) Describe() Description {
+	d := s.Schema.Describe()
+	d.Type = "integer"
+	return d
+}
+
+// Rules returns an iterator over the constraints of the description and of
+// its nested descriptions, with the path of the value they apply to, e.g.
+// `address.zip`, or `tags[]` for the items of an array. The path of the
+// described schema itself is empty, and variants have the path of the
+// described schema.
+func (d Description) Rules() iter.Seq2[string, Constraint] {
+	return func(yield func(string, Constraint) bool) {
+		d.rules("", yield)
+	}
+}
+
+func (d Description) rules(path string, yield func(string, Constraint) bool) bool {
+	for _, c := range d.Constraints {
+		if !yield(path, c) {
+			return false
+		}
+	}
+	for _, field := range d.Fields {
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		if !field.Description.rules(fieldPath, yield) {
+			return false
+		}
+	}
+	if d.Items != nil && !d.Items.rules(path+"[]", yield) {
+		return false
+	}
+	for _, variant := range d.Variants {
+		if !variant.rules(path, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	bigIntType     = reflect.TypeFor[big.Int]()
+	timeType       = reflect.TypeFor[time.Time]()
+)
+
+// jsonType returns the JSON type of values of Go type t, as encoded by
+// encoding/json, or an empty string if it can be any type.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case rawMessageType:
+		return ""
+	case bigIntType:
+		return "integer"
+	case timeType:
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return "string"
+		}
+		return "array"
+	case reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+// fieldConstraints returns the constraints of a field, including `required`
+// if it was added with RequiredFields.
+func (s *ObjectSchema[T]) fieldConstraints(field objectField[T]) []Constraint {
+	var constraints []Constraint
+	required := slices.Contains(s.requiredFields, field.name)
+	if required {
+		constraints = append(constraints, Constraint{Keyword: "required", Value: true})
+	}
+	if field.describer != nil {
+		for _, c := range field.describer.Constraints() {
+			if c.Keyword == "required" && required {
+				continue
+			}
+			constraints = append(constraints, c)
+		}
+	}
+	return constraints
+}
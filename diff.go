@@ -0,0 +1,308 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// DiffErrors validates a previous and a current value against the schema and
+// returns the errors of the current value that are not reported for the
+// previous value, e.g. to give incremental feedback in an editor that
+// validates on each change. Joined errors (see ObjectSchema.AllErrors) are
+// compared individually, by message. A ConstraintError is compared by its code
+// instead, so that e.g. a string that was already too long and gets longer is
+// not reported.
+func DiffErrors[T any](schema Validator[T], previous, current T) []error {
+	currentErrs := flattenErrors(schema.Validate(current))
+	if len(currentErrs) == 0 {
+		return nil
+	}
+
+	previousMsgs := make(map[string]struct{})
+	for _, err := range flattenErrors(schema.Validate(previous)) {
+		previousMsgs[errorKey(err)] = struct{}{}
+	}
+
+	var errs []error
+	for _, err := range currentErrs {
+		if _, ok := previousMsgs[errorKey(err)]; !ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// flattenErrors returns the errors joined in err, recursively, or err itself if
+// it is not a joined error.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, flattenErrors(err)...)
+	}
+	return errs
+}
+
+// errorKey returns the key by which DiffErrors compares an error: its message,
+// with the message of a wrapped ConstraintError replaced by its code.
+func errorKey(err error) string {
+	var constraintErr *ConstraintError
+	if errors.As(err, &constraintErr) {
+		return strings.Replace(err.Error(), constraintErr.Message, constraintErr.Code, 1)
+	}
+	return err.Error()
+}
+
+// ChangeKind is the kind of a change between two schemas.
+type ChangeKind int
+
+const (
+	// ChangeAdded is a constraint that only the current schema has.
+	ChangeAdded ChangeKind = iota
+	// ChangeRemoved is a constraint that only the previous schema has.
+	ChangeRemoved
+	// ChangeTightened is a constraint of which the current value rejects
+	// more values, e.g. a lower maximum length.
+	ChangeTightened
+	// ChangeLoosened is a constraint of which the current value accepts more
+	// values, e.g. an enum with more values.
+	ChangeLoosened
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeTightened:
+		return "tightened"
+	case ChangeLoosened:
+		return "loosened"
+	default:
+		return "unknown"
+	}
+}
+
+// Change is a difference in a constraint between the previous and the
+// current version of a schema, as returned by Diff.
+type Change struct {
+	Kind    ChangeKind
+	Path    string // Path of the constrained value, see Description.Rules.
+	Keyword string // Keyword of the constraint, e.g. `maxLength`, or `type` for the JSON type.
+	Old     any    // Value of the constraint in the previous schema, nil if added.
+	New     any    // Value of the constraint in the current schema, nil if removed.
+}
+
+// Breaking reports whether the change can make values that were valid for the
+// previous schema invalid, i.e. whether it is added or tightened.
+func (c Change) Breaking() bool {
+	return c.Kind == ChangeAdded || c.Kind == ChangeTightened
+}
+
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "."
+	}
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("added %s %s: %v", path, c.Keyword, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("removed %s %s: %v", path, c.Keyword, c.Old)
+	default:
+		return fmt.Sprintf("%s %s %s: %v -> %v", c.Kind, path, c.Keyword, c.Old, c.New)
+	}
+}
+
+// lowerBounds and upperBounds are the keywords of which Diff compares the
+// values, to tell a tightened bound from a loosened one.
+var (
+	lowerBounds = map[string]bool{"minLength": true, "minItems": true, "minimum": true, "exclusiveMinimum": true}
+	upperBounds = map[string]bool{"maxLength": true, "maxItems": true, "maximum": true, "exclusiveMaximum": true}
+)
+
+// Diff compares the descriptions of the previous and the current version of a
+// schema (see Describe) and returns the changes in their constraints and JSON
+// types, e.g. to fail a CI build on breaking changes (see Change.Breaking) to
+// an API contract. Changes are returned in the order of the current schema,
+// followed by the removed constraints in the order of the previous schema.
+//
+// Bounds, such as `maxLength`, are tightened or loosened if their value
+// changes, and an enum is tightened or loosened if its values are a subset or
+// a superset of the previous values. Other constraints, and enums that are
+// neither, are compared by value, so that a changed pattern is reported as a
+// removed and an added pattern. The same goes for bounds that aren't numbers.
+// If a bound is described multiple times for the same path, e.g. for variants
+// of a union, the strictest value is compared.
+func Diff(previous, current Describer) []Change {
+	oldRules, oldKeys := diffRules(previous.Describe())
+	newRules, newKeys := diffRules(current.Describe())
+
+	var changes []Change
+	for _, key := range newKeys {
+		newValue := newRules[key]
+		oldValue, ok := oldRules[key]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Path: key.path, Keyword: key.keyword, New: newValue})
+			continue
+		}
+		if key.value != "" {
+			continue
+		}
+		kind, changed := compareRule(key.keyword, oldValue, newValue)
+		if !changed {
+			continue
+		}
+		if kind == ChangeAdded {
+			changes = append(changes,
+				Change{Kind: ChangeRemoved, Path: key.path, Keyword: key.keyword, Old: oldValue},
+				Change{Kind: ChangeAdded, Path: key.path, Keyword: key.keyword, New: newValue})
+			continue
+		}
+		changes = append(changes, Change{Kind: kind, Path: key.path, Keyword: key.keyword, Old: oldValue, New: newValue})
+	}
+	for _, key := range oldKeys {
+		if _, ok := newRules[key]; !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Path: key.path, Keyword: key.keyword, Old: oldRules[key]})
+		}
+	}
+	return changes
+}
+
+// ruleKey identifies a constraint for Diff. Bounds and enums are identified by
+// their path and keyword; other constraints also by their value.
+type ruleKey struct {
+	path, keyword, value string
+}
+
+// diffRules returns the constraints of a description by key, and the keys in
+// order, with the JSON types of the description and of its nested
+// descriptions as `type` constraints.
+func diffRules(d Description) (map[ruleKey]any, []ruleKey) {
+	rules := make(map[ruleKey]any)
+	var keys []ruleKey
+	add := func(path, keyword string, value any) {
+		key := ruleKey{path: path, keyword: keyword}
+		if !lowerBounds[keyword] && !upperBounds[keyword] && keyword != "enum" {
+			key.value = fmt.Sprintf("%#v", value)
+		}
+		existing, ok := rules[key]
+		if !ok {
+			keys = append(keys, key)
+			rules[key] = value
+			return
+		}
+		if kind, changed := compareRule(keyword, existing, value); changed && kind == ChangeTightened {
+			rules[key] = value
+		}
+	}
+
+	var walk func(d Description, path string)
+	walk = func(d Description, path string) {
+		if d.Type != "" {
+			add(path, "type", d.Type)
+		}
+		for _, c := range d.Constraints {
+			add(path, c.Keyword, c.Value)
+		}
+		for _, field := range d.Fields {
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			walk(field.Description, fieldPath)
+		}
+		if d.Items != nil {
+			walk(*d.Items, path+"[]")
+		}
+		for _, variant := range d.Variants {
+			walk(variant, path)
+		}
+	}
+	walk(d, "")
+	return rules, keys
+}
+
+// compareRule compares the old and new value of a bound or enum, and reports
+// whether they differ. Enums that are neither a subset nor a superset, and
+// values that can't be compared, are reported as ChangeAdded.
+func compareRule(keyword string, oldValue, newValue any) (ChangeKind, bool) {
+	if keyword == "enum" {
+		oldValues, newValues := enumValues(oldValue), enumValues(newValue)
+		oldInNew, newInOld := subset(oldValues, newValues), subset(newValues, oldValues)
+		switch {
+		case oldInNew && newInOld:
+			return 0, false
+		case newInOld:
+			return ChangeTightened, true
+		case oldInNew:
+			return ChangeLoosened, true
+		default:
+			return ChangeAdded, true
+		}
+	}
+
+	oldRat, ok1 := new(big.Rat).SetString(fmt.Sprint(oldValue))
+	newRat, ok2 := new(big.Rat).SetString(fmt.Sprint(newValue))
+	if !ok1 || !ok2 {
+		return ChangeAdded, fmt.Sprint(oldValue) != fmt.Sprint(newValue)
+	}
+	cmp := newRat.Cmp(oldRat)
+	switch {
+	case cmp == 0:
+		return 0, false
+	case (cmp > 0) == lowerBounds[keyword]:
+		return ChangeTightened, true
+	default:
+		return ChangeLoosened, true
+	}
+}
+
+// enumValues returns the values of an enum, formatted with %#v.
+func enumValues(enum any) map[string]bool {
+	values := make(map[string]bool)
+	v := reflect.ValueOf(enum)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		values[fmt.Sprintf("%#v", enum)] = true
+		return values
+	}
+	for i := range v.Len() {
+		values[fmt.Sprintf("%#v", v.Index(i).Interface())] = true
+	}
+	return values
+}
+
+// subset reports whether all values of a are in b.
+func subset(a, b map[string]bool) bool {
+	for v := range a {
+		if !b[v] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/valtor/formats"
+)
+
+// ErrUndeliverable is wrapped by the error of StringSchema.Email when the
+// domain of an email address has no MX, A or AAAA records, or a null MX record
+// (RFC 7505), so that it can't receive mail.
+var ErrUndeliverable = errors.New("email address is undeliverable")
+
+// DefaultLookupTimeout is the timeout of the DNS lookups of WithMXCheck,
+// unless set with WithLookupTimeout.
+const DefaultLookupTimeout = 5 * time.Second
+
+// Resolver looks up DNS records. It is implemented by *net.Resolver.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailOption configures StringSchema.Email.
+type EmailOption func(*emailConfig)
+
+type emailConfig struct {
+	resolver Resolver
+	timeout  time.Duration
+}
+
+// WithMXCheck makes StringSchema.Email check that the domain of the email
+// address can receive mail, by looking up its MX records with the resolver,
+// or net.DefaultResolver if nil. Like in RFC 5321, a domain without MX
+// records can receive mail if it has A or AAAA records.
+func WithMXCheck(resolver Resolver) EmailOption {
+	return func(cfg *emailConfig) {
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		cfg.resolver = resolver
+	}
+}
+
+// WithLookupTimeout sets the timeout of the DNS lookups of WithMXCheck, which
+// defaults to DefaultLookupTimeout. A timeout of 0 means that lookups are only
+// bound by the validation context.
+func WithLookupTimeout(timeout time.Duration) EmailOption {
+	return func(cfg *emailConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// Email adds a validator that checks if the string is an email address, like
+// Format("email"), and returns the schema for chaining. With WithMXCheck, the
+// domain of a valid address must also be able to receive mail, e.g. to reduce
+// bounces of sign-up emails. This check runs with the validation context and
+// fails with an error that wraps ErrUndeliverable. Lookup errors other than a
+// domain that doesn't exist, e.g. timeouts, are returned as is, so that they
+// can be told apart from undeliverable addresses.
+func (s *StringSchema) Email(opts ...EmailOption) *StringSchema {
+	cfg := &emailConfig{
+		timeout: DefaultLookupTimeout,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	s.describe("format", "email")
+	s.validators = append(s.validators, func(ctx context.Context, v string) error {
+		if err := formats.Email(v); err != nil {
+			return enforce(ctx, ProfileFromContext(ctx).AssertFormats, err)
+		}
+		if cfg.resolver == nil {
+			return nil
+		}
+		_, domain, _ := strings.Cut(v, "@")
+		return checkDeliverable(ctx, cfg, domain)
+	})
+	return s
+}
+
+// checkDeliverable checks that the domain has a mail server.
+func checkDeliverable(ctx context.Context, cfg *emailConfig, domain string) error {
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	undeliverable := func() error {
+		return constraintError(CodeDeliverable, map[string]any{"domain": domain}, "email domain %q can't receive mail", domain)
+	}
+
+	mxs, err := cfg.resolver.LookupMX(ctx, domain)
+	switch {
+	case err != nil && !isNotFound(err):
+		return fmt.Errorf("failed to look up MX records of %q: %w", domain, err)
+	case len(mxs) == 1 && mxs[0].Host == ".":
+		// A null MX record, see RFC 7505.
+		return undeliverable()
+	case len(mxs) > 0:
+		return nil
+	}
+
+	hosts, err := cfg.resolver.LookupHost(ctx, domain)
+	switch {
+	case err != nil && !isNotFound(err):
+		return fmt.Errorf("failed to look up hosts of %q: %w", domain, err)
+	case len(hosts) == 0:
+		return undeliverable()
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
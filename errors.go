@@ -0,0 +1,279 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dstotijn/valtor/formats"
+)
+
+// ConstraintError is returned when a value violates a built-in constraint,
+// such as a minimum length. Besides the message, it carries a code that
+// identifies the constraint and the parameters of the violation, including
+// the actual value or length that was observed. Use errors.As to retrieve it
+// from a wrapped error. For common constraints, it wraps a typed error, such
+// as *MinLengthError, which can be retrieved with errors.As as well.
+type ConstraintError struct {
+	Code    string         // Constraint code, e.g. `max_length`.
+	Params  map[string]any // Parameters, e.g. `max` and `actual`.
+	Message string
+	Err     error // Typed error, e.g. *MaxLengthError, or nil.
+}
+
+func (e *ConstraintError) Error() string {
+	return e.Message
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// constraintError creates a ConstraintError with a formatted message.
+func constraintError(code string, params map[string]any, format string, args ...any) error {
+	return &ConstraintError{
+		Code:    code,
+		Params:  params,
+		Message: fmt.Sprintf(format, args...),
+		Err:     typedError(code, params),
+	}
+}
+
+// typedError returns the typed error for the constraint code, or nil if there
+// is none.
+func typedError(code string, params map[string]any) error {
+	switch code {
+	case CodeMinLength:
+		return &MinLengthError{Min: params["min"].(int), Actual: params["actual"].(int)}
+	case CodeMaxLength:
+		return &MaxLengthError{Max: params["max"].(int), Actual: params["actual"].(int)}
+	case CodeLength:
+		return &LengthError{Length: params["length"].(int), Actual: params["actual"].(int)}
+	case CodeMin:
+		return &MinError{Min: params["min"], Actual: params["actual"]}
+	case CodeMax:
+		return &MaxError{Max: params["max"], Actual: params["actual"]}
+	case CodeBetween:
+		return &RangeError{Min: params["min"], Max: params["max"], Actual: params["actual"]}
+	case CodeNotBetween:
+		return &RangeError{Min: params["min"], Max: params["max"], Actual: params["actual"], Excluded: true}
+	case CodePattern:
+		return &PatternError{Pattern: params["pattern"].(string)}
+	case CodeUnique:
+		return &UniqueError{Index: params["index"].(int)}
+	case CodeDeliverable:
+		return ErrUndeliverable
+	case CodeLimit:
+		return ErrLimitExceeded
+	default:
+		return nil
+	}
+}
+
+// MinLengthError is wrapped by a ConstraintError when a string or array is
+// shorter than the minimum length.
+type MinLengthError struct {
+	Min    int
+	Actual int
+}
+
+func (e *MinLengthError) Error() string {
+	return fmt.Sprintf("length must be at least %d, got %d", e.Min, e.Actual)
+}
+
+// MaxLengthError is wrapped by a ConstraintError when a string or array is
+// longer than the maximum length.
+type MaxLengthError struct {
+	Max    int
+	Actual int
+}
+
+func (e *MaxLengthError) Error() string {
+	return fmt.Sprintf("length must be at most %d, got %d", e.Max, e.Actual)
+}
+
+// LengthError is wrapped by a ConstraintError when a string or array doesn't
+// have the exact length.
+type LengthError struct {
+	Length int
+	Actual int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("length must be exactly %d, got %d", e.Length, e.Actual)
+}
+
+// MinError is wrapped by a ConstraintError when a number is less than the
+// minimum. Min and Actual have the type of the validated number, e.g.
+// *big.Int, or are strings for decimals.
+type MinError struct {
+	Min    any
+	Actual any
+}
+
+func (e *MinError) Error() string {
+	return fmt.Sprintf("value must be at least %v, got %v", e.Min, e.Actual)
+}
+
+// MaxError is wrapped by a ConstraintError when a number is greater than the
+// maximum. See MinError for the types of Max and Actual.
+type MaxError struct {
+	Max    any
+	Actual any
+}
+
+func (e *MaxError) Error() string {
+	return fmt.Sprintf("value must be at most %v, got %v", e.Max, e.Actual)
+}
+
+// RangeError is wrapped by a ConstraintError when a number is outside of a
+// range (see NumberSchema.Between), or inside of an excluded range if
+// Excluded is true (see NumberSchema.NotBetween).
+type RangeError struct {
+	Min      any
+	Max      any
+	Actual   any
+	Excluded bool
+}
+
+func (e *RangeError) Error() string {
+	if e.Excluded {
+		return fmt.Sprintf("value must not be between %v and %v, got %v", e.Min, e.Max, e.Actual)
+	}
+	return fmt.Sprintf("value must be between %v and %v, got %v", e.Min, e.Max, e.Actual)
+}
+
+// PatternError is wrapped by a ConstraintError when a string doesn't match a
+// regular expression.
+type PatternError struct {
+	Pattern string
+}
+
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("string must match pattern %q", e.Pattern)
+}
+
+// UniqueError is wrapped by a ConstraintError when an array has duplicate
+// items. Index is the index of the first duplicate.
+type UniqueError struct {
+	Index int
+}
+
+func (e *UniqueError) Error() string {
+	return fmt.Sprintf("array items must be unique (duplicate found at index %d)", e.Index)
+}
+
+// FieldError is returned when a field of an object fails validation.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("validation failed for field %q: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned by ValidateJSON, ValidateJSONBytes and DecodeJSON
+// when the input cannot be decoded, e.g. because it is truncated or malformed.
+type DecodeError struct {
+	Offset int64  // Byte offset in the input at which decoding failed.
+	Path   string // Dot separated path of the value, e.g. `address.zip`, if known.
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("failed to decode JSON at %q (offset %d): %v", e.Path, e.Offset, e.Err)
+	}
+	return fmt.Sprintf("failed to decode JSON at offset %d: %v", e.Offset, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Summary holds the number of validation errors, grouped by top-level field
+// and by constraint code.
+type Summary struct {
+	Total   int
+	ByField map[string]int // Errors that are not about a field are not counted.
+	ByCode  map[string]int
+}
+
+// Summarize summarizes a validation error, which can be a joined error (see
+// ObjectSchema.AllErrors), e.g. to log one concise line per failed request.
+// Errors are grouped by the code of a ConstraintError, or else by the code of
+// a sentinel error, e.g. `required` for ErrValueRequired, or `invalid` (see
+// Codes).
+func Summarize(err error) Summary {
+	summary := Summary{
+		ByField: make(map[string]int),
+		ByCode:  make(map[string]int),
+	}
+	for _, err := range flattenErrors(err) {
+		summary.Total++
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			summary.ByField[fieldErr.Field]++
+		}
+		summary.ByCode[errorCode(err)]++
+	}
+	return summary
+}
+
+// errorCode returns the code by which Summarize groups an error.
+func errorCode(err error) string {
+	var constraintErr *ConstraintError
+	switch {
+	case errors.As(err, &constraintErr):
+		return constraintErr.Code
+	case errors.Is(err, ErrValueRequired):
+		return CodeRequired
+	case errors.Is(err, formats.ErrInvalidFormat):
+		return CodeFormat
+	case errors.Is(err, ErrUnknownField):
+		return CodeUnknownField
+	case errors.Is(err, ErrReadOnly):
+		return CodeReadOnly
+	case errors.Is(err, ErrWriteOnly):
+		return CodeWriteOnly
+	case errors.Is(err, ErrDeprecated):
+		return CodeDeprecated
+	default:
+		return CodeInvalid
+	}
+}
+
+// String formats the summary as a single line, e.g.
+// `3 errors (fields: age=1 name=2; codes: min=1 required=2)`.
+func (s Summary) String() string {
+	return fmt.Sprintf("%d errors (fields: %s; codes: %s)", s.Total, formatCounts(s.ByField), formatCounts(s.ByCode))
+}
+
+func formatCounts(counts map[string]int) string {
+	parts := make([]string, 0, len(counts))
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		parts = append(parts, fmt.Sprintf("%s=%d", key, counts[key]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleVerbose() {
+	type User struct {
+		Name string
+		Age  int
+	}
+
+	schema := valtor.Object[User]().AllErrors()
+	valtor.FieldOf(schema, "name", func(u User) string { return u.Name }, valtor.String().Min(2))
+	valtor.FieldOf(schema, "age", func(u User) int { return u.Age },
+		valtor.Number[int]().Min(0).Annotate("Age", "user's age in years"))
+
+	err := schema.Validate(User{Name: "A", Age: -1})
+	fmt.Println(err)
+	fmt.Println(valtor.Verbose(err))
+
+	// Output:
+	// validation failed for field "name": length must be at least 2, got 1
+	// validation failed for field "age": value must be at least 0, got -1
+	// validation failed for field "name": length must be at least 2, got 1
+	// Age (user's age in years): value must be at least 0, got -1
+}
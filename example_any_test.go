@@ -0,0 +1,42 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleWhenType() {
+	schema := valtor.Any().Required()
+	valtor.WhenType(schema, valtor.String().Min(3))
+	valtor.WhenType(schema, valtor.Number[float64]().Min(0))
+
+	err := schema.Validate("gopher")
+	fmt.Println(err)
+	err = schema.Validate(-1.0)
+	fmt.Println(err)
+	err = schema.Validate(true)
+	fmt.Println(err)
+	err = schema.Validate(nil)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be at least 0, got -1
+	// expected string or float64 value, got bool
+	// value is required
+}
@@ -15,6 +15,8 @@
 package valtor_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/dstotijn/valtor"
@@ -48,8 +50,8 @@ func ExampleArraySchema_Min() {
 	// Output:
 	// Validating ["a", "b", "c"]: <nil>
 	// Validating ["a", "b"]: <nil>
-	// Validating ["a"]: array length must be at least 2
-	// Validating []: array length must be at least 2
+	// Validating ["a"]: array length must be at least 2, got 1
+	// Validating []: array length must be at least 2, got 0
 }
 
 func ExampleArraySchema_Max() {
@@ -64,7 +66,7 @@ func ExampleArraySchema_Max() {
 	// Output:
 	// Validating [1]: <nil>
 	// Validating [1, 2]: <nil>
-	// Validating [1, 2, 3]: array length must be at most 2
+	// Validating [1, 2, 3]: array length must be at most 2, got 3
 }
 
 func ExampleArraySchema_Length() {
@@ -77,9 +79,9 @@ func ExampleArraySchema_Length() {
 	fmt.Println("Validating [1, 2, 3]:", schema.Validate([]int{1, 2, 3}))
 
 	// Output:
-	// Validating [1]: array length must be exactly 2
+	// Validating [1]: array length must be exactly 2, got 1
 	// Validating [1, 2]: <nil>
-	// Validating [1, 2, 3]: array length must be exactly 2
+	// Validating [1, 2, 3]: array length must be exactly 2, got 3
 }
 
 func ExampleArraySchema_UniqueItems() {
@@ -135,8 +137,126 @@ func ExampleArraySchema_multiple_validators() {
 
 	// Output:
 	// Valid array: <nil>
-	// Too short: array length must be at least 2
-	// Too long: array length must be at most 4
+	// Too short: array length must be at least 2, got 1
+	// Too long: array length must be at most 4, got 5
 	// Invalid item: invalid item at index 1: item must be positive
 	// Duplicate items: array items must be unique (duplicate found at index 2)
 }
+
+func ExampleArraySchema_ValidateChunked() {
+	schema := valtor.Array[int]().Items(valtor.Number[int]().Positive().Validate)
+
+	rows := []int{1, 2, -3, 4, 5, 0, 7}
+	err := schema.ValidateChunked(context.Background(), rows, 3, func(index int, errs []error) {
+		fmt.Printf("validated %d rows, %d invalid\n", index, len(errs))
+	})
+	fmt.Println(err)
+
+	// Output:
+	// validated 3 rows, 1 invalid
+	// validated 6 rows, 2 invalid
+	// validated 7 rows, 2 invalid
+	// invalid item at index 2: value must be positive
+	// invalid item at index 5: value must be positive
+}
+
+func ExampleArraySchema_AllErrors() {
+	schema := valtor.Array[string]().
+		NonEmpty().
+		Items(valtor.String().Required().Validate).
+		AllErrors()
+
+	err := schema.Validate([]string{"a", "", "c", ""})
+	fmt.Println(err)
+	err = schema.Validate([]string{})
+	fmt.Println(err)
+
+	// Output:
+	// invalid item at index 1: value is required
+	// invalid item at index 3: value is required
+	// array must not be empty
+}
+
+func ExampleUniqueBy() {
+	type Line struct {
+		SKU      string
+		Quantity int
+	}
+
+	schema := valtor.UniqueBy(valtor.Array[Line](), func(l Line) string {
+		return l.SKU
+	})
+
+	err := schema.Validate([]Line{{"A-1", 1}, {"B-2", 2}})
+	fmt.Println(err)
+	err = schema.Validate([]Line{{"A-1", 1}, {"A-1", 2}})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// array items must be unique (duplicate found at index 1)
+}
+
+func ExampleArraySchema_Sorted() {
+	type Reading struct {
+		At    int64
+		Value float64
+	}
+
+	schema := valtor.Array[Reading]().Sorted(func(a, b Reading) bool {
+		return a.At < b.At
+	})
+
+	err := schema.Validate([]Reading{{At: 1}, {At: 2}, {At: 2}})
+	fmt.Println(err)
+	err = schema.Validate([]Reading{{At: 1}, {At: 3}, {At: 2}})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// array items out of order at index 2
+}
+
+func ExampleSortedAscending() {
+	schema := valtor.SortedAscending(valtor.Array[string]())
+
+	err := schema.Validate([]string{"v1", "v2", "v3"})
+	fmt.Println(err)
+	err = schema.Validate([]string{"v2", "v1"})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// array items out of order at index 1
+}
+
+func ExamplePtrItems() {
+	schema := valtor.PtrItems(valtor.Array[*string](), valtor.String().Min(3))
+
+	name, short := "gopher", "go"
+
+	err := schema.Validate([]*string{&name, nil})
+	fmt.Println(err)
+	err = schema.Validate([]*string{&name, &short})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// invalid item at index 1: length must be at least 3, got 2
+}
+
+func ExampleConstraintError() {
+	schema := valtor.Array[int]().Max(5)
+
+	err := schema.Validate([]int{1, 2, 3, 4, 5, 6, 7})
+	fmt.Println(err)
+
+	var constraintErr *valtor.ConstraintError
+	if errors.As(err, &constraintErr) {
+		fmt.Println(constraintErr.Code, constraintErr.Params["max"], constraintErr.Params["actual"])
+	}
+
+	// Output:
+	// array length must be at most 5, got 7
+	// max_length 5 7
+}
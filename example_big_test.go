@@ -0,0 +1,62 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleBigInt() {
+	schema := valtor.BigInt().Required().Min(big.NewInt(0))
+
+	balance, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	err := schema.Validate(balance)
+	fmt.Println(err)
+	err = schema.Validate(big.NewInt(-1))
+	fmt.Println(err)
+	err = schema.Validate(nil)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be at least 0
+	// value is required
+}
+
+func ExampleDecimal() {
+	schema := valtor.Decimal().Required().Min("0.01").Max("1000000").Scale(2)
+
+	err := schema.Validate("19.99")
+	fmt.Println(err)
+	err = schema.Validate("19.999")
+	fmt.Println(err)
+	err = schema.Validate("0.001")
+	fmt.Println(err)
+	err = schema.Validate("1e3")
+	fmt.Println(err)
+	err = schema.Validate("ten")
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must have at most 2 digits after the decimal point
+	// value must be at least 0.01
+	// <nil>
+	// value must be a decimal number
+}
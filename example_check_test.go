@@ -0,0 +1,72 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+type Email string
+
+func (e Email) Validate() error {
+	return valtor.String().Required().Format("email").Validate(string(e))
+}
+
+type Address struct {
+	Zip string
+}
+
+func (Address) Schema() valtor.Validator[Address] {
+	return valtor.Object[Address]().
+		Field("zip", func(a Address) error {
+			return valtor.String().Length(6).Validate(a.Zip)
+		})
+}
+
+type Customer struct {
+	Emails    []Email
+	Addresses map[string]*Address
+}
+
+func (c *Customer) Validate() error {
+	if len(c.Emails) == 0 {
+		return errors.New("at least one email is required")
+	}
+	return nil
+}
+
+func ExampleCheck() {
+	fmt.Println(valtor.Check(Customer{
+		Emails:    []Email{"gopher@example.com"},
+		Addresses: map[string]*Address{"home": {Zip: "1011AB"}},
+	}))
+	fmt.Println(valtor.Check(Customer{}))
+	fmt.Println(valtor.Check(&Customer{
+		Emails: []Email{"gopher@example.com", "gopher"},
+	}))
+	fmt.Println(valtor.Check(Customer{
+		Emails:    []Email{"gopher@example.com"},
+		Addresses: map[string]*Address{"home": {Zip: "1011"}},
+	}))
+
+	// Output:
+	// <nil>
+	// at least one email is required
+	// validation failed for field "Emails": invalid item at index 1: string must be a valid email address
+	// validation failed for field "Addresses": invalid value for key home: validation failed for field "zip": length must be exactly 6, got 4
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleCodes() {
+	err := valtor.Array[string]().UniqueItems().Validate([]string{"a", "b", "a"})
+
+	var constraintErr *valtor.ConstraintError
+	if errors.As(err, &constraintErr) && constraintErr.Code == valtor.CodeUnique {
+		fmt.Println(constraintErr.Code, constraintErr.Params)
+	}
+
+	fmt.Println(slices.Contains(valtor.Codes(), valtor.CodeUnique))
+
+	// Output:
+	// unique map[index:2]
+	// true
+}
+
+func ExampleMinLengthError() {
+	schema := valtor.Object[any]().Field("name", func(v any) error {
+		name, _ := v.(string)
+		return valtor.String().Min(3).Validate(name)
+	})
+
+	err := schema.Validate(map[string]any{"name": "Go"})
+
+	var minLengthErr *valtor.MinLengthError
+	if errors.As(err, &minLengthErr) {
+		fmt.Printf("need %d more characters\n", minLengthErr.Min-minLengthErr.Actual)
+	}
+
+	// Output:
+	// need 1 more characters
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleCoerceNumber() {
+	fmt.Println(valtor.CoerceNumber[int64](float64(42)))
+	fmt.Println(valtor.CoerceNumber[int64](1.5))
+	fmt.Println(valtor.CoerceNumber[uint8](300))
+	fmt.Println(valtor.CoerceNumber[int](-1e19))
+	fmt.Println(valtor.CoerceNumber[uint64](uint64(math.MaxUint64)))
+	fmt.Println(valtor.CoerceNumber[float32](1e39))
+	fmt.Println(valtor.CoerceNumber[float64]("42"))
+	fmt.Println(valtor.CoerceNumber[int64](json.Number("9007199254740993")))
+	fmt.Println(valtor.CoerceNumber[int64](json.Number("2.5")))
+
+	// Output:
+	// 42 <nil>
+	// 0 expected integer value, got 1.5
+	// 0 value 300 is out of range for uint8
+	// 0 value -1e+19 is out of range for int
+	// 18446744073709551615 <nil>
+	// 0 value 1e+39 is out of range for float32
+	// 0 expected numeric value, got string
+	// 9007199254740993 <nil>
+	// 0 expected integer value, got 2.5
+}
+
+func ExampleCoerceNumberMode() {
+	fmt.Println(valtor.CoerceNumberMode[int64](1.9, valtor.CoerceTruncate))
+	fmt.Println(valtor.CoerceNumberMode[int64](-1.9, valtor.CoerceTruncate))
+	fmt.Println(valtor.CoerceNumberMode[uint8](300, valtor.CoerceTruncate))
+	fmt.Println(valtor.CoerceNumberMode[uint8](300, valtor.CoerceClamp))
+	fmt.Println(valtor.CoerceNumberMode[int8](-1000.5, valtor.CoerceClamp))
+	fmt.Println(valtor.CoerceNumberMode[int32](math.Inf(1), valtor.CoerceClamp))
+	fmt.Println(valtor.CoerceNumberMode[int64](json.Number("1e30"), valtor.CoerceClamp))
+	fmt.Println(valtor.CoerceNumberMode[float32](1e39, valtor.CoerceClamp))
+	fmt.Println(valtor.CoerceNumberMode[int64](math.NaN(), valtor.CoerceClamp))
+
+	// Output:
+	// 1 <nil>
+	// -1 <nil>
+	// 0 value 300 is out of range for uint8
+	// 255 <nil>
+	// -128 <nil>
+	// 2147483647 <nil>
+	// 9223372036854775807 <nil>
+	// 3.4028235e+38 <nil>
+	// 0 expected integer value, got NaN
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleObjectSchema_ValidateJSON() {
+	type Address struct {
+		Zip string `json:"zip"`
+	}
+	type User struct {
+		Name    string  `json:"name"`
+		Address Address `json:"address"`
+	}
+
+	schema := valtor.Object[User]().
+		Field("name", func(u User) error {
+			return valtor.String().Required().Validate(u.Name)
+		})
+
+	for _, doc := range []string{
+		`{"name": "Gopher", "address": {"zip": "1011AB"}}`,
+		`{"name": "", "address": {"zip": "1011AB"}}`,
+		`{"name": "Gopher", "address": {"zip": 1011}}`,
+		`{"name": "Gopher", "address": {`,
+		`{"name": "Gopher"} {}`,
+	} {
+		fmt.Println(schema.ValidateJSON(strings.NewReader(doc)))
+	}
+
+	// Output:
+	// <nil>
+	// validation failed for field "name": value is required
+	// failed to decode JSON at "address.zip" (offset 42): json: cannot unmarshal number into Go struct field User.address.zip of type string
+	// failed to decode JSON at offset 31: unexpected EOF
+	// failed to decode JSON at offset 18: unexpected data after top-level value
+}
+
+func ExampleSchema_ValidateJSONBytes() {
+	schema := valtor.New[map[string]any]().Custom(func(m map[string]any) error {
+		id, ok := m["id"].(json.Number)
+		if !ok {
+			return errors.New("id must be a number")
+		}
+		fmt.Println("id:", id)
+		return nil
+	})
+
+	fmt.Println(schema.ValidateJSONBytes([]byte(`{"id": 9007199254740993}`)))
+	fmt.Println(schema.ValidateJSONBytes([]byte(`{"id": 1,}`)))
+	fmt.Println(schema.ValidateJSONBytes(nil))
+
+	// Output:
+	// id: 9007199254740993
+	// <nil>
+	// failed to decode JSON at offset 10: invalid character '}' looking for beginning of object key string
+	// failed to decode JSON at offset 0: unexpected EOF
+}
+
+func ExampleValidateJSONStream() {
+	type Post struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	}
+
+	schema := valtor.Object[Post]()
+	valtor.FieldOf(schema, "title", func(p Post) string { return p.Title }, valtor.String().Required().Max(20))
+	valtor.FieldOf(schema, "tags", func(p Post) []string { return p.Tags },
+		valtor.Array[string]().Max(2).ItemsOf(valtor.String().Max(10)))
+
+	for _, doc := range []string{
+		`{"title": "Hello", "tags": ["go"]}`,
+		`{"title": "", "tags": []}`,
+		`{"title": "Hello", "tags": ["go", "json", "validation"` + strings.Repeat(`, "spam"`, 1000) + `]}`,
+		`{"title": "Hello", "tags": ["encoding/json"]}`,
+		`{"title": "Hello", "tags": [`,
+	} {
+		fmt.Println(valtor.ValidateJSONStream(context.Background(), strings.NewReader(doc), schema))
+	}
+
+	// Output:
+	// <nil>
+	// validation failed for field "title": value is required
+	// validation failed for field "tags": array length must be at most 2, got more
+	// validation failed for field "tags[0]": length must be at most 10, got 13
+	// failed to decode JSON at offset 28: unexpected EOF
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleSetDefaults() {
+	valtor.RegisterMessages("de", valtor.Messages{
+		valtor.CodeMinLength: "muss mindestens {min} Zeichen lang sein",
+	})
+
+	defaults := valtor.Defaults()
+	defer valtor.SetDefaults(defaults)
+
+	valtor.SetDefaults(valtor.Config{
+		FailFast:   false,
+		LengthMode: valtor.LengthRunes,
+		Locale:     "de",
+	})
+
+	schema := valtor.String().Min(3).Contains("@")
+	fmt.Println(schema.Validate("äö"))
+
+	// Options take precedence over the defaults.
+	schema = valtor.String(valtor.WithFailFast(), valtor.WithLocale("en")).Min(3).Contains("@")
+	fmt.Println(schema.Validate("äö"))
+
+	// Output:
+	// muss mindestens 3 Zeichen lang sein
+	// string must contain "@"
+	// length must be at least 3, got 2
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+type describeAddress struct {
+	Street string
+	Zip    string
+}
+
+type describeUser struct {
+	Name    string
+	Tags    []string
+	Address describeAddress
+}
+
+func describeUserSchema() *valtor.ObjectSchema[describeUser] {
+	address := valtor.Object[describeAddress]()
+	valtor.FieldOf(address, "street", func(a describeAddress) string { return a.Street }, valtor.String().Required())
+	valtor.FieldOf(address, "zip", func(a describeAddress) string { return a.Zip }, valtor.String().Length(6))
+
+	user := valtor.Object[describeUser]()
+	valtor.FieldOf(user, "name", func(u describeUser) string { return u.Name }, valtor.String().Required().Max(50))
+	valtor.FieldOf(user, "tags", func(u describeUser) []string { return u.Tags },
+		valtor.Array[string]().Max(5).ItemsOf(valtor.String().Min(2)))
+	valtor.FieldOf(user, "address", func(u describeUser) describeAddress { return u.Address }, address)
+	return user
+}
+
+func ExampleObjectSchema_Describe() {
+	b, _ := json.MarshalIndent(describeUserSchema().Describe().Fields[1], "", "  ")
+	fmt.Println(string(b))
+
+	// Output:
+	// {
+	//   "name": "tags",
+	//   "type": "array",
+	//   "constraints": [
+	//     {
+	//       "keyword": "maxItems",
+	//       "value": 5
+	//     }
+	//   ],
+	//   "items": {
+	//     "type": "string",
+	//     "constraints": [
+	//       {
+	//         "keyword": "minLength",
+	//         "value": 2
+	//       }
+	//     ]
+	//   }
+	// }
+}
+
+func ExampleDescription_Rules() {
+	for path, rule := range describeUserSchema().Describe().Rules() {
+		fmt.Println(path, rule.Keyword, rule.Value)
+	}
+
+	// Output:
+	// name required true
+	// name maxLength 50
+	// tags maxItems 5
+	// tags[] minLength 2
+	// address.street required true
+	// address.zip minLength 6
+	// address.zip maxLength 6
+}
+
+func ExampleAnySchema_Describe() {
+	schema := valtor.Any().Required()
+	valtor.WhenType(schema, valtor.String().Min(3))
+	valtor.WhenType(schema, valtor.Number[int]().Min(0))
+
+	fmt.Print(valtor.Sprint(schema))
+
+	// Output:
+	// any of string | integer, required
+	//   |: string, >= 3 chars
+	//   |: integer, >= 0
+}
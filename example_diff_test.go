@@ -0,0 +1,76 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleDiffErrors() {
+	type Profile struct {
+		Name string
+		Bio  string
+	}
+
+	schema := valtor.Object[Profile]().AllErrors()
+	schema.Field("name", func(p Profile) error {
+		return valtor.String().Required().Validate(p.Name)
+	})
+	schema.Field("bio", func(p Profile) error {
+		return valtor.String().Max(10).Validate(p.Bio)
+	})
+
+	previous := Profile{Bio: "Gopher"}
+	current := Profile{Bio: "Gopher since 2012"}
+
+	fmt.Println(valtor.DiffErrors(schema, previous, current))
+
+	// Output:
+	// [validation failed for field "bio": length must be at most 10, got 17]
+}
+
+func ExampleDiff() {
+	type Signup struct {
+		Username string
+		Plan     string
+		Age      int
+	}
+
+	previous := valtor.Object[Signup]()
+	valtor.FieldOf(previous, "username", func(s Signup) string { return s.Username }, valtor.String().Required().Max(50))
+	valtor.FieldOf(previous, "plan", func(s Signup) string { return s.Plan }, valtor.String().OneOf("free", "pro"))
+	valtor.FieldOf(previous, "age", func(s Signup) int { return s.Age }, valtor.Number[int]().Min(18))
+
+	current := valtor.Object[Signup]()
+	valtor.FieldOf(current, "username", func(s Signup) string { return s.Username }, valtor.String().Required().Max(20).Alphanumeric())
+	valtor.FieldOf(current, "plan", func(s Signup) string { return s.Plan }, valtor.String().OneOf("free", "pro", "team"))
+	valtor.FieldOf(current, "age", func(s Signup) int { return s.Age }, valtor.Number[int]())
+
+	for _, change := range valtor.Diff(previous, current) {
+		if change.Breaking() {
+			fmt.Println("breaking:", change)
+			continue
+		}
+		fmt.Println(change)
+	}
+
+	// Output:
+	// breaking: tightened username maxLength: 50 -> 20
+	// breaking: added username alphanumeric: true
+	// loosened plan enum: [free pro] -> [free pro team]
+	// removed age minimum: 18
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+// prefixPack is a validator pack with a single `x-prefix` rule, whose
+// parameter is the required prefix of a string value.
+type prefixPack struct{}
+
+func (prefixPack) Extensions() []valtor.Extension {
+	return []valtor.Extension{{
+		Name: "x-prefix",
+		Parse: func(params json.RawMessage) (func(any) error, error) {
+			var prefix string
+			if err := json.Unmarshal(params, &prefix); err != nil {
+				return nil, fmt.Errorf("expected string prefix: %w", err)
+			}
+			return func(value any) error {
+				if s, ok := value.(string); ok && !strings.HasPrefix(s, prefix) {
+					return fmt.Errorf("string must start with %q", prefix)
+				}
+				return nil
+			}, nil
+		},
+	}}
+}
+
+func ExampleExtensionRegistry() {
+	registry := valtor.NewExtensionRegistry()
+	if err := registry.Use(prefixPack{}); err != nil {
+		panic(err)
+	}
+	fmt.Println(registry.Use(prefixPack{}))
+
+	ext, _ := registry.Lookup("x-prefix")
+	validateFn, err := ext.Parse(json.RawMessage(`"sku-"`))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(validateFn("sku-123"))
+	fmt.Println(validateFn("123"))
+
+	// Output:
+	// extension "x-prefix" is already registered
+	// <nil>
+	// string must start with "sku-"
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleLatitude() {
+	type Location struct {
+		Lat float64
+		Lng float64
+	}
+
+	schema := valtor.Object[Location]()
+	valtor.FieldOf(schema, "lat", func(l Location) float64 { return l.Lat }, valtor.Latitude())
+	valtor.FieldOf(schema, "lng", func(l Location) float64 { return l.Lng }, valtor.Longitude())
+
+	fmt.Println(schema.Validate(Location{Lat: 52.37, Lng: 4.89}))
+	fmt.Println(schema.Validate(Location{Lat: 4.89, Lng: 252.37}))
+
+	// Output:
+	// <nil>
+	// validation failed for field "lng": value must be between -180 and 180, got 252.37
+}
+
+func ExampleGeoJSON() {
+	schema := valtor.GeoJSON().Types("Point", "Polygon")
+
+	err := schema.Validate(json.RawMessage(`{"type": "Point", "coordinates": [4.89, 52.37]}`))
+	fmt.Println(err)
+
+	// Latitude and longitude swapped.
+	err = schema.Validate(json.RawMessage(`{"type": "Point", "coordinates": [52.37, 104.89]}`))
+	fmt.Println(err)
+
+	err = schema.Validate(json.RawMessage(`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}`))
+	fmt.Println(err)
+
+	err = schema.Validate(json.RawMessage(`{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`))
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// validation failed for field "coordinates": invalid latitude: value must be between -90 and 90, got 104.89
+	// validation failed for field "coordinates": invalid GeoJSON: linear ring at index 0 must be closed
+	// GeoJSON type must be one of Point, Polygon, got "LineString"
+}
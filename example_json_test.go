@@ -0,0 +1,55 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleJSON() {
+	schema := valtor.JSON().Required()
+
+	fmt.Println(schema.Validate(json.RawMessage(`{"theme": "dark"}`)))
+	fmt.Println(schema.Validate(json.RawMessage(`{"theme": `)))
+	fmt.Println(schema.Validate(nil))
+
+	// Output:
+	// <nil>
+	// value must be valid JSON
+	// value is required
+}
+
+func ExampleJSONOf() {
+	type Settings struct {
+		Theme string `json:"theme"`
+	}
+
+	schema := valtor.JSONOf(valtor.Object[Settings]().
+		Field("theme", func(s Settings) error {
+			return valtor.String().OneOf("light", "dark").Validate(s.Theme)
+		}))
+
+	fmt.Println(schema.Validate(json.RawMessage(`{"theme": "dark"}`)))
+	fmt.Println(schema.Validate(json.RawMessage(`{"theme": "blue"}`)))
+	fmt.Println(schema.Validate(json.RawMessage(`{"theme": 1}`)))
+
+	// Output:
+	// <nil>
+	// validation failed for field "theme": value must be one of "light", "dark"
+	// failed to decode JSON: json: cannot unmarshal number into Go struct field Settings.theme of type string
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+type User struct {
+	Name  string
+	Admin bool
+}
+
+var currentUser = valtor.NewKey[User]("user")
+
+func ExampleKey() {
+	const quota = 10
+
+	schema := valtor.Number[int]().Min(1)
+	schema.Rule(valtor.NewContextRule(func(ctx context.Context, n int) error {
+		if user, _ := currentUser.Value(ctx); user.Admin || n <= quota {
+			return nil
+		}
+		return fmt.Errorf("must not exceed quota of %d", quota)
+	}))
+
+	ctx := currentUser.With(context.Background(), User{Name: "alice"})
+	fmt.Println(schema.ValidateContext(ctx, 25))
+
+	ctx = currentUser.With(context.Background(), User{Name: "bob", Admin: true})
+	fmt.Println(schema.ValidateContext(ctx, 25))
+
+	// Output:
+	// must not exceed quota of 10
+	// <nil>
+}
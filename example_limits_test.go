@@ -0,0 +1,50 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleLimits() {
+	schema := valtor.Object[map[string]any]().Limits(valtor.Limits{
+		MaxDepth:       3,
+		MaxTotalItems:  100,
+		MaxStringBytes: 64,
+	})
+
+	for _, doc := range []string{
+		`{"user": {"tags": ["go"]}}`,
+		`{"user": {"tags": [["go"]]}}`,
+		`[` + strings.Repeat(`1, `, 200) + `1]`,
+		`{"bio": "` + strings.Repeat("a", 100) + `"}`,
+	} {
+		err := schema.ValidateJSON(strings.NewReader(doc))
+		fmt.Println(err, errors.Is(err, valtor.ErrLimitExceeded))
+	}
+
+	fmt.Println(schema.ValidateMap(map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{}}}}))
+
+	// Output:
+	// <nil> false
+	// validation failed for field "user.tags[0]": nesting depth must be at most 3 true
+	// validation failed for field "[100]": total number of items must be at most 100 true
+	// validation failed for field "bio": string must be at most 64 bytes, got 100 true
+	// validation failed for field "a.b.c": nesting depth must be at most 3
+}
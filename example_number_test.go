@@ -16,6 +16,7 @@ package valtor_test
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/dstotijn/valtor"
 )
@@ -34,20 +35,42 @@ func ExampleNumber() {
 
 	// Output:
 	// <nil>
-	// value must be at least 18
-	// value must be at most 120
+	// value must be at least 18, got 15
+	// value must be at most 120, got 150
 }
 
 func ExampleNumberSchema_Required() {
 	schema := valtor.Number[int]().Required()
 
-	err := schema.Validate(0)
+	err := schema.ValidatePresence(0, true)
+	fmt.Println(err)
+	err = schema.ValidatePresence(0, false)
+	fmt.Println(err)
+
+	quantity := 0
+	ptrSchema := valtor.Ptr(schema)
+	err = ptrSchema.Validate(&quantity)
+	fmt.Println(err)
+	err = ptrSchema.Validate(nil)
 	fmt.Println(err)
 
 	// Output:
+	// <nil>
+	// value is required
+	// <nil>
 	// value is required
 }
 
+func ExampleNumberSchema_NonZero() {
+	schema := valtor.Number[int]().NonZero()
+
+	err := schema.Validate(0)
+	fmt.Println(err)
+
+	// Output:
+	// value must not be zero
+}
+
 func ExampleNumberSchema_Min() {
 	schema := valtor.Number[float64]().Min(0.5)
 
@@ -58,7 +81,7 @@ func ExampleNumberSchema_Min() {
 
 	// Output:
 	// <nil>
-	// value must be at least 0.5
+	// value must be at least 0.5, got 0.1
 }
 
 func ExampleNumberSchema_Max() {
@@ -71,7 +94,7 @@ func ExampleNumberSchema_Max() {
 
 	// Output:
 	// <nil>
-	// value must be at most 100
+	// value must be at most 100, got 200
 }
 
 func ExampleNumberSchema_Custom() {
@@ -91,3 +114,91 @@ func ExampleNumberSchema_Custom() {
 	// <nil>
 	// value must be positive
 }
+
+func ExampleNumberSchema_Positive() {
+	schema := valtor.Number[int]().Positive()
+
+	err := schema.Validate(1)
+	fmt.Println(err)
+	err = schema.Validate(0)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be positive
+}
+
+func ExampleNumberSchema_Finite() {
+	schema := valtor.Number[float64]().Finite()
+
+	err := schema.Validate(1.5)
+	fmt.Println(err)
+	err = schema.Validate(math.Inf(1))
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be finite
+}
+
+func ExampleNumberSchema_Int() {
+	schema := valtor.Number[float64]().Int()
+
+	err := schema.Validate(2.0)
+	fmt.Println(err)
+	err = schema.Validate(2.5)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be a whole number
+}
+
+func ExampleNumberSchema_Between() {
+	schema := valtor.Number[int]().Between(18, 120)
+
+	err := schema.Validate(25)
+	fmt.Println(err)
+	err = schema.Validate(15)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be between 18 and 120, got 15
+}
+
+func ExampleNumberSchema_NotBetween() {
+	schema := valtor.Number[int]().NotBetween(1000, 1999)
+
+	err := schema.Validate(2000)
+	fmt.Println(err)
+	err = schema.Validate(1500)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must not be between 1000 and 1999, got 1500
+}
+
+func ExampleNumberSchema_Check() {
+	schema := valtor.Number[int]().Min(18).Between(0, 12)
+
+	err := schema.Check()
+	fmt.Println(err)
+
+	// Output:
+	// impossible bounds: minimum 18 is greater than maximum 12
+}
+
+func ExampleNumberSchema_OneOf() {
+	schema := valtor.Number[int]().OneOf(1, 5, 10)
+
+	err := schema.Validate(5)
+	fmt.Println(err)
+	err = schema.Validate(3)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be one of 1, 5, 10
+}
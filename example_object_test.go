@@ -15,6 +15,8 @@
 package valtor_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 
@@ -64,7 +66,7 @@ func ExampleObjectSchema_Field() {
 
 	// Output:
 	// <nil>
-	// validation failed for field "name": length must be at least 2
+	// validation failed for field "name": length must be at least 2, got 1
 }
 
 func ExampleObjectSchema_Field_validateField() {
@@ -107,7 +109,7 @@ func ExampleObjectSchema_Field_validateField() {
 
 	// Output:
 	// <nil>
-	// validation failed for field "name": length must be at least 2
+	// validation failed for field "name": length must be at least 2, got 1
 }
 
 func ExampleObjectSchema_Map() {
@@ -154,5 +156,296 @@ func ExampleObjectSchema_Map() {
 
 	// Output:
 	// <nil>
-	// validation failed for field "baz": validation failed for field "quo": length must be at most 5
+	// validation failed for field "baz": validation failed for field "quo": length must be at most 5, got 6
+}
+
+func ExampleObjectSchema_PropertyNames() {
+	schema := valtor.Object[any]().
+		PropertyNames(valtor.String().Regexp(regexp.MustCompile(`^[a-z_]+$`)))
+
+	err := schema.Validate(map[string]any{"first_name": "John"})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{"FirstName": "John"})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// invalid property name "FirstName": string must match pattern "^[a-z_]+$"
+}
+
+func ExampleObjectSchema_PatternField() {
+	schema := valtor.Object[any]().
+		PatternField(regexp.MustCompile(`^x-`), func(v any) error {
+			s, _ := v.(string)
+			return valtor.String().Max(5).Validate(s)
+		})
+
+	err := schema.Validate(map[string]any{"x-foo": "bar", "name": "John Doe"})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{"x-foo": "foobar"})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// validation failed for field "x-foo": length must be at most 5, got 6
+}
+
+func ExampleObjectSchema_WildcardField() {
+	schema := valtor.Object[any]().
+		WildcardField("label.*", func(v any) error {
+			s, _ := v.(string)
+			return valtor.String().Required().Max(5).Validate(s)
+		})
+
+	err := schema.Validate(map[string]any{"label.env": "prod", "label.team": "infra"})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{"label.env": "staging", "name": "web"})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// validation failed for field "label.env": length must be at most 5, got 7
+}
+
+func ExampleObjectSchema_At() {
+	schema := valtor.Object[any]().
+		At("spec.replicas", func(v any) error {
+			replicas, _ := v.(int)
+			return valtor.Number[int]().Min(1).Validate(replicas)
+		})
+
+	err := schema.Validate(map[string]any{"spec": map[string]any{"replicas": 3}})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{"spec": map[string]any{"replicas": 0}})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{"spec": "web"})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// validation failed for field "spec.replicas": value must be at least 1, got 0
+	// validation failed for field "spec": value must be an object
+}
+
+func ExampleObjectSchema_MinProperties() {
+	schema := valtor.Object[map[string]any]().MinProperties(1).MaxProperties(2)
+
+	err := schema.Validate(map[string]any{"foo": "bar"})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{"foo": 1, "bar": 2, "baz": 3})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// number of properties must be at least 1
+	// number of properties must be at most 2
+}
+
+func ExampleObjectSchema_RequiredFields() {
+	schema := valtor.Object[any]().
+		RequiredFields("age").
+		FieldPresence("nickname", func(v any, present bool) error {
+			if !present {
+				return nil
+			}
+			s, _ := v.(string)
+			return valtor.String().Required().Validate(s)
+		})
+
+	err := schema.Validate(map[string]any{"age": 0})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{})
+	fmt.Println(err)
+	err = schema.Validate(map[string]any{"age": 0, "nickname": ""})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// validation failed for field "age": value is required
+	// validation failed for field "nickname": value is required
+}
+
+func ExampleObjectSchema_AllErrors() {
+	type User struct {
+		Name string
+		Age  int
+	}
+
+	schema := valtor.Object[User]().AllErrors()
+	schema.Field("name", func(u User) error {
+		return valtor.String().Required().Validate(u.Name)
+	})
+	schema.Field("age", func(u User) error {
+		return valtor.Number[int]().Min(18).Validate(u.Age)
+	})
+
+	err := schema.Validate(User{Age: 12})
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, valtor.ErrValueRequired))
+
+	// Output:
+	// validation failed for field "name": value is required
+	// validation failed for field "age": value must be at least 18, got 12
+	// true
+}
+
+func ExampleObjectSchema_ValidateField() {
+	schema := valtor.Object[any]()
+	schema.Field("email", func(v any) error {
+		email, _ := v.(string)
+		return valtor.String().Required().Contains("@").Validate(email)
+	})
+
+	err := schema.ValidateField("email", "gopher")
+	fmt.Println(err)
+	err = schema.ValidateField("email", "gopher@example.com")
+	fmt.Println(err)
+
+	// Output:
+	// validation failed for field "email": string must contain "@"
+	// <nil>
+}
+
+func ExampleFieldPtrOf() {
+	type User struct {
+		Name     *string
+		Nickname *string
+	}
+
+	schema := valtor.Object[User]()
+	valtor.RequiredFieldPtrOf(schema, "name",
+		func(u User) *string { return u.Name },
+		valtor.String().Min(2),
+	)
+	valtor.FieldPtrOf(schema, "nickname",
+		func(u User) *string { return u.Nickname },
+		valtor.String().Min(3),
+	)
+
+	name, nickname := "Alice", "Al"
+
+	err := schema.Validate(User{Name: &name})
+	fmt.Println(err)
+	err = schema.Validate(User{Name: &name, Nickname: &nickname})
+	fmt.Println(err)
+	err = schema.Validate(User{})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// validation failed for field "nickname": length must be at least 3, got 2
+	// validation failed for field "name": value is required
+}
+
+func ExampleSummarize() {
+	schema := valtor.Object[any]().AllErrors().RequiredFields("name", "email")
+	schema.Field("age", func(v any) error {
+		age, _ := v.(int)
+		return valtor.Number[int]().Min(18).Validate(age)
+	})
+
+	err := schema.ValidateMap(map[string]any{"age": 12})
+	fmt.Println(valtor.Summarize(err))
+
+	// Output:
+	// 3 errors (fields: age=1 email=1 name=1; codes: min=1 required=2)
+}
+
+func ExampleObjectSchema_DeprecatedField() {
+	schema := valtor.Object[any]().
+		Field("name", func(v any) error {
+			name, _ := v.(string)
+			return valtor.String().Required().Validate(name)
+		}).
+		DeprecatedField("username", "use `name` instead")
+
+	result := valtor.ValidateResult(context.Background(), schema, any(map[string]any{
+		"name":     "Gopher",
+		"username": "gopher",
+	}))
+	fmt.Println(result.Valid())
+	fmt.Println(result.Warnings)
+	fmt.Println(errors.Is(result.Warnings[0], valtor.ErrDeprecated))
+
+	// Output:
+	// true
+	// [validation failed for field "username": value is deprecated]
+	// true
+}
+
+func ExampleObjectSchema_ReadOnlyFields() {
+	schema := valtor.Object[any]().
+		RequiredFields("id", "name").
+		ReadOnlyFields("id").
+		WriteOnlyFields("password")
+
+	user := map[string]any{"id": 1, "name": "Gopher", "password": "secret"}
+
+	requestCtx := valtor.WithMode(context.Background(), valtor.ModeRequest)
+	responseCtx := valtor.WithMode(context.Background(), valtor.ModeResponse)
+
+	fmt.Println(schema.ValidateContext(requestCtx, user))
+	fmt.Println(schema.ValidateContext(responseCtx, user))
+
+	// Read-only fields can be stripped instead, and are not required.
+	schema.StripFields(requestCtx, user)
+	fmt.Println(user)
+	fmt.Println(schema.ValidateContext(requestCtx, user))
+
+	// Output:
+	// validation failed for field "id": field is read-only
+	// validation failed for field "password": field is write-only
+	// map[name:Gopher password:secret]
+	// <nil>
+}
+
+func ExampleObjectSchema_Extend() {
+	audit := valtor.Object[any]().
+		RequiredFields("created_by").
+		Field("created_by", func(v any) error {
+			createdBy, _ := v.(string)
+			return valtor.String().Required().Validate(createdBy)
+		})
+
+	schema := valtor.Object[any]().
+		Field("title", func(v any) error {
+			title, _ := v.(string)
+			return valtor.String().Required().Max(10).Validate(title)
+		}).
+		Extend(audit)
+
+	fmt.Println(schema.ValidateMap(map[string]any{"title": "Hello", "created_by": "gopher"}))
+	fmt.Println(schema.ValidateMap(map[string]any{"title": "Hello"}))
+
+	// Output:
+	// <nil>
+	// validation failed for field "created_by": value is required
+}
+
+func ExampleMerge() {
+	page := valtor.Object[any]().Field("limit", func(v any) error {
+		limit, _ := v.(int)
+		return valtor.Number[int]().Min(1).Validate(limit)
+	})
+	search := valtor.Object[any]().Field("limit", func(v any) error {
+		limit, _ := v.(int)
+		return valtor.Number[int]().Max(50).Validate(limit)
+	})
+
+	_, err := valtor.Merge(page, search, valtor.ConflictError)
+	fmt.Println(err)
+
+	schema, err := valtor.Merge(page, search, valtor.ConflictCombine)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(schema.ValidateMap(map[string]any{"limit": 0}))
+	fmt.Println(schema.ValidateMap(map[string]any{"limit": 100}))
+
+	// Output:
+	// field "limit" has a validator in both schemas
+	// validation failed for field "limit": value must be at least 1, got 0
+	// validation failed for field "limit": value must be at most 50, got 100
 }
@@ -0,0 +1,88 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleWithErrorCollector() {
+	schema := valtor.String(valtor.WithErrorCollector()).Min(8).Contains("@")
+
+	fmt.Println(schema.Validate("admin"))
+
+	// Output:
+	// length must be at least 8, got 5
+	// string must contain "@"
+}
+
+func ExampleWithMessages() {
+	catalog := valtor.Messages{
+		valtor.CodeMinLength: "muss mindestens {min} Zeichen lang sein",
+	}
+	opts := []valtor.Option{valtor.WithMessages(catalog), valtor.WithLengthMode(valtor.LengthRunes)}
+
+	schema := valtor.String(opts...).Min(3).Max(5)
+
+	fmt.Println(schema.Validate("äö"))
+	fmt.Println(schema.Validate("äöüßé!"))
+
+	// Output:
+	// muss mindestens 3 Zeichen lang sein
+	// length must be at most 5, got 6
+}
+
+func ExampleWithMaxErrors() {
+	schema := valtor.Array[int](valtor.WithMaxErrors(2)).ItemsOf(valtor.Number[int]().Positive())
+
+	err := schema.Validate([]int{-1, -2, -3, -4})
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, valtor.ErrTooManyErrors))
+
+	// Output:
+	// invalid item at index 0: value must be positive
+	// invalid item at index 1: value must be positive
+	// too many errors, stopped after 2
+	// true
+}
+
+func ExampleWithMaxErrors_nested() {
+	type user struct {
+		Name  string
+		Email string
+		Age   int
+	}
+
+	userSchema := valtor.Object[user]().AllErrors()
+	valtor.FieldOf(userSchema, "name", func(u user) string { return u.Name }, valtor.String().Required())
+	valtor.FieldOf(userSchema, "email", func(u user) string { return u.Email }, valtor.String().Required())
+	valtor.FieldOf(userSchema, "age", func(u user) int { return u.Age }, valtor.Number[int]().Positive())
+	userSchema.Custom(func(user) error {
+		fmt.Println("not reached")
+		return nil
+	})
+	schema := valtor.Array[user](valtor.WithMaxErrors(2)).ItemsOf(userSchema)
+
+	err := schema.Validate([]user{{}, {}})
+	fmt.Println(err)
+
+	// Output:
+	// invalid item at index 0: validation failed for field "name": value is required
+	// validation failed for field "email": value is required
+	// too many errors, stopped after 2
+}
@@ -83,7 +83,7 @@ func ExamplePtr() {
 
 	// Output:
 	// <nil>
-	// length must be at least 3
+	// length must be at least 3, got 2
 	// <nil>
 	// value is required
 }
@@ -0,0 +1,50 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleSprint() {
+	type Server struct {
+		Host  string
+		Port  int
+		Tags  []string
+		Debug bool
+		Mode  string
+	}
+
+	schema := valtor.Object[Server]()
+	valtor.FieldOf(schema, "host", func(s Server) string { return s.Host }, valtor.String().Required().Format("hostname"))
+	valtor.FieldOf(schema, "port", func(s Server) int { return s.Port }, valtor.Number[int]().Between(1, 65535))
+	valtor.FieldOf(schema, "tags", func(s Server) []string { return s.Tags },
+		valtor.Array[string]().Max(10).UniqueItems().ItemsOf(valtor.String().Min(2).Max(50)))
+	valtor.FieldOf(schema, "mode", func(s Server) string { return s.Mode }, valtor.String().OneOf("dev", "prod"))
+	schema.Field("debug", func(Server) error { return nil })
+
+	fmt.Print(valtor.Sprint(schema))
+
+	// Output:
+	// object
+	//   host: string, required, format hostname
+	//   port: integer, 1..65535
+	//   tags: array, <= 10 items, unique
+	//     []: string, 2..50 chars
+	//   mode: string, one of "dev", "prod"
+	//   debug: any
+}
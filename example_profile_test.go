@@ -0,0 +1,47 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleWithProfile() {
+	schema := valtor.Object[any]()
+	schema.Field("name", func(v any) error {
+		name, _ := v.(string)
+		return valtor.String().Required().Validate(name)
+	})
+
+	dev := valtor.DevProfile
+	dev.Warn = func(_ context.Context, err error) {
+		fmt.Println("warning:", err)
+	}
+
+	value := map[string]any{"name": "gopher", "nmae": "typo"}
+
+	err := schema.ValidateContext(valtor.WithProfile(context.Background(), dev), value)
+	fmt.Println(err)
+	err = schema.ValidateContext(valtor.WithProfile(context.Background(), valtor.ProdProfile), value)
+	fmt.Println(err)
+
+	// Output:
+	// warning: validation failed for field "nmae": unknown field
+	// <nil>
+	// validation failed for field "nmae": unknown field
+}
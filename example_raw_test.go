@@ -0,0 +1,59 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleRawMessage() {
+	type Envelope struct {
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	type UserCreated struct {
+		Email string `json:"email"`
+	}
+
+	payloadSchema := valtor.RawMessage()
+	valtor.RawCase(payloadSchema, "user.created", valtor.Object[UserCreated]().
+		Field("email", func(e UserCreated) error {
+			return valtor.String().Required().Format("email").Validate(e.Email)
+		}))
+
+	schema := valtor.Object[Envelope]().
+		Field("payload", valtor.ValidateRawField(payloadSchema,
+			func(e Envelope) string { return e.Type },
+			func(e Envelope) json.RawMessage { return e.Payload },
+		))
+
+	for _, doc := range []string{
+		`{"type": "user.created", "payload": {"email": "gopher@example.com"}}`,
+		`{"type": "user.created", "payload": {"email": "gopher"}}`,
+		`{"type": "user.deleted", "payload": {}}`,
+	} {
+		var envelope Envelope
+		_ = json.Unmarshal([]byte(doc), &envelope)
+		fmt.Println(schema.Validate(envelope))
+	}
+
+	// Output:
+	// <nil>
+	// validation failed for field "payload": validation failed for field "email": string must be a valid email address
+	// validation failed for field "payload": unknown discriminator "user.deleted"
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleRegistry() {
+	type CreateUser struct {
+		Name string
+	}
+
+	schema := valtor.Object[CreateUser]().
+		Field("name", func(c CreateUser) error {
+			return valtor.String().Required().Validate(c.Name)
+		})
+
+	registry := valtor.NewRegistry()
+	valtor.RegisterType(registry, schema)
+	valtor.RegisterNamed(registry, "user.create", schema)
+
+	// Look up the schema by type, e.g. in a handler.
+	userSchema, _ := valtor.Lookup[CreateUser](registry)
+	fmt.Println(userSchema.Validate(CreateUser{Name: "Gopher"}))
+
+	// Validate by dynamic type, e.g. in middleware.
+	fmt.Println(registry.ValidateValue(context.Background(), CreateUser{}))
+
+	// Validate by name, e.g. in a message handler.
+	fmt.Println(registry.Validate("user.create", CreateUser{}))
+	fmt.Println(registry.Validate("user.create", "Gopher"))
+	fmt.Println(registry.Validate("user.delete", CreateUser{}))
+
+	// Output:
+	// <nil>
+	// validation failed for field "name": value is required
+	// validation failed for field "name": value is required
+	// expected value of type valtor_test.CreateUser, got string
+	// unknown schema "user.delete"
+}
+
+func ExampleFor() {
+	type Order struct {
+		Quantity int
+	}
+
+	// Register the schema once at startup.
+	valtor.Register(valtor.Object[Order]().
+		Field("quantity", func(o Order) error {
+			return valtor.Number[int]().Min(1).Validate(o.Quantity)
+		}))
+
+	// Look it up elsewhere.
+	schema, ok := valtor.For[Order]()
+	fmt.Println(ok)
+	fmt.Println(schema.Validate(Order{Quantity: 0}))
+
+	// Output:
+	// true
+	// validation failed for field "quantity": value must be at least 1, got 0
+}
+
+func ExampleRegisterRule() {
+	// Build a catalog of rules once at startup.
+	valtor.RegisterRule(valtor.DefineRule("sku", "acme.sku", func(v string) error {
+		if !strings.HasPrefix(v, "SKU-") {
+			return errors.New("must be a SKU, e.g. SKU-1234")
+		}
+		return nil
+	}))
+
+	// Reuse them across schemas.
+	sku, _ := valtor.RuleFor[string]("sku")
+	schema := valtor.String(valtor.WithMessages(valtor.Messages{
+		"acme.sku": "ist keine Artikelnummer",
+	})).Rule(sku)
+
+	err := schema.Validate("1234")
+	fmt.Println(err)
+
+	var constraintErr *valtor.ConstraintError
+	if errors.As(err, &constraintErr) {
+		fmt.Println(constraintErr.Code)
+	}
+
+	_, ok := valtor.RuleFor[int]("sku")
+	fmt.Println(ok)
+
+	// Output:
+	// ist keine Artikelnummer
+	// acme.sku
+	// false
+}
+
+func ExampleRegistry_Extensions() {
+	registry := valtor.NewRegistry()
+	sku := valtor.DefineRule("sku", "acme.sku", func(v string) error {
+		if !strings.HasPrefix(v, "SKU-") {
+			return errors.New("must be a SKU, e.g. SKU-1234")
+		}
+		return nil
+	})
+	if err := valtor.AddRule(registry, sku); err != nil {
+		panic(err)
+	}
+
+	// Names are unique, and rules without a name can't be added.
+	fmt.Println(valtor.AddRule(registry, sku))
+	fmt.Println(valtor.AddRule(registry, valtor.NewRule(func(string) error { return nil })))
+
+	// Named rules are extensions as well, e.g. for JSON Schema documents with
+	// `"sku": true`.
+	ext, _ := registry.Extensions().Lookup("sku")
+	validateFn, _ := ext.Parse(json.RawMessage("true"))
+	fmt.Println(validateFn("SKU-1234"))
+	fmt.Println(validateFn("1234"))
+
+	// Errors of rules with a hint get their message from the catalog, too.
+	rule, _ := valtor.LookupRule[string](registry, "sku")
+	schema := valtor.String(valtor.WithMessages(valtor.Messages{
+		"acme.sku": "ist keine Artikelnummer",
+	})).Rule(rule.Hint("z.B. SKU-1234"))
+
+	err := schema.Validate("1234")
+	var ruleErr *valtor.RuleError
+	if errors.As(err, &ruleErr) {
+		fmt.Println(ruleErr, "-", ruleErr.Hint)
+	}
+
+	// Output:
+	// extension "sku" is already registered
+	// invalid rule: name is required, see DefineRule
+	// <nil>
+	// must be a SKU, e.g. SKU-1234
+	// ist keine Artikelnummer - z.B. SKU-1234
+}
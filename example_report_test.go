@@ -0,0 +1,154 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleNewReport() {
+	type Transfer struct {
+		IBAN   string `json:"iban"`
+		Amount string `json:"amount"`
+	}
+
+	schema := valtor.Object[Transfer]().AllErrors()
+	schema.Field("iban", func(t Transfer) error {
+		return valtor.String().Required().Format("iban").Validate(t.IBAN)
+	})
+	schema.Field("amount", func(t Transfer) error {
+		return valtor.Decimal().Required().Min("0.01").Scale(2).Validate(t.Amount)
+	})
+
+	report, err := valtor.NewReport(context.Background(), schema, "transfer/v3", Transfer{
+		IBAN:   "NL91ABNA0417164300",
+		Amount: "10.005",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Fix the timestamp for the example output.
+	report.Timestamp = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	b, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(b))
+
+	// Output:
+	// {
+	//   "schema": "transfer/v3",
+	//   "input_hash": "sha256:95af916f8e2c4cb9cade09cc43d41d1c53cb18cd534bc9b2a984596ca33df5ab",
+	//   "timestamp": "2025-01-01T00:00:00Z",
+	//   "valid": false,
+	//   "failures": [
+	//     {
+	//       "field": "amount",
+	//       "code": "scale",
+	//       "message": "validation failed for field \"amount\": value must have at most 2 digits after the decimal point"
+	//     }
+	//   ]
+	// }
+}
+
+func ExampleValidateAll() {
+	type User struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	schema := valtor.Object[User]().AllErrors()
+	valtor.FieldOf(schema, "name", func(u User) string { return u.Name }, valtor.String().Required())
+	valtor.FieldOf(schema, "age", func(u User) int { return u.Age }, valtor.Number[int]().Min(18))
+
+	users := []User{{Name: "Alice", Age: 30}, {Age: 12}, {Name: "Bob", Age: 40}}
+
+	report := valtor.ValidateAll(context.Background(), schema, func(yield func(string, User) bool) {
+		for i, user := range users {
+			if !yield(fmt.Sprintf("users.json:%d", i+1), user) {
+				return
+			}
+		}
+	})
+	if err := report.WriteText(os.Stdout); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// INPUT         FIELD  CODE      MESSAGE
+	// users.json:2  name   required  validation failed for field "name": value is required
+	// users.json:2  age    min       validation failed for field "age": value must be at least 18, got 12
+	// 2 of 3 values valid, 2 failures (fields: age=1 name=1; codes: min=1 required=1)
+}
+
+func ExampleBatchReport_WriteSARIF() {
+	schema := valtor.String().Required().Max(8)
+
+	report := valtor.ValidateAll(context.Background(), schema, func(yield func(string, string) bool) {
+		_ = yield("config/hosts.txt:1", "web-1") &&
+			yield("config/hosts.txt:2", "database-1")
+	})
+	if err := report.WriteSARIF(os.Stdout); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// {
+	//   "version": "2.1.0",
+	//   "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+	//   "runs": [
+	//     {
+	//       "tool": {
+	//         "driver": {
+	//           "name": "valtor",
+	//           "informationUri": "https://github.com/dstotijn/valtor",
+	//           "rules": [
+	//             {
+	//               "id": "max_length"
+	//             }
+	//           ]
+	//         }
+	//       },
+	//       "results": [
+	//         {
+	//           "ruleId": "max_length",
+	//           "ruleIndex": 0,
+	//           "level": "error",
+	//           "message": {
+	//             "text": "length must be at most 8, got 10"
+	//           },
+	//           "locations": [
+	//             {
+	//               "physicalLocation": {
+	//                 "artifactLocation": {
+	//                   "uri": "config/hosts.txt"
+	//                 },
+	//                 "region": {
+	//                   "startLine": 2
+	//                 }
+	//               }
+	//             }
+	//           ]
+	//         }
+	//       ]
+	//     }
+	//   ]
+	// }
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleRule_EnabledIf() {
+	type tenantKey struct{}
+
+	strictTenants := map[string]bool{"acme": true}
+
+	schema := valtor.String().Required()
+	schema.Rule(
+		valtor.NewRule(valtor.String().Max(5).Validate).
+			EnabledIf(func(ctx context.Context) bool {
+				tenant, _ := ctx.Value(tenantKey{}).(string)
+				return strictTenants[tenant]
+			}),
+	)
+
+	acmeCtx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	otherCtx := context.WithValue(context.Background(), tenantKey{}, "other")
+
+	err := schema.ValidateContext(acmeCtx, "foobar")
+	fmt.Println(err)
+	err = schema.ValidateContext(otherCtx, "foobar")
+	fmt.Println(err)
+	err = schema.ValidateContext(otherCtx, "")
+	fmt.Println(err)
+
+	// Output:
+	// length must be at most 5, got 6
+	// <nil>
+	// value is required
+}
+
+func ExampleRule_SampleBy() {
+	type userKey struct{}
+
+	var checked []string
+	expensive := valtor.NewRule(func(v string) error {
+		checked = append(checked, v)
+		return nil
+	}).SampleBy(0.5, func(ctx context.Context, _ string) string {
+		user, _ := ctx.Value(userKey{}).(string)
+		return user
+	})
+
+	for _, user := range []string{"alice", "carol", "alice", "carol"} {
+		ctx := context.WithValue(context.Background(), userKey{}, user)
+		_ = expensive.ValidateContext(ctx, user)
+	}
+	fmt.Println(checked)
+
+	// Output:
+	// [alice alice]
+}
+
+func ExampleRule_Hint() {
+	schema := valtor.String()
+	schema.Rule(
+		valtor.NewRule(func(v string) error {
+			if _, err := time.Parse(time.DateOnly, v); err != nil {
+				return errors.New("invalid date")
+			}
+			return nil
+		}).Hint("use ISO 8601, e.g. 2024-01-31"),
+	)
+
+	err := schema.Validate("31/01/2024")
+	fmt.Println(err)
+
+	var ruleErr *valtor.RuleError
+	if errors.As(err, &ruleErr) {
+		fmt.Println(ruleErr.Hint)
+	}
+
+	// Output:
+	// invalid date
+	// use ISO 8601, e.g. 2024-01-31
+}
+
+func ExampleRule_DocURL() {
+	type Order struct {
+		Currency string
+	}
+
+	currencySchema := valtor.String().Required().Length(3)
+
+	schema := valtor.Object[Order]()
+	schema.Field("currency", valtor.NewRule(func(o Order) error {
+		return currencySchema.Validate(o.Currency)
+	}).DocURL("https://example.com/docs/orders#currency").Validate)
+
+	err := schema.Validate(Order{Currency: "euro"})
+	fmt.Println(err)
+
+	var ruleErr *valtor.RuleError
+	if errors.As(err, &ruleErr) {
+		fmt.Println(ruleErr.DocURL)
+	}
+
+	// Output:
+	// validation failed for field "currency": length must be exactly 3, got 4
+	// https://example.com/docs/orders#currency
+}
+
+func ExampleNewContextRule() {
+	type Order struct {
+		CouponCode string
+	}
+
+	// A lookup that honors cancellation, e.g. a database query.
+	redeemed := map[string]bool{"WELCOME10": true}
+	notRedeemed := valtor.NewContextRule(func(ctx context.Context, code string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if redeemed[code] {
+			return errors.New("coupon code is already redeemed")
+		}
+		return nil
+	})
+
+	couponCode := valtor.String()
+	couponCode.Rule(notRedeemed)
+
+	schema := valtor.Object[Order]()
+	valtor.FieldOf(schema, "couponCode", func(o Order) string { return o.CouponCode }, couponCode)
+
+	fmt.Println(schema.ValidateContext(context.Background(), Order{CouponCode: "WELCOME10"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fmt.Println(schema.ValidateContext(ctx, Order{CouponCode: "SPRING25"}))
+
+	// Output:
+	// validation failed for field "couponCode": coupon code is already redeemed
+	// validation failed for field "couponCode": context canceled
+}
+
+func ExampleDefineRule() {
+	evenRule := valtor.DefineRule("even", "even", func(n int) error {
+		if n%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+
+	schema := valtor.Number[int]().Min(0).Rule(evenRule)
+
+	err := schema.Validate(3)
+	fmt.Println(err)
+	fmt.Println(valtor.Summarize(err).ByCode)
+
+	// Output:
+	// must be even
+	// map[even:1]
+}
+
+func ExampleExpressionRule() {
+	var rules []valtor.ExpressionRule
+	data := `["max >= min", {"rule": "len(name) > 0", "message": "name is required"}]`
+	if err := json.Unmarshal([]byte(data), &rules); err != nil {
+		panic(err)
+	}
+
+	for _, rule := range rules {
+		fmt.Println(rule.Failure())
+	}
+
+	// Output:
+	// value must satisfy max >= min
+	// name is required
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleStringSchema_NoHTML() {
+	schema := valtor.String().NoHTML()
+
+	fmt.Println(schema.Validate("1 < 2 & 3 > 2"))
+	fmt.Println(schema.Validate(`<img src=x onerror="alert(1)">`))
+	fmt.Println(schema.Validate("<!-- hidden -->"))
+
+	// Output:
+	// <nil>
+	// string must not contain HTML
+	// string must not contain HTML
+}
+
+func ExampleStripHTML() {
+	fmt.Printf("%q\n", valtor.StripHTML(`<p title="a > b">Hello, <b>world</b>!</p><script>alert("hi")</script>`))
+	fmt.Printf("%q\n", valtor.StripHTML("1 < 2"))
+
+	// Output:
+	// "Hello, world!"
+	// "1 < 2"
+}
+
+func ExampleStringSchema_NoSQLMeta() {
+	schema := valtor.String().NoSQLMeta()
+
+	fmt.Println(schema.Validate("O Brien"))
+	fmt.Println(schema.Validate("O'Brien"))
+	fmt.Println(schema.Validate("1; DROP TABLE users --"))
+
+	// Output:
+	// <nil>
+	// string must not contain SQL metacharacters
+	// string must not contain SQL metacharacters
+}
+
+func ExampleStringSchema_SafeFilename() {
+	schema := valtor.String().SafeFilename()
+
+	fmt.Println(schema.Validate("report-2025.pdf"))
+	fmt.Println(schema.Validate("../../etc/passwd"))
+	fmt.Println(schema.Validate("invoice.pdf\x00.exe"))
+	fmt.Println(schema.Validate("con.txt"))
+
+	// Output:
+	// <nil>
+	// string must be a safe file name
+	// string must be a safe file name
+	// string must be a safe file name
+}
+
+func ExampleSanitizeFilename() {
+	for _, name := range []string{
+		"report-2025.pdf",
+		"../../etc/passwd",
+		"C:\\Users\\gopher\\notes.txt",
+		"invoice.pdf\x00.exe",
+		"con.txt",
+		"..",
+		"draft. ",
+	} {
+		fmt.Printf("%q\n", valtor.SanitizeFilename(name))
+	}
+	fmt.Println(len(valtor.SanitizeFilename(strings.Repeat("a", 300) + ".txt")))
+
+	// Output:
+	// "report-2025.pdf"
+	// ".._.._etc_passwd"
+	// "C__Users_gopher_notes.txt"
+	// "invoice.pdf_.exe"
+	// "_con.txt"
+	// "_"
+	// "draft"
+	// 255
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleSet() {
+	schema := valtor.Set[string]().
+		Subset("read", "write", "admin").
+		Superset("read")
+
+	err := schema.Validate([]string{"write", "read"})
+	fmt.Println(err)
+	err = schema.Validate([]string{"read", "read"})
+	fmt.Println(err)
+	err = schema.Validate([]string{"read", "delete"})
+	fmt.Println(err)
+	err = schema.Validate([]string{"write"})
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// array items must be unique (duplicate found at index 1)
+	// set item "delete" at index 1 is not allowed, must be one of "read", "write", "admin"
+	// set must contain "read"
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleValidateResult() {
+	type Request struct {
+		Name     string
+		Username string // Deprecated: use Name.
+	}
+
+	schema := valtor.Object[Request]()
+	valtor.FieldOf(schema, "name", func(r Request) string { return r.Name }, valtor.String().Required())
+	schema.Rule(valtor.NewRule(func(r Request) error {
+		if r.Username != "" {
+			return errors.New("field `username` is deprecated")
+		}
+		return nil
+	}).Severity(valtor.SeverityWarning).Hint("use `name` instead"))
+
+	result := valtor.ValidateResult(context.Background(), schema, Request{Name: "Gopher", Username: "gopher"})
+	fmt.Println(result.Valid(), result.Warnings)
+
+	var ruleErr *valtor.RuleError
+	if errors.As(result.Warnings[0], &ruleErr) {
+		fmt.Println(ruleErr.Severity, ruleErr.Hint)
+	}
+
+	result = valtor.ValidateResult(context.Background(), schema, Request{Username: "gopher"})
+	fmt.Println(result.Valid(), result.Err)
+
+	// Output:
+	// true [field `username` is deprecated]
+	// warning use `name` instead
+	// false validation failed for field "name": value is required
+}
+
+func ExampleSchema_Deprecated() {
+	schema := valtor.New[string]().Deprecated("use `locale` instead")
+
+	for _, lang := range []string{"", "en"} {
+		result := valtor.ValidateResult(context.Background(), schema, lang)
+		for _, warning := range result.Warnings {
+			var ruleErr *valtor.RuleError
+			errors.As(warning, &ruleErr)
+			fmt.Printf("%q: %v (%s)\n", lang, warning, ruleErr.Hint)
+		}
+	}
+
+	// Output:
+	// "en": value is deprecated (use `locale` instead)
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleShadow() {
+	current := valtor.String().Max(10)
+	upcoming := valtor.String().Max(5)
+
+	schema := valtor.Shadow(current, upcoming).
+		OnFailure(func(_ context.Context, value string, err error) {
+			fmt.Printf("shadow: %q: %v\n", value, err)
+		})
+
+	err := schema.Validate("foobar")
+	fmt.Println(err)
+	err = schema.Validate("foobarbazqux")
+	fmt.Println(err)
+	fmt.Println(schema.Failures())
+
+	// Output:
+	// shadow: "foobar": length must be at most 5, got 6
+	// <nil>
+	// length must be at most 10, got 12
+	// 1
+}
@@ -15,8 +15,12 @@
 package valtor_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"regexp"
+	"time"
 
 	"github.com/dstotijn/valtor"
 )
@@ -38,7 +42,7 @@ func ExampleString() {
 	// Output:
 	// <nil>
 	// <nil>
-	// length must be at most 8
+	// length must be at most 8, got 10
 	// string must match pattern "^[a-zA-Z]*$"
 }
 
@@ -62,7 +66,7 @@ func ExampleStringSchema_Min() {
 
 	// Output:
 	// <nil>
-	// length must be at least 3
+	// length must be at least 3, got 2
 }
 
 func ExampleStringSchema_Max() {
@@ -75,7 +79,7 @@ func ExampleStringSchema_Max() {
 
 	// Output:
 	// <nil>
-	// length must be at most 5
+	// length must be at most 5, got 8
 }
 
 func ExampleStringSchema_Length() {
@@ -88,7 +92,7 @@ func ExampleStringSchema_Length() {
 
 	// Output:
 	// <nil>
-	// length must be exactly 5
+	// length must be exactly 5, got 8
 }
 
 func ExampleStringSchema_Regexp() {
@@ -104,6 +108,24 @@ func ExampleStringSchema_Regexp() {
 	// string must match pattern "^[a-z]+$"
 }
 
+func ExampleStringSchema_Pattern() {
+	schema, err := valtor.String().Required().Pattern(`^[a-z]+$`)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(schema.Validate("hello"))
+	fmt.Println(schema.Validate("Hello123"))
+
+	_, err = valtor.String().Pattern(`^[a-z+$`)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// string must match pattern "^[a-z]+$"
+	// invalid pattern "^[a-z+$": error parsing regexp: missing closing ]: `[a-z+$`
+}
+
 func ExampleStringSchema_Custom() {
 	schema := valtor.String().Custom(func(s string) error {
 		if s == "hello" {
@@ -121,3 +143,157 @@ func ExampleStringSchema_Custom() {
 	// <nil>
 	// invalid string
 }
+
+func ExampleStringSchema_LengthMode() {
+	bytesSchema := valtor.String().Max(5)
+	runesSchema := valtor.String().Max(5).LengthMode(valtor.LengthRunes)
+
+	err := bytesSchema.Validate("héllo")
+	fmt.Println(err)
+	err = runesSchema.Validate("héllo")
+	fmt.Println(err)
+
+	// Output:
+	// length must be at most 5, got 6
+	// <nil>
+}
+
+func ExampleStringSchema_HasPrefix() {
+	schema := valtor.String().HasPrefix("sk_").NotContains(" ")
+
+	err := schema.Validate("sk_live_123")
+	fmt.Println(err)
+	err = schema.Validate("pk_live_123")
+	fmt.Println(err)
+	err = schema.Validate("sk_live 123")
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// string must start with "sk_"
+	// string must not contain " "
+}
+
+func ExampleStringSchema_Alphanumeric() {
+	schema := valtor.String().Alphanumeric()
+
+	err := schema.Validate("abc123")
+	fmt.Println(err)
+	err = schema.Validate("abc-123")
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// string must only contain letters and digits
+}
+
+func ExampleStringSchema_NoControlChars() {
+	schema := valtor.String().ASCII().NoControlChars()
+
+	err := schema.Validate("hello world")
+	fmt.Println(err)
+	err = schema.Validate("héllo")
+	fmt.Println(err)
+	err = schema.Validate("hello\x00world")
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// string must only contain ASCII characters
+	// string must not contain control characters
+}
+
+func ExampleStringSchema_Format() {
+	schema := valtor.String().Format("semver")
+
+	err := schema.Validate("1.2.3")
+	fmt.Println(err)
+	err = schema.Validate("v1.2")
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// string must be a valid semantic version
+}
+
+func ExampleStringSchema_OneOf() {
+	schema := valtor.String().OneOf("draft", "published", "archived")
+
+	err := schema.Validate("published")
+	fmt.Println(err)
+	err = schema.Validate("deleted")
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// value must be one of "draft", "published", "archived"
+}
+
+func ExampleStringSchema_OneOf_many() {
+	schema := valtor.String().OneOf("ar", "de", "en", "es", "fr", "hi", "it", "ja", "ko", "nl", "pt", "zh")
+
+	err := schema.Validate("xx")
+	fmt.Println(err)
+
+	var constraintErr *valtor.ConstraintError
+	if errors.As(err, &constraintErr) {
+		fmt.Println(len(constraintErr.Params["allowed"].([]any)))
+	}
+
+	// Output:
+	// value must be one of "ar", "de", "en", "es", "fr", "hi", "it", "ja", "ko", "nl", ... (and 2 more)
+	// 12
+}
+
+// fakeResolver resolves the domains of ExampleWithMXCheck without network
+// access.
+type fakeResolver struct{}
+
+func (fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	switch name {
+	case "example.com":
+		return []*net.MX{{Host: "mail.example.com.", Pref: 10}}, nil
+	case "example.net":
+		// A null MX record: the domain doesn't accept mail.
+		return []*net.MX{{Host: "."}}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func ExampleWithMXCheck() {
+	schema := valtor.String().Email(valtor.WithMXCheck(fakeResolver{}), valtor.WithLookupTimeout(time.Second))
+
+	fmt.Println(schema.Validate("jane@example.com"))
+	fmt.Println(schema.Validate("jane@example.net"))
+
+	err := schema.Validate("jane@example.invalid")
+	fmt.Println(err, errors.Is(err, valtor.ErrUndeliverable))
+
+	// Output:
+	// <nil>
+	// email domain "example.net" can't receive mail
+	// email domain "example.invalid" can't receive mail true
+}
+
+func ExampleStringSchema_TimeZone() {
+	type Preferences struct {
+		TimeZone string
+		Accent   string
+	}
+
+	schema := valtor.Object[Preferences]().AllErrors()
+	valtor.FieldOf(schema, "timeZone", func(p Preferences) string { return p.TimeZone }, valtor.String().TimeZone())
+	valtor.FieldOf(schema, "accent", func(p Preferences) string { return p.Accent }, valtor.String().HexColor())
+
+	fmt.Println(schema.Validate(Preferences{TimeZone: "Asia/Taipei", Accent: "#00aaff"}))
+	fmt.Println(schema.Validate(Preferences{TimeZone: "CET+1", Accent: "blue"}))
+
+	// Output:
+	// <nil>
+	// validation failed for field "timeZone": string must be a valid time zone
+	// validation failed for field "accent": string must be a valid hex color
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleRun() {
+	schema := valtor.String().TrimSpace().Required().Max(10)
+	schema.Transform(valtor.StripHTML)
+	schema.Deprecated("use the display name instead")
+
+	out := valtor.Run(context.Background(), schema, "  <b>Gopher</b> ")
+	fmt.Printf("%q %v %v\n", out.Value, out.Valid(), out.Warnings)
+
+	out = valtor.Run(context.Background(), schema, "   ")
+	fmt.Printf("%q %v\n", out.Value, out.Err)
+
+	// Validate checks values as is.
+	fmt.Println(schema.Validate("  <b>Gopher</b> "))
+
+	// Output:
+	// "Gopher" true [value is deprecated]
+	// "" value is required
+	// length must be at most 10, got 16
+}
+
+func ExampleSchema_Transform() {
+	type Signup struct {
+		Email string
+	}
+
+	schema := valtor.Object[Signup]()
+	valtor.FieldOf(schema, "email", func(s Signup) string { return s.Email }, valtor.String().Email())
+	schema.Transform(func(s Signup) Signup {
+		s.Email = strings.ToLower(strings.TrimSpace(s.Email))
+		return s
+	})
+
+	out := valtor.Run(context.Background(), schema, Signup{Email: " Gopher@Example.com"})
+	fmt.Println(out.Value.Email, out.Err)
+
+	// Output:
+	// gopher@example.com <nil>
+}
+
+func ExampleFieldRef() {
+	type Signup struct {
+		Name string
+		Tags []string
+	}
+
+	schema := valtor.Object[Signup]()
+	valtor.FieldRef(schema, "name", func(s *Signup) *string { return &s.Name }, valtor.String().TrimSpace().Required())
+	valtor.FieldRef(schema, "tags", func(s *Signup) *[]string { return &s.Tags }, valtor.Array[string]().ItemsOf(valtor.String().TrimSpace()))
+
+	out := valtor.Run(context.Background(), schema, Signup{Name: " Gopher ", Tags: []string{" go "}})
+	fmt.Printf("%q %q %v\n", out.Value.Name, out.Value.Tags, out.Err)
+
+	out = valtor.Run(context.Background(), schema, Signup{Name: "   "})
+	fmt.Printf("%q %v\n", out.Value.Name, out.Err)
+
+	// Output:
+	// "Gopher" ["go"] <nil>
+	// "" validation failed for field "name": value is required
+}
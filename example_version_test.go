@@ -0,0 +1,61 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleSchemaSet() {
+	type ContactV1 struct {
+		Name string `json:"name"`
+	}
+	type ContactV2 struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	}
+
+	v1 := valtor.Object[ContactV1]()
+	valtor.FieldOf(v1, "name", func(c ContactV1) string { return c.Name }, valtor.String().Required())
+
+	v2 := valtor.Object[ContactV2]()
+	valtor.FieldOf(v2, "first_name", func(c ContactV2) string { return c.FirstName }, valtor.String().Required())
+	valtor.FieldOf(v2, "last_name", func(c ContactV2) string { return c.LastName }, valtor.String().Required())
+
+	set := valtor.NewSchemaSet("v2", v2)
+	valtor.AddVersion(set, "v1", v1)
+	valtor.AddMigration(set, "v1", "v2", func(c ContactV1) (ContactV2, error) {
+		first, last, _ := strings.Cut(c.Name, " ")
+		return ContactV2{FirstName: first, LastName: last}, nil
+	})
+
+	ctx := context.Background()
+	fmt.Println(set.Upgrade(ctx, "v1", []byte(`{"name": "Ada Lovelace"}`)))
+	fmt.Println(set.Upgrade(ctx, "v1", []byte(`{"name": ""}`)))
+	fmt.Println(set.Upgrade(ctx, "v1", []byte(`{"name": "Ada"}`)))
+	fmt.Println(set.Upgrade(ctx, "v2", []byte(`{"first_name": "Grace", "last_name": "Hopper"}`)))
+	fmt.Println(set.Validate("v3", []byte(`{}`)))
+
+	// Output:
+	// {Ada Lovelace} <nil>
+	// { } validation failed for field "name": value is required
+	// { } invalid result of migration from version "v1" to "v2": validation failed for field "last_name": value is required
+	// {Grace Hopper} <nil>
+	// unknown schema version "v3"
+}
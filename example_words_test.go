@@ -0,0 +1,70 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor_test
+
+import (
+	"errors"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/dstotijn/valtor"
+)
+
+func ExampleStringSchema_NotInList() {
+	// Lists can also be embedded with embed.FS, or read with os.DirFS.
+	fsys := fstest.MapFS{
+		"reserved.txt": {Data: []byte("# Reserved usernames\nadmin\nroot\nsupport\n")},
+	}
+	reserved, err := valtor.ReadWordsFile(fsys, "reserved.txt")
+	if err != nil {
+		panic(err)
+	}
+
+	schema := valtor.String().Required().NotInList(reserved)
+
+	fmt.Println(schema.Validate("gopher"))
+	fmt.Println(schema.Validate("Admin"))
+
+	// Output:
+	// <nil>
+	// value is not allowed
+}
+
+func ExampleStringSchema_DenyWords() {
+	schema := valtor.String().DenyWords(valtor.Words("darn", "heck"))
+
+	fmt.Println(schema.Validate("What a lovely day"))
+
+	err := schema.Validate("Oh heck, not again")
+	var constraintErr *valtor.ConstraintError
+	if errors.As(err, &constraintErr) {
+		fmt.Println(constraintErr.Code, constraintErr.Params["word"], err)
+	}
+
+	// Output:
+	// <nil>
+	// denied_word heck string contains a word that is not allowed
+}
+
+func ExampleStringSchema_InList() {
+	schema := valtor.String().InList(valtor.Words("EUR", "USD", "GBP"))
+
+	fmt.Println(schema.Validate("usd"))
+	fmt.Println(schema.Validate("XYZ"))
+
+	// Output:
+	// <nil>
+	// value must be one of the allowed values
+}
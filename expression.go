@@ -0,0 +1,62 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExpressionRule is an expression that must hold for valid values, with the
+// message of the error returned otherwise, e.g. as read from a configuration
+// file. It is the rule type of the expression languages of packages
+// valtorexpr and valtorcel, which compile it into a Rule.
+type ExpressionRule struct {
+	Expr    string `json:"rule"`
+	Message string `json:"message,omitempty"` // Defaults to `value must satisfy <expr>`.
+}
+
+// UnmarshalJSON decodes a rule from an object, e.g.
+// `{"rule": "max >= min", "message": "..."}`, or from the expression as a
+// string, e.g. `"max >= min"`, as in the parameters of an extension.
+func (r *ExpressionRule) UnmarshalJSON(data []byte) error {
+	var expr string
+	if err := json.Unmarshal(data, &expr); err == nil {
+		*r = ExpressionRule{Expr: expr}
+		return nil
+	}
+
+	type rule ExpressionRule // Without the UnmarshalJSON method.
+	var v rule
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("expected string or object: %w", err)
+	}
+	*r = ExpressionRule(v)
+	return nil
+}
+
+// Failure returns the error for a value for which the expression doesn't hold:
+// a *ConstraintError with code CodeExpression and the expression as parameter.
+func (r ExpressionRule) Failure() *ConstraintError {
+	message := r.Message
+	if message == "" {
+		message = fmt.Sprintf("value must satisfy %s", r.Expr)
+	}
+	return &ConstraintError{
+		Code:    CodeExpression,
+		Params:  map[string]any{"expression": r.Expr},
+		Message: message,
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Extension is a named rule published by a third-party validator pack, e.g.
+// `luhn`. Parse creates a validator from the parameters of the rule, encoded
+// as JSON. Integrations that read rules by name, such as JSON Schema extension
+// keywords in package valtorjsonschema, pass the keyword value as parameters.
+type Extension struct {
+	Name  string
+	Parse func(params json.RawMessage) (func(any) error, error)
+
+	// rule is the named rule of an extension added with AddRule.
+	rule any
+}
+
+// Pack is a set of extensions, e.g. as published by a third-party module.
+// Validators that are too heavy for the core package, such as those of
+// packages valtorphone and valtorimage, publish packs as well, so that they
+// are only linked into programs that register them.
+type Pack interface {
+	Extensions() []Extension
+}
+
+// DefaultExtensions is the registry used by Use and LookupExtension.
+var DefaultExtensions = NewExtensionRegistry()
+
+// ExtensionRegistry maps extension names to extensions. It is safe for
+// concurrent use.
+type ExtensionRegistry struct {
+	mu         sync.RWMutex
+	extensions map[string]Extension
+}
+
+// NewExtensionRegistry creates a new, empty extension registry.
+func NewExtensionRegistry() *ExtensionRegistry {
+	return &ExtensionRegistry{
+		extensions: make(map[string]Extension),
+	}
+}
+
+// Use registers the extensions of a pack. It fails without registering any
+// extension if one of them has no name or parser, or if its name is already
+// registered, so that packs cannot silently replace each other's rules.
+func (r *ExtensionRegistry) Use(pack Pack) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exts := pack.Extensions()
+	seen := make(map[string]struct{}, len(exts))
+	for _, ext := range exts {
+		if ext.Name == "" || ext.Parse == nil {
+			return fmt.Errorf("invalid extension %q: name and parser are required", ext.Name)
+		}
+		if _, ok := r.extensions[ext.Name]; ok {
+			return fmt.Errorf("extension %q is already registered", ext.Name)
+		}
+		if _, ok := seen[ext.Name]; ok {
+			return fmt.Errorf("extension %q is defined more than once", ext.Name)
+		}
+		seen[ext.Name] = struct{}{}
+	}
+	for _, ext := range exts {
+		r.extensions[ext.Name] = ext
+	}
+	return nil
+}
+
+// add registers an extension. Like Use, it fails if an extension with its
+// name is already registered.
+func (r *ExtensionRegistry) add(ext Extension) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.extensions[ext.Name]; ok {
+		return fmt.Errorf("extension %q is already registered", ext.Name)
+	}
+	r.extensions[ext.Name] = ext
+	return nil
+}
+
+// ruleNames returns the names of the extensions that are named rules, in
+// sorted order.
+func (r *ExtensionRegistry) ruleNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	for name, ext := range r.extensions {
+		if ext.rule != nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Lookup returns the extension with the given name.
+func (r *ExtensionRegistry) Lookup(name string) (Extension, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ext, ok := r.extensions[name]
+	return ext, ok
+}
+
+// Use registers the extensions of a pack in the DefaultExtensions registry.
+func Use(pack Pack) error {
+	return DefaultExtensions.Use(pack)
+}
+
+// LookupExtension returns the extension with the given name from the
+// DefaultExtensions registry.
+func LookupExtension(name string) (Extension, bool) {
+	return DefaultExtensions.Lookup(name)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formats
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HexColor validates that s is a hexadecimal CSS color of 3, 4, 6 or 8 digits
+// with a leading `#`, e.g. `#0af` or `#00aaff80`.
+func HexColor(s string) error {
+	digits, ok := strings.CutPrefix(s, "#")
+	if !ok || (len(digits) != 3 && len(digits) != 4 && len(digits) != 6 && len(digits) != 8) {
+		return invalid("hex color")
+	}
+	for i := range len(digits) {
+		if !isHex(digits[i]) {
+			return invalid("hex color")
+		}
+	}
+	return nil
+}
+
+// RGBColor validates that s is a CSS color in functional RGB notation, with
+// comma or space separated channels of 0 to 255 or 0% to 100%, and an optional
+// alpha channel of 0 to 1 or 0% to 100%, e.g. `rgb(0, 170, 255)`,
+// `rgba(0, 170, 255, 0.5)` or `rgb(0% 67% 100% / 50%)`.
+func RGBColor(s string) error {
+	args, ok := strings.CutPrefix(s, "rgb(")
+	if !ok {
+		args, ok = strings.CutPrefix(s, "rgba(")
+	}
+	args, closed := strings.CutSuffix(args, ")")
+	if !ok || !closed {
+		return invalid("RGB color")
+	}
+
+	var channels []string
+	alpha := ""
+	if strings.Contains(args, ",") {
+		channels = strings.Split(args, ",")
+		for i := range channels {
+			channels[i] = strings.TrimSpace(channels[i])
+		}
+		if len(channels) == 4 {
+			alpha = channels[3]
+			channels = channels[:3]
+		}
+	} else {
+		var hasAlpha bool
+		args, alpha, hasAlpha = strings.Cut(args, "/")
+		alpha = strings.TrimSpace(alpha)
+		if hasAlpha && alpha == "" {
+			return invalid("RGB color")
+		}
+		channels = strings.Fields(args)
+	}
+	if len(channels) != 3 {
+		return invalid("RGB color")
+	}
+
+	// Channels are either all numbers or all percentages.
+	percent := strings.HasSuffix(channels[0], "%")
+	for _, channel := range channels {
+		if strings.HasSuffix(channel, "%") != percent {
+			return invalid("RGB color")
+		}
+		max := 255.0
+		if percent {
+			channel, max = strings.TrimSuffix(channel, "%"), 100
+		}
+		if !inRange(channel, max) {
+			return invalid("RGB color")
+		}
+	}
+	if alpha != "" {
+		if value, ok := strings.CutSuffix(alpha, "%"); (ok && !inRange(value, 100)) || (!ok && !inRange(alpha, 1)) {
+			return invalid("RGB color")
+		}
+	}
+	return nil
+}
+
+// inRange reports whether s is a decimal number, without sign or exponent,
+// between 0 and max.
+func inRange(s string, max float64) bool {
+	digits := 0
+	dots := 0
+	for i := range len(s) {
+		switch {
+		case isDigit(s[i]):
+			digits++
+		case s[i] == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	if digits == 0 || dots > 1 {
+		return false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return err == nil && f <= max
+}
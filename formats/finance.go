@@ -0,0 +1,165 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formats
+
+import "strings"
+
+// countryCodes are the officially assigned ISO 3166-1 alpha-2 codes.
+var countryCodes = codeSet(`
+AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL
+BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV
+CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD
+GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM
+IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK
+LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW
+MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR
+PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS
+ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY
+UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW
+`)
+
+// currencyCodes are the active ISO 4217 currency codes, including funds and
+// precious metals.
+var currencyCodes = codeSet(`
+AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV
+BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK
+DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL
+HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT
+LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR
+MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF
+SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP
+TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF XAG XAU
+XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF XPT XSU XTS XUA XXX YER ZAR ZMW ZWG
+`)
+
+// ibanLengths are the IBAN lengths per country, as published in the SWIFT IBAN
+// registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HN": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20,
+	"LU": 20, "LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20,
+	"MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24,
+	"SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25,
+	"SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+	"YE": 30,
+}
+
+func codeSet(codes string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, code := range strings.Fields(codes) {
+		set[code] = struct{}{}
+	}
+	return set
+}
+
+// CreditCard validates that s is a payment card number of 12 to 19 digits with
+// a valid Luhn check digit. Spaces and hyphens are not allowed.
+func CreditCard(s string) error {
+	if len(s) < 12 || len(s) > 19 {
+		return invalid("credit card number")
+	}
+
+	var sum int
+	for i := range len(s) {
+		c := s[len(s)-1-i]
+		if !isDigit(c) {
+			return invalid("credit card number")
+		}
+		digit := int(c - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	if sum%10 != 0 {
+		return invalid("credit card number")
+	}
+	return nil
+}
+
+// IBAN validates that s is an International Bank Account Number in electronic
+// format (uppercase, without spaces), with the length of its country and a
+// valid mod 97 checksum as defined by ISO 13616.
+func IBAN(s string) error {
+	if len(s) < 4 || !isDigit(s[2]) || !isDigit(s[3]) {
+		return invalid("IBAN")
+	}
+	if length, ok := ibanLengths[s[:2]]; !ok || len(s) != length {
+		return invalid("IBAN")
+	}
+
+	// Move the country code and check digits to the end, convert letters to
+	// numbers (A = 10, ..., Z = 35) and compute the remainder incrementally.
+	var remainder int
+	for i := range len(s) {
+		switch c := s[(i+4)%len(s)]; {
+		case isUpper(c):
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		case isDigit(c):
+			remainder = (remainder*10 + int(c-'0')) % 97
+		default:
+			return invalid("IBAN")
+		}
+	}
+	if remainder != 1 {
+		return invalid("IBAN")
+	}
+	return nil
+}
+
+// BIC validates that s is a Business Identifier Code (SWIFT code) as defined
+// by ISO 9362, e.g. `DEUTDEFF` or `DEUTDEFF500`, with a valid country code.
+func BIC(s string) error {
+	if len(s) != 8 && len(s) != 11 {
+		return invalid("BIC")
+	}
+	for i := range len(s) {
+		// Institution and country codes are letters; the rest may be digits.
+		if !isUpper(s[i]) && (i < 6 || !isDigit(s[i])) {
+			return invalid("BIC")
+		}
+	}
+	// Kosovo has no ISO 3166 code, but uses the user-assigned `XK`.
+	if country := s[4:6]; CountryCode(country) != nil && country != "XK" {
+		return invalid("BIC")
+	}
+	return nil
+}
+
+// CountryCode validates that s is an uppercase ISO 3166-1 alpha-2 country
+// code, e.g. `NL`.
+func CountryCode(s string) error {
+	if _, ok := countryCodes[s]; !ok {
+		return invalid("ISO 3166-1 alpha-2 country code")
+	}
+	return nil
+}
+
+// CurrencyCode validates that s is an uppercase, active ISO 4217 currency
+// code, e.g. `EUR`.
+func CurrencyCode(s string) error {
+	if _, ok := currencyCodes[s]; !ok {
+		return invalid("ISO 4217 currency code")
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package formats provides validators for common string formats, such as
+// hostnames, phone numbers, semantic versions, colors and time zones.
+// Validators can be used directly, or by name via valtor.StringSchema.Format.
+// Formats that need data beyond the standard library, such as `language-tag`,
+// are provided by other packages (see valtorlocale.RegisterFormats).
+package formats
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidFormat = errors.New("invalid format")
+
+// FormatError is returned when a string does not match a format. It matches
+// ErrInvalidFormat with errors.Is.
+type FormatError struct {
+	Name string
+}
+
+func (e *FormatError) Error() string {
+	return "string must be a valid " + e.Name
+}
+
+// Is reports whether target is ErrInvalidFormat.
+func (e *FormatError) Is(target error) bool {
+	return target == ErrInvalidFormat
+}
+
+func invalid(name string) error {
+	return &FormatError{Name: name}
+}
+
+var builtin = map[string]func(string) error{
+	"hostname":  Hostname,
+	"e164":      E164,
+	"semver":    Semver,
+	"slug":      Slug,
+	"ulid":      ULID,
+	"base64":    Base64,
+	"base64url": Base64URL,
+	"hex":       Hex,
+	"jwt":       JWT,
+	"uuid":      UUID,
+	"email":     Email,
+
+	"credit-card":    CreditCard,
+	"iban":           IBAN,
+	"bic":            BIC,
+	"iso3166-alpha2": CountryCode,
+	"iso4217":        CurrencyCode,
+
+	"hex-color": HexColor,
+	"rgb-color": RGBColor,
+	"timezone":  TimeZone,
+}
+
+// Default is the registry used by Register and Lookup.
+var Default = NewRegistry()
+
+// Registry maps format names to validators. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	formats map[string]func(string) error
+}
+
+// NewRegistry creates a new registry that contains the built-in formats.
+func NewRegistry() *Registry {
+	return &Registry{
+		formats: maps.Clone(builtin),
+	}
+}
+
+// Register registers a validator for the format with the given name. If the
+// format already exists, its validator is replaced.
+func (r *Registry) Register(name string, fn func(string) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.formats[name] = fn
+}
+
+// Lookup returns the validator for the format with the given name.
+func (r *Registry) Lookup(name string) (func(string) error, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.formats[name]
+	return fn, ok
+}
+
+// Register registers a validator for the format with the given name in the
+// Default registry.
+func Register(name string, fn func(string) error) {
+	Default.Register(name, fn)
+}
+
+// Lookup returns the validator for the format with the given name from the
+// Default registry.
+func Lookup(name string) (func(string) error, bool) {
+	return Default.Lookup(name)
+}
+
+var semverRegexp = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// The formats below are validated with hand-written parsers instead of regular
+// expressions where possible, as they are often used on hot request paths.
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+func isLower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || isUpper(c) || isLower(c)
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// isHostnameLabel reports whether s is a hostname label of 1 to 63 letters,
+// digits and hyphens, that does not start or end with a hyphen.
+func isHostnameLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := range len(s) {
+		if !isAlnum(s[i]) && s[i] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// Hostname validates that s is a hostname as defined by RFC 1123. A single
+// trailing dot is allowed.
+func Hostname(s string) error {
+	name := strings.TrimSuffix(s, ".")
+	if name == "" || len(name) > 253 {
+		return invalid("hostname")
+	}
+	for label := range strings.SplitSeq(name, ".") {
+		if !isHostnameLabel(label) {
+			return invalid("hostname")
+		}
+	}
+	return nil
+}
+
+// E164 validates that s is a phone number in E.164 format, e.g. `+14155552671`.
+func E164(s string) error {
+	if len(s) < 3 || len(s) > 16 || s[0] != '+' || s[1] == '0' {
+		return invalid("E.164 phone number")
+	}
+	for i := 1; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return invalid("E.164 phone number")
+		}
+	}
+	return nil
+}
+
+// UUID validates that s is a UUID in its canonical textual representation as
+// defined by RFC 9562, e.g. `f81d4fae-7dec-11d0-a765-00a0c91e6bf6`. Both
+// lowercase and uppercase hexadecimal digits are allowed.
+func UUID(s string) error {
+	if len(s) != 36 {
+		return invalid("UUID")
+	}
+	for i := range len(s) {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if s[i] != '-' {
+				return invalid("UUID")
+			}
+			continue
+		}
+		if !isHex(s[i]) {
+			return invalid("UUID")
+		}
+	}
+	return nil
+}
+
+// Email validates that s is an email address with a dot-atom local part (as
+// defined by RFC 5322, without quoted strings or comments) and a hostname
+// domain, e.g. `john.doe+news@example.com`.
+func Email(s string) error {
+	local, domain, ok := strings.Cut(s, "@")
+	if !ok || local == "" || len(local) > 64 || Hostname(domain) != nil {
+		return invalid("email address")
+	}
+	if local[0] == '.' || local[len(local)-1] == '.' || strings.Contains(local, "..") {
+		return invalid("email address")
+	}
+	for i := range len(local) {
+		if c := local[i]; !isAlnum(c) && c != '.' && !strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", rune(c)) {
+			return invalid("email address")
+		}
+	}
+	return nil
+}
+
+// Semver validates that s is a semantic version as defined by Semantic
+// Versioning 2.0.0, e.g. `1.2.3-beta.1+build.5`. A `v` prefix is not allowed.
+func Semver(s string) error {
+	if !semverRegexp.MatchString(s) {
+		return invalid("semantic version")
+	}
+	return nil
+}
+
+// Slug validates that s is a URL slug: lowercase letters and digits, separated
+// by single hyphens, e.g. `hello-world-2`.
+func Slug(s string) error {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' {
+		return invalid("slug")
+	}
+	for i := range len(s) {
+		switch c := s[i]; {
+		case isLower(c) || isDigit(c):
+		case c == '-' && s[i-1] != '-':
+		default:
+			return invalid("slug")
+		}
+	}
+	return nil
+}
+
+// ULID validates that s is a ULID: 26 characters of Crockford's base32, with a
+// timestamp that does not overflow 48 bits.
+func ULID(s string) error {
+	if len(s) != 26 || s[0] < '0' || s[0] > '7' {
+		return invalid("ULID")
+	}
+	for i := 1; i < len(s); i++ {
+		switch c := s[i] | 0x20; { // Lowercase letters, keep digits.
+		case isDigit(s[i]):
+		case isLower(c) && c != 'i' && c != 'l' && c != 'o' && c != 'u':
+		default:
+			return invalid("ULID")
+		}
+	}
+	return nil
+}
+
+// Base64 validates that s is padded standard base64 as defined by RFC 4648.
+func Base64(s string) error {
+	if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+		return invalid("base64 string")
+	}
+	return nil
+}
+
+// Base64URL validates that s is URL-safe base64 as defined by RFC 4648, with or
+// without padding.
+func Base64URL(s string) error {
+	enc := base64.URLEncoding
+	if !strings.HasSuffix(s, "=") {
+		enc = base64.RawURLEncoding
+	}
+	if _, err := enc.DecodeString(s); err != nil {
+		return invalid("base64url string")
+	}
+	return nil
+}
+
+// Hex validates that s is a non-empty string of hexadecimal digits.
+func Hex(s string) error {
+	if s == "" {
+		return invalid("hexadecimal string")
+	}
+	for i := range len(s) {
+		if !isHex(s[i]) {
+			return invalid("hexadecimal string")
+		}
+	}
+	return nil
+}
+
+// JWT validates that s has the structure of a JSON Web Token in compact
+// serialization: three unpadded base64url segments, of which the header and
+// payload decode to JSON objects. The signature is not verified.
+func JWT(s string) error {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return invalid("JWT")
+	}
+	for _, part := range parts[:2] {
+		b, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return invalid("JWT")
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(b, &obj); err != nil || obj == nil {
+			return invalid("JWT")
+		}
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return invalid("JWT")
+	}
+	return nil
+}
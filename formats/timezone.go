@@ -0,0 +1,44 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formats
+
+import (
+	"sync"
+	"time"
+)
+
+// timeZones caches the names of time zones that were loaded successfully.
+var timeZones sync.Map
+
+// TimeZone validates that s is the name of a time zone in the IANA Time Zone
+// Database, e.g. `Europe/Amsterdam` or `UTC`, as loaded by time.LoadLocation.
+// `Local` and the empty string are not valid. Valid names are cached, as
+// loading a time zone reads the database.
+//
+// The database of the operating system is used. Import time/tzdata to embed
+// a copy in programs that run on systems without one.
+func TimeZone(s string) error {
+	if s == "" || s == "Local" {
+		return invalid("time zone")
+	}
+	if _, ok := timeZones.Load(s); ok {
+		return nil
+	}
+	if _, err := time.LoadLocation(s); err != nil {
+		return invalid("time zone")
+	}
+	timeZones.Store(s, struct{}{})
+	return nil
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidGeoJSON is wrapped by errors of GeoJSONSchema for documents that
+// don't have the structure of GeoJSON, e.g. a polygon ring that is not closed.
+var ErrInvalidGeoJSON = errors.New("invalid GeoJSON")
+
+// Latitude creates a new validation schema for latitudes in decimal degrees,
+// which must be between -90 and 90.
+func Latitude(opts ...Option) *NumberSchema[float64] {
+	return Number[float64](opts...).Finite().Between(-90, 90)
+}
+
+// Longitude creates a new validation schema for longitudes in decimal degrees,
+// which must be between -180 and 180.
+func Longitude(opts ...Option) *NumberSchema[float64] {
+	return Number[float64](opts...).Finite().Between(-180, 180)
+}
+
+// geoJSONGeometries are the types of GeoJSON geometry objects.
+var geoJSONGeometries = []string{
+	"Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon", "GeometryCollection",
+}
+
+// GeoJSONSchema represents a validation schema for GeoJSON documents (RFC 7946)
+// in raw form, e.g. a json.RawMessage field of a struct.
+type GeoJSONSchema struct {
+	*Schema[json.RawMessage]
+	required bool
+	types    []string
+}
+
+// GeoJSON creates a new validation schema for GeoJSON documents. It checks the
+// structure of geometries, features and feature collections, and that
+// positions are a longitude and latitude (optionally followed by an altitude)
+// within range. Linear rings of polygons must have at least four positions and
+// be closed. Winding order is not checked, as RFC 7946 requires parsers to
+// accept either. An empty document is considered missing, and skips all other
+// validators.
+func GeoJSON(opts ...Option) *GeoJSONSchema {
+	s := &GeoJSONSchema{
+		Schema: New[json.RawMessage](opts...),
+	}
+	s.addValidator(func(v json.RawMessage) error {
+		obj, err := decodeGeoJSON(v)
+		if err != nil {
+			return err
+		}
+		if len(s.types) > 0 && !slices.Contains(s.types, obj.Type) {
+			return constraintError(CodeType, map[string]any{"type": obj.Type},
+				"GeoJSON type must be one of %s, got %q", strings.Join(s.types, ", "), obj.Type)
+		}
+		return obj.validate()
+	})
+	return s
+}
+
+// Required will make a GeoJSON document required to be not empty when
+// validated.
+func (s *GeoJSONSchema) Required() *GeoJSONSchema {
+	s.required = true
+	return s
+}
+
+// Types sets the allowed types of the document, e.g. `Point` or `Feature`, and
+// returns the schema for chaining. By default, all GeoJSON types are allowed.
+func (s *GeoJSONSchema) Types(types ...string) *GeoJSONSchema {
+	s.types = types
+	return s
+}
+
+// Validate validates the GeoJSON document against the schema and returns an
+// error if the document is not valid.
+func (s *GeoJSONSchema) Validate(value json.RawMessage) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the GeoJSON document against the schema with the
+// given context and returns an error if the document is not valid.
+func (s *GeoJSONSchema) ValidateContext(ctx context.Context, value json.RawMessage) error {
+	if len(value) == 0 {
+		if s.required {
+			return ErrValueRequired
+		}
+		return nil
+	}
+	return s.Schema.ValidateContext(ctx, value)
+}
+
+// geoJSONObject holds the members of any GeoJSON object.
+type geoJSONObject struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometries  []json.RawMessage `json:"geometries"`
+	Geometry    json.RawMessage   `json:"geometry"`
+	Features    []json.RawMessage `json:"features"`
+}
+
+func decodeGeoJSON(raw json.RawMessage) (geoJSONObject, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return obj, fmt.Errorf("failed to decode GeoJSON: %w", err)
+	}
+	if obj.Type == "" {
+		return obj, &FieldError{Field: "type", Err: ErrValueRequired}
+	}
+	return obj, nil
+}
+
+func (obj geoJSONObject) validate() error {
+	switch obj.Type {
+	case "Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon":
+		if len(obj.Coordinates) == 0 {
+			return &FieldError{Field: "coordinates", Err: ErrValueRequired}
+		}
+		if err := validateCoordinates(obj.Type, obj.Coordinates); err != nil {
+			return &FieldError{Field: "coordinates", Err: err}
+		}
+		return nil
+	case "GeometryCollection":
+		for i, raw := range obj.Geometries {
+			if err := validateGeoJSONMember(raw, geoJSONGeometries); err != nil {
+				return &FieldError{Field: "geometries", Err: fmt.Errorf("invalid geometry at index %d: %w", i, err)}
+			}
+		}
+		return nil
+	case "Feature":
+		// A feature without a location has a null geometry.
+		if len(obj.Geometry) == 0 {
+			return &FieldError{Field: "geometry", Err: ErrValueRequired}
+		}
+		if string(obj.Geometry) == "null" {
+			return nil
+		}
+		if err := validateGeoJSONMember(obj.Geometry, geoJSONGeometries); err != nil {
+			return &FieldError{Field: "geometry", Err: err}
+		}
+		return nil
+	case "FeatureCollection":
+		for i, raw := range obj.Features {
+			if err := validateGeoJSONMember(raw, []string{"Feature"}); err != nil {
+				return &FieldError{Field: "features", Err: fmt.Errorf("invalid feature at index %d: %w", i, err)}
+			}
+		}
+		return nil
+	default:
+		return &FieldError{Field: "type", Err: fmt.Errorf("%w: unknown type %q", ErrInvalidGeoJSON, obj.Type)}
+	}
+}
+
+// validateGeoJSONMember validates a nested GeoJSON object, which must be of
+// one of the given types.
+func validateGeoJSONMember(raw json.RawMessage, types []string) error {
+	obj, err := decodeGeoJSON(raw)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(types, obj.Type) {
+		return &FieldError{Field: "type", Err: fmt.Errorf("%w: type must be one of %s, got %q", ErrInvalidGeoJSON, strings.Join(types, ", "), obj.Type)}
+	}
+	return obj.validate()
+}
+
+// validateCoordinates validates the coordinates of a geometry of the given
+// type, other than GeometryCollection.
+func validateCoordinates(typ string, raw json.RawMessage) error {
+	switch typ {
+	case "Point":
+		var position []float64
+		if err := json.Unmarshal(raw, &position); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+		}
+		return validatePosition(position)
+	case "MultiPoint", "LineString":
+		var positions [][]float64
+		if err := json.Unmarshal(raw, &positions); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+		}
+		if typ == "LineString" {
+			return validateLineString(positions)
+		}
+		return validatePositions(positions)
+	case "MultiLineString", "Polygon":
+		var lines [][][]float64
+		if err := json.Unmarshal(raw, &lines); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+		}
+		if typ == "Polygon" {
+			return validatePolygon(lines)
+		}
+		for i, line := range lines {
+			if err := validateLineString(line); err != nil {
+				return fmt.Errorf("invalid line string at index %d: %w", i, err)
+			}
+		}
+		return nil
+	default: // MultiPolygon.
+		var polygons [][][][]float64
+		if err := json.Unmarshal(raw, &polygons); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+		}
+		for i, polygon := range polygons {
+			if err := validatePolygon(polygon); err != nil {
+				return fmt.Errorf("invalid polygon at index %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+}
+
+func validatePolygon(rings [][][]float64) error {
+	for i, ring := range rings {
+		if len(ring) < 4 {
+			return fmt.Errorf("%w: linear ring at index %d must have at least 4 positions, got %d", ErrInvalidGeoJSON, i, len(ring))
+		}
+		if err := validatePositions(ring); err != nil {
+			return fmt.Errorf("invalid linear ring at index %d: %w", i, err)
+		}
+		if !slices.Equal(ring[0], ring[len(ring)-1]) {
+			return fmt.Errorf("%w: linear ring at index %d must be closed", ErrInvalidGeoJSON, i)
+		}
+	}
+	return nil
+}
+
+func validateLineString(positions [][]float64) error {
+	if len(positions) < 2 {
+		return fmt.Errorf("%w: line string must have at least 2 positions, got %d", ErrInvalidGeoJSON, len(positions))
+	}
+	return validatePositions(positions)
+}
+
+func validatePositions(positions [][]float64) error {
+	for i, position := range positions {
+		if err := validatePosition(position); err != nil {
+			return fmt.Errorf("invalid position at index %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+var (
+	latitude  = Latitude()
+	longitude = Longitude()
+)
+
+// validatePosition validates a position: a longitude and latitude, optionally
+// followed by an altitude.
+func validatePosition(position []float64) error {
+	if len(position) < 2 {
+		return fmt.Errorf("%w: position must have at least 2 elements, got %d", ErrInvalidGeoJSON, len(position))
+	}
+	if err := longitude.Validate(position[0]); err != nil {
+		return fmt.Errorf("invalid longitude: %w", err)
+	}
+	if err := latitude.Validate(position[1]); err != nil {
+		return fmt.Errorf("invalid latitude: %w", err)
+	}
+	return nil
+}
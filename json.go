@@ -0,0 +1,85 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONSchema represents a validation schema for JSON documents in raw form,
+// e.g. a json.RawMessage field of a struct.
+type JSONSchema struct {
+	*Schema[json.RawMessage]
+	required bool
+}
+
+// JSON creates a new validation schema that checks if a raw document is well
+// formed JSON. An empty document is considered missing, and skips all other
+// validators.
+func JSON(opts ...Option) *JSONSchema {
+	s := &JSONSchema{
+		Schema: New[json.RawMessage](opts...),
+	}
+	s.addValidator(func(v json.RawMessage) error {
+		if !json.Valid(v) {
+			return constraintError(CodeJSON, nil, "value must be valid JSON")
+		}
+		return nil
+	})
+	return s
+}
+
+// JSONOf creates a new validation schema for raw JSON documents that are
+// decoded into a value of type T, which is validated with the given schema.
+// The document is decoded once, which also checks if it is well formed.
+func JSONOf[T any](schema Validator[T]) *JSONSchema {
+	s := &JSONSchema{
+		Schema: New[json.RawMessage](),
+	}
+	s.validators = append(s.validators, func(ctx context.Context, v json.RawMessage) error {
+		var value T
+		if err := json.Unmarshal(v, &value); err != nil {
+			return fmt.Errorf("failed to decode JSON: %w", err)
+		}
+		return validateContext(ctx, schema, value)
+	})
+	return s
+}
+
+// Required will make a JSON document required to be not empty when validated.
+func (s *JSONSchema) Required() *JSONSchema {
+	s.required = true
+	return s
+}
+
+// Validate validates the JSON document against the schema and returns an error
+// if the document is not valid.
+func (s *JSONSchema) Validate(value json.RawMessage) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the JSON document against the schema with the
+// given context and returns an error if the document is not valid.
+func (s *JSONSchema) ValidateContext(ctx context.Context, value json.RawMessage) error {
+	if len(value) == 0 {
+		if s.required {
+			return ErrValueRequired
+		}
+		return nil
+	}
+	return s.Schema.ValidateContext(ctx, value)
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import "context"
+
+// Key is a typed key for request-scoped values, e.g. the current user, tenant
+// or feature flags, that are passed to validators via the context of
+// ValidateContext. Rules read them with Value, e.g. in a function passed to
+// NewContextRule or Rule.EnabledIf. Keys are compared by identity, so create
+// them once with NewKey, typically as package-level variables.
+type Key[V any] struct {
+	name string
+}
+
+// NewKey creates a new key for values of type V. The name is only used for
+// debugging.
+func NewKey[V any](name string) *Key[V] {
+	return &Key[V]{name: name}
+}
+
+// With returns a copy of the context that carries the value for the key.
+func (k *Key[V]) With(ctx context.Context, value V) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+// Value returns the value for the key carried by the context and reports
+// whether it was found.
+func (k *Key[V]) Value(ctx context.Context) (V, bool) {
+	value, ok := ctx.Value(k).(V)
+	return value, ok
+}
+
+// String returns the name of the key.
+func (k *Key[V]) String() string {
+	return "valtor.Key(" + k.name + ")"
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+var (
+	// ErrLimitExceeded is wrapped by the ConstraintError of a payload that
+	// exceeds a safety limit of the schema (see Limits).
+	ErrLimitExceeded = errors.New("payload exceeds limit")
+	// ErrTooManyErrors is wrapped by the ConstraintError that is added when
+	// a schema stops validation because it reached its maximum number of
+	// errors (see WithMaxErrors). It wraps ErrLimitExceeded.
+	ErrTooManyErrors = fmt.Errorf("too many errors: %w", ErrLimitExceeded)
+)
+
+// Limits are safety limits on the size of payloads, to mitigate resource
+// exhaustion by deeply nested or giant documents. They are enforced by
+// ValidateJSON as the document is read, before it is decoded, and by
+// ObjectSchema.ValidateMap and ObjectSchema.ValidateContext for maps, before
+// any validator runs. A zero limit is not enforced.
+type Limits struct {
+	MaxDepth       int // Maximum nesting depth of objects and arrays, e.g. 2 for `{"tags": []}`.
+	MaxTotalItems  int // Maximum total number of array items and object members.
+	MaxStringBytes int // Maximum length in bytes of strings and object keys.
+}
+
+// Limits sets the safety limits of the schema and returns the schema for
+// chaining.
+func (s *Schema[T]) Limits(limits Limits) *Schema[T] {
+	s.limits = limits
+	return s
+}
+
+// Limits sets the safety limits of the schema and returns the schema for
+// chaining.
+func (s *ObjectSchema[T]) Limits(limits Limits) *ObjectSchema[T] {
+	s.Schema.Limits(limits)
+	return s
+}
+
+// merge returns the stricter of the limits, per limit.
+func (l Limits) merge(other Limits) Limits {
+	return Limits{
+		MaxDepth:       minLimit(l.MaxDepth, other.MaxDepth),
+		MaxTotalItems:  minLimit(l.MaxTotalItems, other.MaxTotalItems),
+		MaxStringBytes: minLimit(l.MaxStringBytes, other.MaxStringBytes),
+	}
+}
+
+// minLimit returns the lower of two limits, of which 0 means no limit.
+func minLimit(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// payloadLimits returns the safety limits of the schema.
+func (s *Schema[T]) payloadLimits() Limits {
+	return s.limits
+}
+
+// limiter is implemented by schemas with safety limits.
+type limiter interface {
+	payloadLimits() Limits
+}
+
+// limitChecker enforces limits on a single payload.
+type limitChecker struct {
+	limits Limits
+	items  int
+	sorted bool // Whether to check map keys in order.
+}
+
+// enter checks the depth of an object or array at path, where depth is 1 for
+// a top-level object or array.
+func (c *limitChecker) enter(path string, depth int) error {
+	if max := c.limits.MaxDepth; max > 0 && depth > max {
+		return pathError(path, limitError("max_depth", max, "nesting depth must be at most %d", max))
+	}
+	return nil
+}
+
+// item counts an array item or object member at path.
+func (c *limitChecker) item(path string) error {
+	c.items++
+	if max := c.limits.MaxTotalItems; max > 0 && c.items > max {
+		return pathError(path, limitError("max_total_items", max, "total number of items must be at most %d", max))
+	}
+	return nil
+}
+
+// str checks the length of a string or object key at path.
+func (c *limitChecker) str(path, s string) error {
+	if max := c.limits.MaxStringBytes; max > 0 && len(s) > max {
+		return pathError(path, limitError("max_string_bytes", max, "string must be at most %d bytes, got %d", max, len(s)))
+	}
+	return nil
+}
+
+// check enforces the limits on a decoded value, such as a map that is passed
+// to ValidateMap. Map keys are checked in any order first, and only if a limit
+// is exceeded again in sorted order, so that errors are deterministic without
+// sorting the keys of every valid map.
+func (c *limitChecker) check(value any) error {
+	items := c.items
+	if err := c.checkValue("", 0, value); err == nil {
+		return nil
+	}
+	c.items, c.sorted = items, true
+	return c.checkValue("", 0, value)
+}
+
+// checkValue enforces the limits on a decoded value at path.
+func (c *limitChecker) checkValue(path string, depth int, value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		if err := c.enter(path, depth+1); err != nil {
+			return err
+		}
+		if !c.sorted {
+			for key, elem := range v {
+				if err := c.checkMember(path, depth, key, elem); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if err := c.checkMember(path, depth, key, v[key]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if err := c.enter(path, depth+1); err != nil {
+			return err
+		}
+		for i, elem := range v {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			if err := c.item(elemPath); err != nil {
+				return err
+			}
+			if err := c.checkValue(elemPath, depth+1, elem); err != nil {
+				return err
+			}
+		}
+	case string:
+		return c.str(path, v)
+	}
+	return nil
+}
+
+// checkMember enforces the limits on the member key of an object at path.
+func (c *limitChecker) checkMember(path string, depth int, key string, elem any) error {
+	keyPath := joinPath(path, key)
+	if err := c.item(keyPath); err != nil {
+		return err
+	}
+	if err := c.str(keyPath, key); err != nil {
+		return err
+	}
+	return c.checkValue(keyPath, depth+1, elem)
+}
+
+// limitError returns the ConstraintError of an exceeded limit.
+func limitError(limit string, max int, format string, args ...any) error {
+	return constraintError(CodeLimit, map[string]any{"limit": limit, "max": max}, format, args...)
+}
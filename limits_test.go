@@ -0,0 +1,62 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import "testing"
+
+func TestValidateMapLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		value  map[string]any
+		want   string
+	}{
+		{
+			name:   "within limits",
+			limits: Limits{MaxTotalItems: 3, MaxStringBytes: 3},
+			value:  map[string]any{"a": "abc", "b": []any{"c"}},
+		},
+		{
+			name:   "first key in order",
+			limits: Limits{MaxStringBytes: 1},
+			value:  map[string]any{"d": "long", "b": "long", "c": "long", "a": "long"},
+			want:   `validation failed for field "a": string must be at most 1 bytes, got 4`,
+		},
+		{
+			name:   "total items",
+			limits: Limits{MaxTotalItems: 2},
+			value:  map[string]any{"b": 1, "a": 1, "c": 1},
+			want:   `validation failed for field "c": total number of items must be at most 2`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Validate repeatedly, as the order of map iteration varies.
+			for range 20 {
+				schema := Object[map[string]any]().AllErrors().MinProperties(10).Limits(tt.limits)
+				err := schema.ValidateMap(tt.value)
+				if tt.want == "" {
+					if err == nil || err.Error() != "number of properties must be at least 10" {
+						t.Fatalf("got error %v, want only the MinProperties error", err)
+					}
+					continue
+				}
+				if err == nil || err.Error() != tt.want {
+					t.Fatalf("got error %v, want %q", err, tt.want)
+				}
+			}
+		})
+	}
+}
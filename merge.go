@@ -0,0 +1,141 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ConflictPolicy determines how Merge handles a field that has a validator in
+// both schemas.
+type ConflictPolicy int
+
+const (
+	// ConflictError makes Merge fail.
+	ConflictError ConflictPolicy = iota
+	// ConflictOverride uses the field validator of the second schema.
+	ConflictOverride
+	// ConflictKeep uses the field validator of the first schema.
+	ConflictKeep
+	// ConflictCombine validates the field with the validators of both
+	// schemas, first with that of the first schema.
+	ConflictCombine
+)
+
+// Extend adds the field validators, field options (e.g. RequiredFields),
+// validators, transformations and constraints of the other schema to the
+// schema, and returns the schema for chaining, e.g. to compose a schema from
+// shared base schemas for audit fields or pagination. Like calling Field
+// again, a field validator of the other schema replaces that of a field with
+// the same name (see Merge for other policies).
+//
+// The schema uses AllErrors and collects errors if either of them does, with
+// the lower maximum number of errors, and the stricter of their safety limits
+// (see Limits). The title, description and message catalog of the other
+// schema are used if the schema has none.
+func (s *ObjectSchema[T]) Extend(other *ObjectSchema[T]) *ObjectSchema[T] {
+	// The override policy never fails.
+	_ = s.extend(other, ConflictOverride)
+	return s
+}
+
+// Merge returns a new schema that combines the field validators, field options
+// and validators of a and b (see Extend), without changing either of them.
+// Fields that have a validator in both schemas are handled according to the
+// policy.
+func Merge[T any](a, b *ObjectSchema[T], policy ConflictPolicy) (*ObjectSchema[T], error) {
+	merged := Object[T]()
+	merged.config = a.config
+	merged.Extend(a)
+	if err := merged.extend(b, policy); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func (s *ObjectSchema[T]) extend(other *ObjectSchema[T], policy ConflictPolicy) error {
+	if policy == ConflictError {
+		for _, field := range other.fields {
+			if s.fieldIndex(field.name) >= 0 {
+				return fmt.Errorf("field %q has a validator in both schemas", field.name)
+			}
+		}
+	}
+
+	for _, field := range other.fields {
+		i := s.fieldIndex(field.name)
+		switch {
+		case i < 0 || policy == ConflictOverride:
+			s.setField(field)
+		case policy == ConflictCombine:
+			first := s.fields[i]
+			s.setField(objectField[T]{
+				name: field.name,
+				validateFn: func(ctx context.Context, value T, present bool) error {
+					if err := first.validateFn(ctx, value, present); err != nil {
+						return err
+					}
+					return field.validateFn(ctx, value, present)
+				},
+			})
+		}
+	}
+
+	s.requiredFields = appendMissing(s.requiredFields, other.requiredFields)
+	s.readOnlyFields = appendMissing(s.readOnlyFields, other.readOnlyFields)
+	s.writeOnlyFields = appendMissing(s.writeOnlyFields, other.writeOnlyFields)
+	for _, field := range other.deprecated {
+		if !slices.ContainsFunc(s.deprecated, func(f deprecatedField) bool { return f.name == field.name }) {
+			s.deprecated = append(s.deprecated, field)
+		}
+	}
+	s.mapValidators = append(s.mapValidators, other.mapValidators...)
+	s.keyValidators = append(s.keyValidators, other.keyValidators...)
+	s.patternFields = append(s.patternFields, other.patternFields...)
+	s.pathFields = append(s.pathFields, other.pathFields...)
+	s.validators = append(s.validators, other.validators...)
+	s.transforms = append(s.transforms, other.transforms...)
+	s.allErrors = s.allErrors || other.allErrors
+
+	for _, c := range other.constraints {
+		if !slices.ContainsFunc(s.constraints, func(sc Constraint) bool {
+			return sc.Keyword == c.Keyword && reflect.DeepEqual(sc.Value, c.Value)
+		}) {
+			s.constraints = append(s.constraints, c)
+		}
+	}
+	s.limits = s.limits.merge(other.limits)
+	if s.title == "" {
+		s.title = other.title
+	}
+	if s.description == "" {
+		s.description = other.description
+	}
+	s.config = s.config.merge(other.config)
+	return nil
+}
+
+// appendMissing appends the field names that are not in fieldNames yet.
+func appendMissing(fieldNames, other []string) []string {
+	for _, fieldName := range other {
+		if !slices.Contains(fieldNames, fieldName) {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	return fieldNames
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeSchemaOptions(t *testing.T) {
+	messages := Messages{"x-id": "fehlt"}
+
+	a := Object[map[string]any](WithMaxErrors(5), WithMessages(messages)).
+		Limits(Limits{MaxDepth: 3})
+	a.Custom(func(value map[string]any) error {
+		if value["id"] == nil {
+			return &ConstraintError{Code: "x-id", Message: "id is missing"}
+		}
+		return nil
+	})
+	a.Annotate("Base", "")
+	a.describe("x-owner", "billing")
+
+	b := Object[map[string]any](WithMaxErrors(2)).
+		Limits(Limits{MaxDepth: 5, MaxTotalItems: 10})
+	b.Annotate("Order", "An order.")
+	b.describe("x-owner", "billing")
+	b.describe("x-tier", "gold")
+
+	merged, err := Merge(a, b, ConflictError)
+	if err != nil {
+		t.Fatalf("failed to merge schemas: %v", err)
+	}
+	// b has no catalog, so it gets that of a.
+	b.Extend(a)
+
+	for name, s := range map[string]*ObjectSchema[map[string]any]{"merged": merged, "extended": b} {
+		if !s.config.collect || s.config.maxErrors != 2 {
+			t.Errorf("%s: expected errors to be collected with a maximum of 2, got %v and %d", name, s.config.collect, s.config.maxErrors)
+		}
+		if want := (Limits{MaxDepth: 3, MaxTotalItems: 10}); s.limits != want {
+			t.Errorf("%s: expected limits %+v, got %+v", name, want, s.limits)
+		}
+		if err := s.Validate(map[string]any{"name": "x"}); err == nil || err.Error() != "fehlt" {
+			t.Errorf("%s: expected message from catalog, got %v", name, err)
+		}
+	}
+
+	if merged.title != "Base" || merged.description != "An order." {
+		t.Errorf("expected title %q and description %q, got %q and %q", "Base", "An order.", merged.title, merged.description)
+	}
+	want := []Constraint{{Keyword: "x-owner", Value: "billing"}, {Keyword: "x-tier", Value: "gold"}}
+	if got := merged.Constraints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected constraints %v, got %v", want, got)
+	}
+}
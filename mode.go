@@ -0,0 +1,64 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import "context"
+
+// Mode is the direction of the data that is validated, which determines how
+// read-only and write-only fields are handled (see
+// ObjectSchema.ReadOnlyFields and ObjectSchema.WriteOnlyFields).
+type Mode int
+
+const (
+	// ModeAny doesn't restrict read-only and write-only fields. This is the
+	// default.
+	ModeAny Mode = iota
+	// ModeRequest is for data sent by a client, in which read-only fields
+	// are not allowed.
+	ModeRequest
+	// ModeResponse is for data sent to a client, in which write-only fields
+	// are not allowed.
+	ModeResponse
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeAny:
+		return "any"
+	case ModeRequest:
+		return "request"
+	case ModeResponse:
+		return "response"
+	default:
+		return "unknown"
+	}
+}
+
+type modeKey struct{}
+
+// WithMode returns a copy of ctx with the mode to use when validating with a
+// ValidateContext method.
+func WithMode(ctx context.Context, mode Mode) context.Context {
+	return context.WithValue(ctx, modeKey{}, mode)
+}
+
+// ModeFromContext returns the mode set with WithMode, or ModeAny if none is
+// set.
+func ModeFromContext(ctx context.Context) Mode {
+	if mode, ok := ctx.Value(modeKey{}).(Mode); ok {
+		return mode
+	}
+	return ModeAny
+}
@@ -14,7 +14,9 @@
 
 package valtor
 
-import "fmt"
+import (
+	"context"
+)
 
 // NullSchema represents a validation schema for null values.
 type NullSchema struct {
@@ -22,16 +24,21 @@ type NullSchema struct {
 }
 
 // Null creates a new validation schema for null values.
-func Null() *NullSchema {
+func Null(opts ...Option) *NullSchema {
 	return &NullSchema{
-		Schema: New[any](),
+		Schema: New[any](opts...),
 	}
 }
 
 // Validate validates that the value is null.
 func (s *NullSchema) Validate(value any) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates that the value is null, with the given context.
+func (s *NullSchema) ValidateContext(ctx context.Context, value any) error {
 	if value != nil {
-		return fmt.Errorf("expected null value, got %T", value)
+		return constraintError(CodeType, map[string]any{"expected": "null"}, "expected null value, got %T", value)
 	}
-	return s.Schema.Validate(value)
+	return s.Schema.ValidateContext(ctx, value)
 }
@@ -14,41 +14,68 @@
 
 package valtor
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"math"
+)
 
 // NumberSchema represents a validation schema for numeric values.
 type NumberSchema[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64] struct {
 	*Schema[T]
 	required bool
+	min, max *T
 }
 
 // Number creates a new validation schema for numeric values.
-func Number[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64]() *NumberSchema[T] {
+func Number[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](opts ...Option) *NumberSchema[T] {
 	return &NumberSchema[T]{
-		Schema: New[T](),
+		Schema: New[T](opts...),
 	}
 }
 
-// Required will make a number value required to not be the empty value when validated.
+// Required will make a number value required to be present when validated.
+// As zero is a legitimate number, it only has effect when the schema knows
+// whether the value is missing: with ValidatePresence, or when wrapped with
+// Ptr, where a nil pointer is missing. Use NonZero to reject zero values.
 func (s *NumberSchema[T]) Required() *NumberSchema[T] {
+	s.describe("required", true)
 	s.required = true
 	return s
 }
 
 // Validate validates the number against the schema and returns an error if the number is not valid.
 func (s *NumberSchema[T]) Validate(value T) error {
-	var zero T
-	if value == zero && s.required {
-		return ErrValueRequired
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the number against the schema with the given
+// context and returns an error if the number is not valid.
+func (s *NumberSchema[T]) ValidateContext(ctx context.Context, value T) error {
+	return s.Schema.ValidateContext(ctx, value)
+}
+
+// ValidatePresence validates the number against the schema, where present
+// reports whether the value exists (e.g. a map key or non-nil pointer). A
+// missing value fails if the schema is required, and is otherwise valid.
+func (s *NumberSchema[T]) ValidatePresence(value T, present bool) error {
+	if !present {
+		if s.required {
+			return ErrValueRequired
+		}
+		return nil
 	}
-	return s.Schema.Validate(value)
+	return s.Validate(value)
 }
 
 // Min adds a minimum value validator to the schema and returns the schema for chaining.
 func (s *NumberSchema[T]) Min(min T) *NumberSchema[T] {
-	s.validators = append(s.validators, func(v T) error {
+	s.describe("minimum", min)
+	s.setMin(min)
+	s.addValidator(func(v T) error {
 		if v < min {
-			return fmt.Errorf("value must be at least %v", min)
+			return constraintError(CodeMin, map[string]any{"min": min, "actual": v},
+				"value must be at least %v, got %v", min, v)
 		}
 		return nil
 	})
@@ -57,9 +84,160 @@ func (s *NumberSchema[T]) Min(min T) *NumberSchema[T] {
 
 // Max adds a maximum value validator to the schema and returns the schema for chaining.
 func (s *NumberSchema[T]) Max(max T) *NumberSchema[T] {
-	s.validators = append(s.validators, func(v T) error {
+	s.describe("maximum", max)
+	s.setMax(max)
+	s.addValidator(func(v T) error {
 		if v > max {
-			return fmt.Errorf("value must be at most %v", max)
+			return constraintError(CodeMax, map[string]any{"max": max, "actual": v},
+				"value must be at most %v, got %v", max, v)
+		}
+		return nil
+	})
+	return s
+}
+
+// setMin records min as the lower bound if it is tighter than the current one.
+func (s *NumberSchema[T]) setMin(min T) {
+	if s.min == nil || min > *s.min {
+		s.min = &min
+	}
+}
+
+// setMax records max as the upper bound if it is tighter than the current one.
+func (s *NumberSchema[T]) setMax(max T) {
+	if s.max == nil || max < *s.max {
+		s.max = &max
+	}
+}
+
+// Check reports an error if no value can satisfy the bounds of the schema,
+// e.g. when composing Min(18) with Max(16) or with a Between(0, 12), so that
+// the mistake surfaces when the schema is built instead of as a validation
+// failure for every value.
+func (s *NumberSchema[T]) Check() error {
+	if s.min != nil && s.max != nil && *s.min > *s.max {
+		return fmt.Errorf("impossible bounds: minimum %v is greater than maximum %v", *s.min, *s.max)
+	}
+	return nil
+}
+
+// Between adds a validator that checks if the value is between min and max
+// (inclusive) and returns the schema for chaining. Unlike combining Min and
+// Max, a violation is reported as a single error mentioning both bounds.
+func (s *NumberSchema[T]) Between(min, max T) *NumberSchema[T] {
+	s.describe("minimum", min)
+	s.describe("maximum", max)
+	s.setMin(min)
+	s.setMax(max)
+	s.addValidator(func(v T) error {
+		if !(v >= min && v <= max) {
+			return constraintError(CodeBetween, map[string]any{"min": min, "max": max, "actual": v},
+				"value must be between %v and %v, got %v", min, max, v)
+		}
+		return nil
+	})
+	return s
+}
+
+// NotBetween adds a validator that checks if the value is less than min or
+// greater than max and returns the schema for chaining.
+func (s *NumberSchema[T]) NotBetween(min, max T) *NumberSchema[T] {
+	s.describe(CodeNotBetween, []T{min, max})
+	s.addValidator(func(v T) error {
+		if v >= min && v <= max {
+			return constraintError(CodeNotBetween, map[string]any{"min": min, "max": max, "actual": v},
+				"value must not be between %v and %v, got %v", min, max, v)
+		}
+		return nil
+	})
+	return s
+}
+
+// OneOf adds a validator that checks if the value is one of the allowed values
+// and returns the schema for chaining. Lookups take constant time, so it is
+// suitable for large sets of values. The error message lists the first 10
+// allowed values, and the ConstraintError has all of them as `allowed`
+// parameter.
+func (s *NumberSchema[T]) OneOf(values ...T) *NumberSchema[T] {
+	s.describe("enum", values)
+	s.addValidator(oneOf(values))
+	return s
+}
+
+// Positive adds a validator that checks if the value is greater than zero and
+// returns the schema for chaining.
+func (s *NumberSchema[T]) Positive() *NumberSchema[T] {
+	s.describe("exclusiveMinimum", T(0))
+	s.addValidator(func(v T) error {
+		if !(v > 0) {
+			return constraintError(CodePositive, map[string]any{"actual": v}, "value must be positive")
+		}
+		return nil
+	})
+	return s
+}
+
+// Negative adds a validator that checks if the value is less than zero and
+// returns the schema for chaining.
+func (s *NumberSchema[T]) Negative() *NumberSchema[T] {
+	s.describe("exclusiveMaximum", T(0))
+	s.addValidator(func(v T) error {
+		if !(v < 0) {
+			return constraintError(CodeNegative, map[string]any{"actual": v}, "value must be negative")
+		}
+		return nil
+	})
+	return s
+}
+
+// NonNegative adds a validator that checks if the value is zero or greater and
+// returns the schema for chaining.
+func (s *NumberSchema[T]) NonNegative() *NumberSchema[T] {
+	s.describe("minimum", T(0))
+	s.addValidator(func(v T) error {
+		if !(v >= 0) {
+			return constraintError(CodeNonNegative, map[string]any{"actual": v}, "value must not be negative")
+		}
+		return nil
+	})
+	return s
+}
+
+// NonZero adds a validator that checks if the value is not zero and returns
+// the schema for chaining. Unlike Required, it rejects zero values regardless
+// of whether presence is known.
+func (s *NumberSchema[T]) NonZero() *NumberSchema[T] {
+	s.describe(CodeNonZero, true)
+	s.addValidator(func(v T) error {
+		if v == 0 {
+			return constraintError(CodeNonZero, nil, "value must not be zero")
+		}
+		return nil
+	})
+	return s
+}
+
+// Finite adds a validator that checks if the value is neither NaN nor infinite
+// and returns the schema for chaining. Integer values are always finite.
+func (s *NumberSchema[T]) Finite() *NumberSchema[T] {
+	s.describe(CodeFinite, true)
+	s.addValidator(func(v T) error {
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			return constraintError(CodeFinite, map[string]any{"actual": v}, "value must be finite")
+		}
+		return nil
+	})
+	return s
+}
+
+// Int adds a validator that checks if the value is a whole number (e.g. `2.0`
+// but not `2.5`) and returns the schema for chaining. It is meant for float
+// schemas; integer values always pass. NaN and infinite values fail.
+func (s *NumberSchema[T]) Int() *NumberSchema[T] {
+	s.describe("multipleOf", T(1))
+	s.addValidator(func(v T) error {
+		if f := float64(v); math.IsInf(f, 0) || f != math.Trunc(f) {
+			return constraintError(CodeInteger, map[string]any{"actual": v}, "value must be a whole number")
 		}
 		return nil
 	})
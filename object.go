@@ -15,77 +15,615 @@
 package valtor
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
 )
 
 // ObjectSchema represents a validation schema for object values.
 type ObjectSchema[T any] struct {
 	*Schema[T]
-	fieldValidators map[string]func(any) error
+	fields          []objectField[T] // In order of declaration.
+	fieldValidators map[string]func(value any, present bool) error
+	requiredFields  []string
+	deprecated      []deprecatedField
+	readOnlyFields  []string
+	writeOnlyFields []string
+	mapValidators   []func(map[string]any) error
+	keyValidators   []func(context.Context, string) error
+	patternFields   []patternField
+	pathFields      []pathField
+	allErrors       bool
+	mayBeMap        bool // Whether values of type T can hold a map[string]any.
+}
+
+// objectField is a validator for a single field of an object.
+type objectField[T any] struct {
+	name       string
+	validateFn func(ctx context.Context, value T, present bool) error
+	describer  Describer // Describes the field's constraints, if known.
+}
+
+// pathField is a validator for a value in nested maps.
+type pathField struct {
+	path       string
+	segments   []string
+	validateFn func(any) error
+}
+
+// deprecatedField is a field that is reported as deprecated when present.
+type deprecatedField struct {
+	name    string
+	message string
+}
+
+// patternField is a validator that applies to all map keys matching a pattern.
+type patternField struct {
+	re         *regexp.Regexp
+	validateFn func(context.Context, any) error
 }
 
 // FieldValidatorMap is a type alias for a map of field names to validator functions.
 type FieldValidatorMap[T any] map[string]func(T) error
 
 // Object creates a new validation schema for object values.
-func Object[T any]() *ObjectSchema[T] {
-	return &ObjectSchema[T]{
-		Schema:          New[T](),
-		fieldValidators: make(map[string]func(any) error),
+func Object[T any](opts ...Option) *ObjectSchema[T] {
+	s := &ObjectSchema[T]{
+		Schema:          New[T](opts...),
+		fieldValidators: make(map[string]func(value any, present bool) error),
+		mayBeMap:        mayBeMap[T](),
+	}
+	s.allErrors = s.config.collect
+	return s
+}
+
+// mayBeMap reports whether values of type T can hold a map[string]any. This is
+// determined once, so that validating e.g. a struct does not box it to check.
+func mayBeMap[T any]() bool {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Interface, reflect.Map:
+		return true
+	default:
+		return false
 	}
 }
 
 // Field adds a field validator to the schema and returns the schema for chaining.
 func (s *ObjectSchema[T]) Field(fieldName string, validateFn func(T) error) *ObjectSchema[T] {
-	s.fieldValidators[fieldName] = func(value any) error {
+	return s.FieldPresence(fieldName, func(value T, _ bool) error {
+		return validateFn(value)
+	})
+}
+
+// FieldPresence adds a presence-aware field validator to the schema and returns
+// the schema for chaining. When validating a map, present reports whether the
+// field's key exists in the map, so a missing key can be told apart from a
+// zero value. When validating other values, present is always true.
+func (s *ObjectSchema[T]) FieldPresence(fieldName string, validateFn func(value T, present bool) error) *ObjectSchema[T] {
+	return s.FieldPresenceContext(fieldName, func(_ context.Context, value T, present bool) error {
+		return validateFn(value, present)
+	})
+}
+
+// FieldContext adds a field validator that receives the context passed to
+// ValidateContext, e.g. to validate a field with a rule created with
+// NewContextRule, and returns the schema for chaining.
+func (s *ObjectSchema[T]) FieldContext(fieldName string, validateFn func(context.Context, T) error) *ObjectSchema[T] {
+	return s.FieldPresenceContext(fieldName, func(ctx context.Context, value T, _ bool) error {
+		return validateFn(ctx, value)
+	})
+}
+
+// FieldPresenceContext adds a presence-aware field validator, like
+// FieldPresence, that receives the context passed to ValidateContext, like
+// FieldContext, and returns the schema for chaining.
+func (s *ObjectSchema[T]) FieldPresenceContext(fieldName string, validateFn func(ctx context.Context, value T, present bool) error) *ObjectSchema[T] {
+	field := objectField[T]{
+		name: fieldName,
+		validateFn: func(ctx context.Context, value T, present bool) error {
+			if err := validateFn(ctx, value, present); err != nil {
+				return &FieldError{Field: fieldName, Err: err}
+			}
+			return nil
+		},
+	}
+
+	s.setField(field)
+	return s
+}
+
+// fieldIndex returns the index of the field in s.fields, or -1 if the schema
+// has no validator for it.
+func (s *ObjectSchema[T]) fieldIndex(fieldName string) int {
+	return slices.IndexFunc(s.fields, func(f objectField[T]) bool { return f.name == fieldName })
+}
+
+// setField adds the field, or replaces the validator of the field with the
+// same name.
+func (s *ObjectSchema[T]) setField(field objectField[T]) {
+	// Fields are validated in the order in which they were first added, so
+	// replacing a field's validator keeps its position.
+	if i := s.fieldIndex(field.name); i >= 0 {
+		s.fields[i] = field
+	} else {
+		s.fields = append(s.fields, field)
+	}
+
+	s.fieldValidators[field.name] = func(value any, present bool) error {
 		// Test whether the value is of type T, else use its zero value (which
 		// could be nil, and should be handled by the validator).
 		typedValue, _ := value.(T)
+		return field.validateFn(context.Background(), typedValue, present)
+	}
+}
+
+// RequiredFields adds field names that must be present when validating a map
+// and returns the schema for chaining. Unlike the Required method of other
+// schemas, a present zero value (e.g. "" or 0) is accepted. It has no effect
+// when validating struct values.
+func (s *ObjectSchema[T]) RequiredFields(fieldNames ...string) *ObjectSchema[T] {
+	s.requiredFields = append(s.requiredFields, fieldNames...)
+	return s
+}
+
+// DeprecatedField marks a field as deprecated, with the message (e.g. `use X
+// instead`) as hint, and returns the schema for chaining. When validating a
+// map that has the field's key, a *FieldError that wraps ErrDeprecated is
+// reported as a warning, which doesn't fail validation (see ValidateResult).
+// It has no effect when validating struct values; use Schema.Deprecated on a
+// rule of the struct instead.
+func (s *ObjectSchema[T]) DeprecatedField(fieldName, message string) *ObjectSchema[T] {
+	s.deprecated = append(s.deprecated, deprecatedField{name: fieldName, message: message})
+	return s
+}
 
-		if err := validateFn(typedValue); err != nil {
-			return fmt.Errorf("validation failed for field %q: %w", fieldName, err)
+// ReadOnlyFields adds field names that must not be present when validating a
+// map in ModeRequest (see WithMode), e.g. because they are set by the server,
+// and returns the schema for chaining. A present field fails with a
+// *FieldError that wraps ErrReadOnly. It has no effect when validating struct
+// values.
+func (s *ObjectSchema[T]) ReadOnlyFields(fieldNames ...string) *ObjectSchema[T] {
+	s.readOnlyFields = append(s.readOnlyFields, fieldNames...)
+	return s
+}
+
+// WriteOnlyFields adds field names that must not be present when validating a
+// map in ModeResponse (see WithMode), e.g. passwords, and returns the schema
+// for chaining. A present field fails with a *FieldError that wraps
+// ErrWriteOnly. It has no effect when validating struct values.
+func (s *ObjectSchema[T]) WriteOnlyFields(fieldNames ...string) *ObjectSchema[T] {
+	s.writeOnlyFields = append(s.writeOnlyFields, fieldNames...)
+	return s
+}
+
+// StripFields deletes the fields from values that are not allowed in the mode
+// of ctx (see ReadOnlyFields and WriteOnlyFields), e.g. to drop read-only
+// fields from a request instead of rejecting it. It should be called before
+// validation.
+func (s *ObjectSchema[T]) StripFields(ctx context.Context, values map[string]any) {
+	fieldNames, _ := s.forbiddenFields(ModeFromContext(ctx))
+	for _, fieldName := range fieldNames {
+		delete(values, fieldName)
+	}
+}
+
+// forbiddenFields returns the fields that are not allowed in the mode, and the
+// error for them.
+func (s *ObjectSchema[T]) forbiddenFields(mode Mode) ([]string, error) {
+	switch mode {
+	case ModeRequest:
+		return s.readOnlyFields, ErrReadOnly
+	case ModeResponse:
+		return s.writeOnlyFields, ErrWriteOnly
+	default:
+		return nil, nil
+	}
+}
+
+// PropertyNames adds a validator for the keys of map values and returns the
+// schema for chaining. The schema receives the context passed to
+// ValidateContext. It has no effect when validating struct values.
+func (s *ObjectSchema[T]) PropertyNames(schema Validator[string]) *ObjectSchema[T] {
+	validate := contextValidate(schema)
+	s.keyValidators = append(s.keyValidators, func(ctx context.Context, key string) error {
+		if err := validate(ctx, key); err != nil {
+			return fmt.Errorf("invalid property name %q: %w", key, err)
 		}
 		return nil
+	})
+	return s
+}
+
+// PatternField adds a validator for all map values whose key matches the
+// regular expression and returns the schema for chaining. It has no effect
+// when validating struct values.
+func (s *ObjectSchema[T]) PatternField(re *regexp.Regexp, validateFn func(T) error) *ObjectSchema[T] {
+	return s.PatternFieldContext(re, func(_ context.Context, value T) error {
+		return validateFn(value)
+	})
+}
+
+// PatternFieldContext adds a validator for all map values whose key matches
+// the regular expression, like PatternField, that receives the context passed
+// to ValidateContext, e.g. to pass it on to a nested schema, and returns the
+// schema for chaining.
+func (s *ObjectSchema[T]) PatternFieldContext(re *regexp.Regexp, validateFn func(context.Context, T) error) *ObjectSchema[T] {
+	s.patternFields = append(s.patternFields, patternField{
+		re: re,
+		validateFn: func(ctx context.Context, value any) error {
+			typedValue, _ := value.(T)
+			return validateFn(ctx, typedValue)
+		},
+	})
+	return s
+}
+
+// WildcardField adds a validator for all map values whose key matches the
+// pattern, in which `*` matches any sequence of characters (e.g. `label.*`),
+// and returns the schema for chaining. It is a shorthand for PatternField, for
+// families of keys such as labels, annotations or headers. It has no effect
+// when validating struct values.
+func (s *ObjectSchema[T]) WildcardField(pattern string, validateFn func(T) error) *ObjectSchema[T] {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
 	}
+	// The pattern is quoted, so it always compiles.
+	re, _ := CompilePattern(`^` + strings.Join(parts, `.*`) + `$`)
+	return s.PatternField(re, validateFn)
+}
+
+// At adds a validator for a value in nested maps, at the dot separated path
+// (e.g. `spec.replicas`), and returns the schema for chaining. Like for Field,
+// a missing value is validated as the zero value of T, also if a map on the
+// path is missing or nil. A value on the path that is not a map[string]any
+// fails. Errors are returned as a *FieldError
+// with the full path as field. It has no effect when validating struct
+// values.
+func (s *ObjectSchema[T]) At(path string, validateFn func(T) error) *ObjectSchema[T] {
+	s.pathFields = append(s.pathFields, pathField{
+		path:     path,
+		segments: strings.Split(path, "."),
+		validateFn: func(value any) error {
+			typedValue, _ := value.(T)
+			return validateFn(typedValue)
+		},
+	})
+	return s
+}
+
+// validate validates the value at the path in values.
+func (pf pathField) validate(values map[string]any) error {
+	var value any = values
+	for i, segment := range pf.segments {
+		if value == nil {
+			// A null value on the path is handled like a missing one.
+			break
+		}
+		m, ok := value.(map[string]any)
+		if !ok {
+			return &FieldError{
+				Field: strings.Join(pf.segments[:i], "."),
+				Err:   constraintError(CodeType, map[string]any{"expected": "object"}, "value must be an object"),
+			}
+		}
+		if value, ok = m[segment]; !ok {
+			break
+		}
+	}
+	if err := pf.validateFn(value); err != nil {
+		return &FieldError{Field: pf.path, Err: err}
+	}
+	return nil
+}
+
+// MinProperties adds a validator that checks if a map value has at least the
+// specified number of properties and returns the schema for chaining. It has no
+// effect when validating struct values.
+func (s *ObjectSchema[T]) MinProperties(min int) *ObjectSchema[T] {
+	s.mapValidators = append(s.mapValidators, func(values map[string]any) error {
+		if len(values) < min {
+			return constraintError(CodeMinProperties, map[string]any{"min": min, "actual": len(values)},
+				"number of properties must be at least %d", min)
+		}
+		return nil
+	})
 	return s
 }
 
-// ValidateField is a helper function to create a field validator.
+// MaxProperties adds a validator that checks if a map value has at most the
+// specified number of properties and returns the schema for chaining. It has no
+// effect when validating struct values.
+func (s *ObjectSchema[T]) MaxProperties(max int) *ObjectSchema[T] {
+	s.mapValidators = append(s.mapValidators, func(values map[string]any) error {
+		if len(values) > max {
+			return constraintError(CodeMaxProperties, map[string]any{"max": max, "actual": len(values)},
+				"number of properties must be at most %d", max)
+		}
+		return nil
+	})
+	return s
+}
+
+// AllErrors makes the schema report all failing fields instead of only the
+// first one, and returns the schema for chaining. Multiple errors are combined
+// with errors.Join, so errors.Is and errors.As match any of them, and the
+// individual errors can be retrieved with an `Unwrap() []error` type assertion.
+func (s *ObjectSchema[T]) AllErrors() *ObjectSchema[T] {
+	s.allErrors = true
+	return s
+}
+
+// errorCollector collects validation errors of a schema.
+type errorCollector struct {
+	all     bool
+	budget  *errorBudget // Shared by all collectors of a validation, or nil.
+	owner   bool         // Whether the collector created the budget.
+	seen    int64        // Errors used of the budget after the last add.
+	stopped bool
+	errs    []error
+}
+
+// errorBudget is the maximum number of errors of a whole validation (see
+// WithMaxErrors), shared via the context by the collectors of nested schemas.
+type errorBudget struct {
+	max       int64
+	used      atomic.Int64
+	exhausted atomic.Bool
+}
+
+type errorBudgetKey struct{}
+
+// errBudgetExhausted is returned by the collector of a nested schema that has
+// no errors to report because the error budget was exhausted. It makes the
+// collectors of enclosing schemas stop, and is never returned to the caller.
+var errBudgetExhausted = errors.New("error budget exhausted")
+
+// newErrorCollector returns a collector that uses the error budget of ctx, if
+// any, or else a new budget of max errors, if max is not 0. The returned
+// context carries the budget, to be passed to nested validators.
+func newErrorCollector(ctx context.Context, all bool, max int) (context.Context, errorCollector) {
+	budget, _ := ctx.Value(errorBudgetKey{}).(*errorBudget)
+	owner := false
+	if budget == nil && max > 0 {
+		budget = &errorBudget{max: int64(max)}
+		ctx = context.WithValue(ctx, errorBudgetKey{}, budget)
+		owner = true
+	}
+	c := errorCollector{all: all, budget: budget, owner: owner}
+	if budget != nil {
+		c.seen = budget.used.Load()
+	}
+	return ctx, c
+}
+
+// add records err and reports whether validation should stop. Errors count
+// against the error budget, if any, unless they were counted by collectors of
+// nested schemas already. Once an error exceeds the budget, it is dropped and
+// all collectors of the validation stop; the collector that created the budget
+// records ErrTooManyErrors instead.
+func (c *errorCollector) add(err error) bool {
+	if err == nil {
+		return false
+	}
+	if b := c.budget; b != nil {
+		if errors.Is(err, errBudgetExhausted) {
+			c.stop()
+			return true
+		}
+		if b.exhausted.Load() {
+			// Errors of a nested schema that exhausted the budget.
+			c.errs = append(c.errs, err)
+			c.stop()
+			return true
+		}
+		if b.used.Load() == c.seen {
+			b.used.Add(1)
+		}
+		c.seen = b.used.Load()
+		if c.seen > b.max {
+			b.exhausted.Store(true)
+			c.stop()
+			return true
+		}
+	}
+	c.errs = append(c.errs, err)
+	c.stopped = !c.all
+	return c.stopped
+}
+
+// stop stops the collector after the error budget was exhausted.
+func (c *errorCollector) stop() {
+	if c.stopped && c.budget.exhausted.Load() {
+		return
+	}
+	c.stopped = true
+	if c.owner {
+		c.errs = append(c.errs, &ConstraintError{
+			Code:    CodeLimit,
+			Params:  map[string]any{"max_errors": int(c.budget.max)},
+			Message: fmt.Sprintf("too many errors, stopped after %d", c.budget.max),
+			Err:     ErrTooManyErrors,
+		})
+	}
+}
+
+// err returns the collected errors, joined if there is more than one.
+func (c *errorCollector) err() error {
+	switch len(c.errs) {
+	case 0:
+		if c.stopped && c.budget != nil && c.budget.exhausted.Load() {
+			return errBudgetExhausted
+		}
+		return nil
+	case 1:
+		return c.errs[0]
+	}
+	return errors.Join(c.errs...)
+}
+
+// ValidateField is a helper function to create a field validator. The
+// context passed to ValidateContext isn't passed on to the field's schema, so
+// e.g. the mode doesn't apply to nested objects and ValidateResult doesn't
+// report their warnings. To pass it on, use FieldOf.
 func ValidateField[T any, F any](getter func(T) F, schema Validator[F]) func(T) error {
 	return func(value T) error {
 		return schema.Validate(getter(value))
 	}
 }
 
-// Map adds multiple field validators to the schema at once using a map.
+// Map adds multiple field validators to the schema at once using a map. As a
+// map is unordered, the fields are added in order of field name.
 func (s *ObjectSchema[T]) Map(fieldValidators FieldValidatorMap[T]) *ObjectSchema[T] {
-	for fieldName, validateFn := range fieldValidators {
-		s.Field(fieldName, validateFn)
+	for _, fieldName := range slices.Sorted(maps.Keys(fieldValidators)) {
+		s.Field(fieldName, fieldValidators[fieldName])
 	}
 	return s
 }
 
 // Validate validates a value against the schema.
 func (s *ObjectSchema[T]) Validate(value T) error {
-	mapValue, ok := any(value).(map[string]any)
-	if ok {
-		return s.ValidateMap(mapValue)
-	}
-	for _, validator := range s.fieldValidators {
-		if err := validator(value); err != nil {
-			return err
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates a value against the schema with the given context.
+// Field validators run first, in the order in which they were added, followed
+// by any validators added to the schema itself (e.g. with Custom or Rule).
+func (s *ObjectSchema[T]) ValidateContext(ctx context.Context, value T) error {
+	ctx, c := newErrorCollector(ctx, s.allErrors, s.config.maxErrors)
+	if mapValue, ok := s.asMap(value); ok {
+		s.validateMap(ctx, &c, mapValue)
+	} else {
+		for _, field := range s.fields {
+			if c.add(field.validateFn(ctx, value, true)) {
+				break
+			}
 		}
 	}
-	return nil
+	if c.stopped {
+		return c.err()
+	}
+	c.add(s.Schema.ValidateContext(ctx, value))
+	return c.err()
+}
+
+// asMap returns the value as a map[string]any, if it is one.
+func (s *ObjectSchema[T]) asMap(value T) (map[string]any, bool) {
+	if !s.mayBeMap {
+		return nil, false
+	}
+	mapValue, ok := any(value).(map[string]any)
+	return mapValue, ok
 }
 
-// ValidateMap validates a map (keyed by field name) of values against the schema.
+// ValidateField validates a single field, e.g. to
+// validate a form field on blur without validating the whole object. The value
+// is passed to the field validator as is: for map schemas (e.g. Object[any])
+// it is the field's value, for struct schemas it is the struct, as its field
+// validators receive the whole struct. Unlike the other validation methods,
+// required fields and validators added to the schema itself are not checked.
+func (s *ObjectSchema[T]) ValidateField(fieldName string, value any) error {
+	validateFn, ok := s.fieldValidators[fieldName]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownField, fieldName)
+	}
+	return validateFn(value, true)
+}
+
+// ValidateMap validates a map (keyed by field name) of values against the
+// schema, using DefaultProfile.
 func (s *ObjectSchema[T]) ValidateMap(values map[string]any) error {
-	for fieldName, validateFn := range s.fieldValidators {
-		value := values[fieldName]
-		if err := validateFn(value); err != nil {
-			return err
+	ctx, c := newErrorCollector(context.Background(), s.allErrors, s.config.maxErrors)
+	s.validateMap(ctx, &c, values)
+	return c.err()
+}
+
+func (s *ObjectSchema[T]) validateMap(ctx context.Context, c *errorCollector, values map[string]any) {
+	if s.limits != (Limits{}) {
+		limits := limitChecker{limits: s.limits}
+		if err := limits.check(values); err != nil {
+			c.add(err)
+			return
+		}
+	}
+	for _, validateFn := range s.mapValidators {
+		if c.add(validateFn(values)) {
+			return
+		}
+	}
+	forbiddenFields, forbiddenErr := s.forbiddenFields(ModeFromContext(ctx))
+	for _, fieldName := range s.requiredFields {
+		// Like in OpenAPI, e.g. a required read-only field is only required
+		// in responses.
+		if slices.Contains(forbiddenFields, fieldName) {
+			continue
+		}
+		if _, ok := values[fieldName]; !ok {
+			if c.add(&FieldError{Field: fieldName, Err: ErrValueRequired}) {
+				return
+			}
+		}
+	}
+	for _, fieldName := range forbiddenFields {
+		if _, ok := values[fieldName]; ok {
+			if c.add(&FieldError{Field: fieldName, Err: forbiddenErr}) {
+				return
+			}
+		}
+	}
+	for _, field := range s.deprecated {
+		if _, ok := values[field.name]; ok {
+			report(ctx, SeverityWarning, &FieldError{
+				Field: field.name,
+				Err:   &RuleError{Err: ErrDeprecated, Hint: field.message, Severity: SeverityWarning},
+			})
+		}
+	}
+	for _, field := range s.fields {
+		value, present := values[field.name]
+		typedValue, _ := value.(T)
+		if c.add(field.validateFn(ctx, typedValue, present)) {
+			return
+		}
+	}
+	for _, pf := range s.pathFields {
+		if c.add(pf.validate(values)) {
+			return
+		}
+	}
+	profile := ProfileFromContext(ctx)
+	if len(s.keyValidators) == 0 && len(s.patternFields) == 0 && !profile.StrictKeys && profile.Warn == nil {
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		for _, validateFn := range s.keyValidators {
+			if c.add(validateFn(ctx, key)) {
+				return
+			}
+		}
+		_, known := s.fieldValidators[key]
+		known = known || slices.ContainsFunc(s.pathFields, func(pf pathField) bool { return pf.segments[0] == key })
+		for _, pf := range s.patternFields {
+			if !pf.re.MatchString(key) {
+				continue
+			}
+			known = true
+			if err := pf.validateFn(ctx, values[key]); err != nil {
+				if c.add(&FieldError{Field: key, Err: err}) {
+					return
+				}
+			}
+		}
+		if !known {
+			if c.add(enforce(ctx, profile.StrictKeys, &FieldError{Field: key, Err: ErrUnknownField})) {
+				return
+			}
 		}
 	}
-	return nil
 }
@@ -0,0 +1,170 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// config is the configuration of a schema, set with options when it is
+// created.
+type config struct {
+	collect    bool
+	maxErrors  int
+	messages   MessageCatalog
+	locale     string
+	lengthMode LengthMode
+}
+
+// merge returns the configuration of a schema that is extended with a schema
+// with the other configuration (see ObjectSchema.Extend).
+func (cfg config) merge(other config) config {
+	cfg.collect = cfg.collect || other.collect
+	cfg.maxErrors = minLimit(cfg.maxErrors, other.maxErrors)
+	if cfg.messages == nil && cfg.locale == "" {
+		cfg.messages, cfg.locale = other.messages, other.locale
+	}
+	if cfg.lengthMode == LengthBytes {
+		cfg.lengthMode = other.lengthMode
+	}
+	return cfg
+}
+
+// Option configures a schema when it is created, e.g.
+// `valtor.String(valtor.WithErrorCollector())`. Options carry configuration
+// that cuts across schema types, so that it can be applied uniformly. Options
+// that don't apply to a type of schema are ignored by it.
+type Option func(*config)
+
+// WithErrorCollector makes a schema run all of its validators and return all
+// errors, joined, instead of stopping at the first error. For object and array
+// schemas, this includes the errors of all fields and items (see
+// ObjectSchema.AllErrors and ArraySchema.AllErrors).
+func WithErrorCollector() Option {
+	return func(cfg *config) {
+		cfg.collect = true
+	}
+}
+
+// WithMaxErrors makes a schema collect errors like WithErrorCollector, but
+// stop validation once it found more than n errors, so that a huge invalid
+// payload doesn't waste time on thousands of errors. The error that exceeds
+// the maximum is replaced with a ConstraintError with code CodeLimit that
+// wraps ErrTooManyErrors. The maximum applies to the whole validation: the
+// errors of nested schemas, e.g. of the fields of array items, count against
+// the maximum of the outermost schema that sets one. A maximum of 0 means no
+// maximum.
+func WithMaxErrors(n int) Option {
+	return func(cfg *config) {
+		cfg.collect = true
+		cfg.maxErrors = n
+	}
+}
+
+// WithMessages sets the catalog that provides the messages of the constraint
+// errors of a schema, e.g. to translate them. Messages that are not in the
+// catalog are left as is.
+func WithMessages(catalog MessageCatalog) Option {
+	return func(cfg *config) {
+		cfg.messages = catalog
+	}
+}
+
+// WithLengthMode sets how string schemas measure length (see
+// StringSchema.LengthMode).
+func WithLengthMode(mode LengthMode) Option {
+	return func(cfg *config) {
+		cfg.lengthMode = mode
+	}
+}
+
+// newConfig returns the default configuration (see SetDefaults) with the
+// options applied.
+func newConfig(opts []Option) config {
+	d := Defaults()
+	cfg := config{
+		collect:    !d.FailFast,
+		maxErrors:  d.MaxErrors,
+		locale:     d.Locale,
+		lengthMode: d.LengthMode,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// MessageCatalog provides the messages of constraint errors by constraint
+// code and parameters (see WithMessages).
+type MessageCatalog interface {
+	Message(code string, params map[string]any) (string, bool)
+}
+
+// Messages is a MessageCatalog of message templates by constraint code, in
+// which `{name}` is replaced with the value of parameter `name`, e.g.
+// `{"min_length": "muss mindestens {min} Zeichen lang sein"}`.
+type Messages map[string]string
+
+// Message returns the message for the constraint code, with the parameters
+// filled in.
+func (m Messages) Message(code string, params map[string]any) (string, bool) {
+	template, ok := m[code]
+	if !ok {
+		return "", false
+	}
+	replacements := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(template), true
+}
+
+// message replaces the message of a ConstraintError with the message from the
+// schema's catalog (see WithMessages and WithLocale), if any. This includes
+// the ConstraintError of a named rule that is wrapped in a RuleError.
+func (s *Schema[T]) message(err error) error {
+	switch err.(type) {
+	case *ConstraintError, *RuleError:
+	default:
+		return err
+	}
+	catalog := s.config.catalog()
+	if catalog == nil {
+		return err
+	}
+	return localize(catalog, err)
+}
+
+// localize replaces the message of a ConstraintError, or of the
+// ConstraintError wrapped by a RuleError, with the message from catalog.
+func localize(catalog MessageCatalog, err error) error {
+	switch e := err.(type) {
+	case *ConstraintError:
+		message, ok := catalog.Message(e.Code, e.Params)
+		if !ok {
+			return err
+		}
+		localized := *e
+		localized.Message = message
+		return &localized
+	case *RuleError:
+		localized := *e
+		localized.Err = localize(catalog, e.Err)
+		return &localized
+	default:
+		return err
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// patternCacheSize is the maximum number of compiled patterns that are cached.
+const patternCacheSize = 256
+
+// patterns caches compiled regular expressions by pattern string.
+var patterns = newPatternCache(patternCacheSize)
+
+// patternCache is a least recently used cache of compiled regular expressions.
+// A compiled regexp.Regexp is safe for concurrent use, so cached values are
+// shared by all schemas that use the same pattern.
+type patternCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Most recently used first; values are *patternEntry.
+	entries map[string]*list.Element
+}
+
+type patternEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newPatternCache(size int) *patternCache {
+	return &patternCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// compile returns the compiled pattern, from the cache if possible. Patterns
+// that fail to compile are not cached.
+func (c *patternCache) compile(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if elem, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(elem)
+		c.mu.Unlock()
+		return elem.Value.(*patternEntry).re, nil
+	}
+	c.mu.Unlock()
+
+	// Compile without holding the lock, so that a slow compilation doesn't
+	// block lookups of other patterns.
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[pattern]; ok {
+		// Compiled concurrently by another caller.
+		c.order.MoveToFront(elem)
+		return elem.Value.(*patternEntry).re, nil
+	}
+	c.entries[pattern] = c.order.PushFront(&patternEntry{pattern: pattern, re: re})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*patternEntry).pattern)
+	}
+	return re, nil
+}
+
+// CompilePattern compiles a regular expression, like regexp.Compile, but
+// returns a cached result if the same pattern was compiled before. The cache
+// is shared by the whole package (see StringSchema.Pattern) and holds the most
+// recently used patterns.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	return patterns.compile(pattern)
+}
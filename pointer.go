@@ -14,6 +14,8 @@
 
 package valtor
 
+import "context"
+
 // PointerSchema represents a validation schema for pointer values.
 type PointerSchema[T any] struct {
 	*Schema[*T]
@@ -21,9 +23,9 @@ type PointerSchema[T any] struct {
 }
 
 // Pointer creates a new validation schema for pointer values.
-func Pointer[T any]() *PointerSchema[T] {
+func Pointer[T any](opts ...Option) *PointerSchema[T] {
 	return &PointerSchema[T]{
-		Schema: New[*T](),
+		Schema: New[*T](opts...),
 	}
 }
 
@@ -47,21 +49,51 @@ func (s *PointerSchema[T]) Custom(fn func(*T) error) *PointerSchema[T] {
 
 // Validate validates the pointer against the schema and returns an error if the pointer is not valid.
 func (s *PointerSchema[T]) Validate(value *T) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the pointer against the schema with the given
+// context and returns an error if the pointer is not valid.
+func (s *PointerSchema[T]) ValidateContext(ctx context.Context, value *T) error {
 	if value == nil && s.required {
 		return ErrValueRequired
 	}
-	return s.Schema.Validate(value)
+	return s.Schema.ValidateContext(ctx, value)
 }
 
-// Ptr wraps another validator schema to validate the pointed-to value.
+// Ptr wraps another validator schema to validate the pointed-to value. If the
+// schema implements PresenceValidator, a nil pointer is validated as a missing
+// value, so e.g. a required number schema rejects nil but accepts a pointer to
+// zero. Run applies the transformations of the schema (see Schema.Transform)
+// to a copy of the pointed-to value.
 func Ptr[T any](schema Validator[T]) *PointerSchema[T] {
 	p := Pointer[T]()
-	p.Custom(func(value *T) error {
+	if t, ok := schema.(transformer[T]); ok {
+		p.TransformContext(func(ctx context.Context, value *T) *T {
+			if value == nil || !t.hasTransforms() {
+				return value
+			}
+			transformed := t.transform(ctx, *value)
+			return &transformed
+		})
+	}
+	if presenceSchema, ok := schema.(PresenceValidator[T]); ok {
+		p.Custom(func(value *T) error {
+			if value == nil {
+				var zero T
+				return presenceSchema.ValidatePresence(zero, false)
+			}
+			return presenceSchema.ValidatePresence(*value, true)
+		})
+		return p
+	}
+	validate := contextValidate(schema)
+	p.validators = append(p.validators, func(ctx context.Context, value *T) error {
 		if value == nil {
 			// Skip validation for nil pointers, handled by Required() if needed.
 			return nil
 		}
-		return schema.Validate(*value)
+		return validate(ctx, *value)
 	})
 	return p
 }
@@ -0,0 +1,177 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// rangeKeywords are the keywords of lower and upper bounds that Sprint
+// combines into a range, e.g. `2..50 chars`, and the unit of the bounds.
+var rangeKeywords = []struct{ min, max, unit string }{
+	{"minLength", "maxLength", " chars"},
+	{"minItems", "maxItems", " items"},
+	{"minimum", "maximum", ""},
+}
+
+// Sprint renders a description of the schema as an indented tree, with a line
+// per field and array item, e.g. `name: string, required, 2..50 chars`, for
+// debugging or for documentation such as CLI help. See Describe for what is
+// described.
+func Sprint(schema Describer) string {
+	d := schema.Describe()
+	var sb strings.Builder
+	sb.WriteString(d.String())
+	sb.WriteByte('\n')
+	sprintNested(&sb, d, "  ")
+	return sb.String()
+}
+
+func sprintNested(sb *strings.Builder, d Description, indent string) {
+	for _, field := range d.Fields {
+		fmt.Fprintf(sb, "%s%s: %s\n", indent, field.Name, field.Description)
+		sprintNested(sb, field.Description, indent+"  ")
+	}
+	if d.Items != nil {
+		fmt.Fprintf(sb, "%s[]: %s\n", indent, d.Items)
+		sprintNested(sb, *d.Items, indent+"  ")
+	}
+	for _, variant := range d.Variants {
+		fmt.Fprintf(sb, "%s|: %s\n", indent, variant)
+		sprintNested(sb, variant, indent+"  ")
+	}
+}
+
+// String returns the type and constraints of the description on a single
+// line, e.g. `string, required, 2..50 chars`, without nested descriptions.
+// The type of a description with variants is `any of`, followed by their
+// types.
+func (d Description) String() string {
+	typ := d.Type
+	if len(d.Variants) > 0 {
+		types := make([]string, len(d.Variants))
+		for i, variant := range d.Variants {
+			types[i] = variant.Type
+			if types[i] == "" {
+				types[i] = "any"
+			}
+		}
+		typ = "any of " + strings.Join(types, " | ")
+	}
+	if typ == "" {
+		typ = "any"
+	}
+	parts := []string{typ}
+
+	values := make(map[string]any, len(d.Constraints))
+	for _, c := range d.Constraints {
+		values[c.Keyword] = c.Value
+	}
+	done := make(map[string]bool)
+	for _, c := range d.Constraints {
+		if done[c.Keyword] {
+			continue
+		}
+		done[c.Keyword] = true
+		if part, ok := rangePhrase(c.Keyword, values, done); ok {
+			parts = append(parts, part)
+			continue
+		}
+		parts = append(parts, constraintPhrase(c))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// rangePhrase renders the bounds of the range that keyword is part of, and
+// marks them as done.
+func rangePhrase(keyword string, values map[string]any, done map[string]bool) (string, bool) {
+	for _, r := range rangeKeywords {
+		if keyword != r.min && keyword != r.max {
+			continue
+		}
+		min, hasMin := values[r.min]
+		max, hasMax := values[r.max]
+		done[r.min], done[r.max] = true, true
+		switch {
+		case hasMin && hasMax && fmt.Sprint(min) == fmt.Sprint(max):
+			return fmt.Sprintf("%v%s", min, r.unit), true
+		case hasMin && hasMax:
+			return fmt.Sprintf("%v..%v%s", min, max, r.unit), true
+		case hasMin:
+			return fmt.Sprintf(">= %v%s", min, r.unit), true
+		default:
+			return fmt.Sprintf("<= %v%s", max, r.unit), true
+		}
+	}
+	return "", false
+}
+
+func constraintPhrase(c Constraint) string {
+	switch c.Keyword {
+	case "required":
+		return "required"
+	case "exclusiveMinimum":
+		return fmt.Sprintf("> %v", c.Value)
+	case "exclusiveMaximum":
+		return fmt.Sprintf("< %v", c.Value)
+	case "multipleOf":
+		return fmt.Sprintf("multiple of %v", c.Value)
+	case "const":
+		return fmt.Sprintf("= %v", c.Value)
+	case "enum":
+		v := reflect.ValueOf(c.Value)
+		values := make([]string, v.Len())
+		for i := range v.Len() {
+			values[i] = fmt.Sprintf("%#v", v.Index(i).Interface())
+		}
+		return "one of " + strings.Join(values, ", ")
+	case "pattern":
+		return fmt.Sprintf("pattern %s", c.Value)
+	case "format":
+		return fmt.Sprintf("format %s", c.Value)
+	case "uniqueItems":
+		return "unique"
+	case CodeNotBetween:
+		bounds := strings.Fields(strings.Trim(fmt.Sprint(c.Value), "[]"))
+		return fmt.Sprintf("not %s..%s", bounds[0], bounds[1])
+	case CodeNonZero:
+		return "non-zero"
+	case CodeContains, CodeNotContains, CodePrefix, CodeSuffix:
+		return fmt.Sprintf("%s %q", strings.ReplaceAll(c.Keyword, "_", " "), c.Value)
+	case CodeControlChars:
+		return "no control chars"
+	case CodeHTML:
+		return "no HTML"
+	case CodeSQLMeta:
+		return "no SQL metacharacters"
+	case CodeFilename:
+		return "safe file name"
+	case CodeOneOf:
+		return "in list"
+	case CodeDenied:
+		return "not in denylist"
+	case CodeDeniedWord:
+		return "no denied words"
+	case CodeExpression:
+		return fmt.Sprintf("%v", c.Value)
+	default:
+		if c.Value == true {
+			return strings.ReplaceAll(c.Keyword, "_", " ")
+		}
+		return fmt.Sprintf("%s=%v", c.Keyword, c.Value)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import "context"
+
+// Profile is a named set of strictness options, selected at validation time
+// with WithProfile, so that the same schemas can be lenient in development
+// and strict in production.
+type Profile struct {
+	Name string
+	// StrictKeys makes object schemas reject map keys that have no field or
+	// pattern field validator.
+	StrictKeys bool
+	// AssertFormats makes StringSchema.Format validators fail for values that
+	// do not match the format.
+	AssertFormats bool
+	// Coerce is the mode in which schemas that coerce values, such as the
+	// integer schemas of valtorjsonschema, handle values that don't fit their
+	// type (see CoerceMode). Schemas configured with a more lenient mode keep
+	// theirs.
+	Coerce CoerceMode
+	// Warn, if not nil, is called with the errors of checks that are not
+	// enforced by the profile, instead of them being ignored, and with the
+	// errors of rules with a severity other than SeverityError.
+	Warn func(ctx context.Context, err error)
+}
+
+var (
+	// DefaultProfile is used when no profile is set in the context. It asserts
+	// formats and allows unknown keys.
+	DefaultProfile = Profile{Name: "default", AssertFormats: true}
+	// DevProfile enforces neither unknown keys nor formats, and truncates
+	// fractional values for integer types. Set Warn on a copy to be notified
+	// of violations.
+	DevProfile = Profile{Name: "dev", Coerce: CoerceTruncate}
+	// ProdProfile rejects unknown keys and asserts formats, and coerces values
+	// only as far as schemas are configured to.
+	ProdProfile = Profile{Name: "prod", StrictKeys: true, AssertFormats: true}
+)
+
+type profileKey struct{}
+
+// WithProfile returns a copy of ctx with the profile to use when validating
+// with a ValidateContext method.
+func WithProfile(ctx context.Context, profile Profile) context.Context {
+	return context.WithValue(ctx, profileKey{}, profile)
+}
+
+// ProfileFromContext returns the profile set with WithProfile, or
+// DefaultProfile if none is set.
+func ProfileFromContext(ctx context.Context) Profile {
+	if profile, ok := ctx.Value(profileKey{}).(Profile); ok {
+		return profile
+	}
+	return DefaultProfile
+}
+
+// enforce returns err if enforced is true. Otherwise, err is reported as a
+// warning (see ValidateResult and the Warn function of the profile in ctx) and
+// nil is returned.
+func enforce(ctx context.Context, enforced bool, err error) error {
+	if err == nil || enforced {
+		return err
+	}
+	report(ctx, SeverityWarning, err)
+	return nil
+}
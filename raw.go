@@ -0,0 +1,71 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RawMessageSchema represents a validation schema for raw JSON documents, e.g.
+// the payload of an envelope, that are validated by a schema chosen at runtime
+// by a discriminator, e.g. a sibling `type` field.
+type RawMessageSchema struct {
+	cases map[string]func(json.RawMessage) error
+}
+
+// RawMessage creates a new validation schema for raw JSON documents.
+func RawMessage() *RawMessageSchema {
+	return &RawMessageSchema{
+		cases: make(map[string]func(json.RawMessage) error),
+	}
+}
+
+// RawCase adds a case to the schema: raw documents with the discriminator are
+// decoded into a value of type T, which is validated with the given schema.
+// It returns the schema for chaining.
+func RawCase[T any](s *RawMessageSchema, discriminator string, schema Validator[T]) *RawMessageSchema {
+	s.cases[discriminator] = func(raw json.RawMessage) error {
+		var value T
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("failed to decode raw message: %w", err)
+		}
+		return schema.Validate(value)
+	}
+	return s
+}
+
+// Validate validates a raw document against the case of the discriminator. An
+// empty document fails with ErrValueRequired.
+func (s *RawMessageSchema) Validate(discriminator string, raw json.RawMessage) error {
+	validateFn, ok := s.cases[discriminator]
+	if !ok {
+		return constraintError(CodeDiscriminator, map[string]any{"discriminator": discriminator},
+			"unknown discriminator %q", discriminator)
+	}
+	if len(raw) == 0 {
+		return ErrValueRequired
+	}
+	return validateFn(raw)
+}
+
+// ValidateRawField is a helper function to create a field validator for a raw
+// JSON document field, which is validated against the case of the
+// discriminator returned by the discriminator getter.
+func ValidateRawField[T any](schema *RawMessageSchema, discriminator func(T) string, raw func(T) json.RawMessage) func(T) error {
+	return func(value T) error {
+		return schema.Validate(discriminator(value), raw(value))
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// registryEntry is a schema registered for a type or name. The schema is kept
+// as is (a Validator[T]) for lookups by type, and wrapped in validateFn for
+// validation of untyped values.
+type registryEntry struct {
+	schema     any
+	validateFn func(context.Context, any) error
+}
+
+// DefaultRegistry is the registry used by Register, RegisterName and For.
+var DefaultRegistry = newRegistry(DefaultExtensions)
+
+// Registry maps Go types and names to schemas, so that schemas can be
+// registered once at startup and looked up elsewhere, e.g. by middleware. Its
+// named rules are kept in an extension registry (see Extensions). It is safe
+// for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	byType     map[reflect.Type]registryEntry
+	byName     map[string]registryEntry
+	extensions *ExtensionRegistry
+}
+
+// NewRegistry creates a new, empty schema registry, with a new extension
+// registry for its named rules.
+func NewRegistry() *Registry {
+	return newRegistry(NewExtensionRegistry())
+}
+
+// newRegistry creates a new, empty schema registry with the extension registry
+// for its named rules. The DefaultRegistry uses DefaultExtensions.
+func newRegistry(extensions *ExtensionRegistry) *Registry {
+	return &Registry{
+		byType:     make(map[reflect.Type]registryEntry),
+		byName:     make(map[string]registryEntry),
+		extensions: extensions,
+	}
+}
+
+// Extensions returns the extension registry that holds the named rules of the
+// registry (see AddRule), e.g. to use them as JSON Schema extension keywords.
+// For the DefaultRegistry, it is DefaultExtensions.
+func (r *Registry) Extensions() *ExtensionRegistry {
+	return r.extensions
+}
+
+func newRegistryEntry[T any](schema Validator[T]) registryEntry {
+	return registryEntry{
+		schema: schema,
+		validateFn: func(ctx context.Context, value any) error {
+			v, ok := value.(T)
+			if !ok {
+				return fmt.Errorf("expected value of type %v, got %T", reflect.TypeFor[T](), value)
+			}
+			return validateContext(ctx, schema, v)
+		},
+	}
+}
+
+// RegisterType registers a schema for type T in the registry. Registering a
+// schema for a type that already has one replaces it.
+func RegisterType[T any](r *Registry, schema Validator[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byType[reflect.TypeFor[T]()] = newRegistryEntry(schema)
+}
+
+// RegisterNamed registers a schema for values of type T by name in the
+// registry, e.g. `user.create`. Registering a schema for a name that already
+// has one replaces it.
+func RegisterNamed[T any](r *Registry, name string, schema Validator[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byName[name] = newRegistryEntry(schema)
+}
+
+// Lookup returns the schema registered for type T in the registry.
+func Lookup[T any](r *Registry) (Validator[T], bool) {
+	r.mu.RLock()
+	e, ok := r.byType[reflect.TypeFor[T]()]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return e.schema.(Validator[T]), true
+}
+
+// AddRule adds a named rule (see DefineRule) to the registry, so that it can be
+// looked up by name and reused across schemas, e.g. to maintain a catalog of
+// rules with consistent codes and messages. It fails if the rule has no name,
+// e.g. because it was created with NewRule, or if the name is already taken by
+// a rule or another extension.
+//
+// The rule is added to the extension registry of the registry (see
+// Extensions), so that it can also be used by name where extensions are, e.g.
+// as JSON Schema extension keyword with the value `true`. Values of another
+// type than T, such as decoded JSON values, are converted to T through their
+// JSON encoding.
+func AddRule[T any](r *Registry, rule *Rule[T]) error {
+	if rule.name == "" {
+		return errors.New("invalid rule: name is required, see DefineRule")
+	}
+	return r.extensions.add(rule.extension())
+}
+
+// LookupRule returns a copy of the rule for values of type T that was added to
+// the registry by name. The copy can be changed, e.g. with EnabledIf, without
+// affecting other schemas. It reports false if there is no rule with the name,
+// or if the rule is for values of another type.
+func LookupRule[T any](r *Registry, name string) (*Rule[T], bool) {
+	ext, ok := r.extensions.Lookup(name)
+	if !ok {
+		return nil, false
+	}
+	rule, ok := ext.rule.(*Rule[T])
+	if !ok {
+		return nil, false
+	}
+	return rule.clone(), true
+}
+
+// RuleNames returns the names of the rules in the registry, in sorted order.
+func (r *Registry) RuleNames() []string {
+	return r.extensions.ruleNames()
+}
+
+// Register registers a schema for type T in the DefaultRegistry.
+func Register[T any](schema Validator[T]) {
+	RegisterType(DefaultRegistry, schema)
+}
+
+// RegisterName registers a schema for values of type T by name in the
+// DefaultRegistry.
+func RegisterName[T any](name string, schema Validator[T]) {
+	RegisterNamed(DefaultRegistry, name, schema)
+}
+
+// For returns the schema registered for type T in the DefaultRegistry.
+func For[T any]() (Validator[T], bool) {
+	return Lookup[T](DefaultRegistry)
+}
+
+// RegisterRule adds a named rule to the DefaultRegistry. See AddRule. As rules
+// are registered at startup, it panics if the rule can't be added.
+func RegisterRule[T any](rule *Rule[T]) {
+	if err := AddRule(DefaultRegistry, rule); err != nil {
+		panic(err)
+	}
+}
+
+// RuleFor returns a copy of the rule for values of type T that was added to
+// the DefaultRegistry by name. See LookupRule.
+func RuleFor[T any](name string) (*Rule[T], bool) {
+	return LookupRule[T](DefaultRegistry, name)
+}
+
+// Validate validates a value against the schema registered by name. It fails
+// with ErrUnknownSchema if no schema is registered for the name, and with a
+// type error if the value is not of the type the schema was registered for.
+func (r *Registry) Validate(name string, value any) error {
+	return r.ValidateContext(context.Background(), name, value)
+}
+
+// ValidateContext validates a value against the schema registered by name
+// with the given context. See Validate.
+func (r *Registry) ValidateContext(ctx context.Context, name string, value any) error {
+	r.mu.RLock()
+	e, ok := r.byName[name]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownSchema, name)
+	}
+	return e.validateFn(ctx, value)
+}
+
+// ValidateValue validates a value against the schema registered for its
+// dynamic type, e.g. in middleware that handles values of many types. Values
+// of a type without a registered schema are considered valid.
+func (r *Registry) ValidateValue(ctx context.Context, value any) error {
+	r.mu.RLock()
+	e, ok := r.byType[reflect.TypeOf(value)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return e.validateFn(ctx, value)
+}
+
+// Names returns the names of the schemas registered by name, in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.byName))
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Report records the outcome of validating an input against a specific
+// version of a schema, e.g. to prove in a regulated workflow that an input was
+// validated. It is encoded as JSON with a fixed field order, so that the
+// encoding can be signed.
+type Report struct {
+	Schema    string          `json:"schema"`     // Identifier of the schema version, e.g. a fingerprint.
+	InputHash string          `json:"input_hash"` // SHA-256 of the JSON encoding of the input, e.g. `sha256:9f86...`.
+	Timestamp time.Time       `json:"timestamp"`
+	Valid     bool            `json:"valid"`
+	Failures  []ReportFailure `json:"failures,omitempty"`
+}
+
+// ReportFailure describes a single failed rule in a Report.
+type ReportFailure struct {
+	Field   string `json:"field,omitempty"` // Dot separated path of the field, if any.
+	Code    string `json:"code"`            // Code as used by Summarize, e.g. `max_length`.
+	Message string `json:"message"`
+}
+
+// NewReport validates value against the schema with the given context and
+// returns a report of the result, timestamped in UTC. The schema ID identifies
+// the version of the schema, e.g. a release tag or a fingerprint of its
+// definition (see valtorjsonschema.Fingerprint). An error is only returned if
+// the value cannot be encoded as JSON to compute the input hash.
+func NewReport[T any](ctx context.Context, schema Validator[T], schemaID string, value T) (*Report, error) {
+	input, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode input: %w", err)
+	}
+	sum := sha256.Sum256(input)
+
+	report := &Report{
+		Schema:    schemaID,
+		InputHash: "sha256:" + hex.EncodeToString(sum[:]),
+		Timestamp: time.Now().UTC(),
+		Valid:     true,
+	}
+
+	for _, err := range flattenErrors(validateContext(ctx, schema, value)) {
+		report.Valid = false
+		report.Failures = append(report.Failures, ReportFailure{
+			Field:   fieldPath(err),
+			Code:    errorCode(err),
+			Message: err.Error(),
+		})
+	}
+
+	return report, nil
+}
+
+// fieldPath returns the dot separated path of the (nested) field errors wrapped
+// in err, e.g. `address.zip`.
+func fieldPath(err error) string {
+	var path []string
+	for {
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			return strings.Join(path, ".")
+		}
+		path = append(path, fieldErr.Field)
+		err = fieldErr.Err
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+)
+
+// Rule represents a validation rule that can be guarded by one or more
+// conditions, e.g. to gradually roll out a stricter validation behind a
+// feature flag. It implements the Validator and ContextValidator interfaces.
+type Rule[T any] struct {
+	name       string
+	code       string
+	validateFn func(context.Context, T) error
+	conditions []func(context.Context, T) bool
+	hint       string
+	docURL     string
+	severity   Severity
+}
+
+// RuleError is returned when a rule fails. Besides the underlying error, it
+// carries remediation guidance and a documentation link that clients can show
+// separately from the error message. Use errors.As to retrieve it from a
+// wrapped error.
+type RuleError struct {
+	Err      error
+	Hint     string
+	DocURL   string
+	Severity Severity
+}
+
+func (e *RuleError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// NewRule creates a new validation rule for type T.
+func NewRule[T any](fn func(T) error) *Rule[T] {
+	return &Rule[T]{
+		validateFn: func(_ context.Context, value T) error {
+			return fn(value)
+		},
+	}
+}
+
+// NewContextRule creates a new validation rule for type T that receives the
+// context passed to ValidateContext, e.g. for rules that query a database and
+// should be canceled along with the request.
+func NewContextRule[T any](fn func(context.Context, T) error) *Rule[T] {
+	return &Rule[T]{
+		validateFn: fn,
+	}
+}
+
+// DefineRule creates a new named validation rule for type T, e.g. to be
+// registered with RegisterRule and reused across schemas. Errors of the rule
+// are returned as a *ConstraintError with the code, e.g. `acme.sku`, that
+// wraps the error of fn, so that they can be told apart like errors of
+// built-in constraints and get their message from a message catalog (see
+// WithMessages).
+func DefineRule[T any](name, code string, fn func(T) error) *Rule[T] {
+	r := NewRule(fn)
+	r.name = name
+	r.code = code
+	return r
+}
+
+// Name returns the name of the rule, if it was created with DefineRule.
+func (r *Rule[T]) Name() string {
+	return r.name
+}
+
+// Code returns the error code of the rule, if it was created with DefineRule.
+func (r *Rule[T]) Code() string {
+	return r.code
+}
+
+// extension returns the rule as an extension with its name (see AddRule). Its
+// only parameter is `true`.
+func (r *Rule[T]) extension() Extension {
+	return Extension{
+		Name: r.name,
+		Parse: func(params json.RawMessage) (func(any) error, error) {
+			if string(params) != "true" {
+				return nil, fmt.Errorf("expected `true`, got %s", params)
+			}
+			return func(value any) error {
+				v, ok := value.(T)
+				if !ok {
+					b, err := json.Marshal(value)
+					if err == nil {
+						err = json.Unmarshal(b, &v)
+					}
+					if err != nil {
+						return fmt.Errorf("expected value of type %v, got %T", reflect.TypeFor[T](), value)
+					}
+				}
+				return r.Validate(v)
+			}, nil
+		},
+		rule: r,
+	}
+}
+
+// clone returns a copy of the rule that can be changed without affecting the
+// original.
+func (r *Rule[T]) clone() *Rule[T] {
+	c := *r
+	c.conditions = slices.Clone(r.conditions)
+	return &c
+}
+
+// EnabledIf adds a condition that must report true for the rule to be applied
+// and returns the rule for chaining. If multiple conditions are added, all of
+// them must report true.
+func (r *Rule[T]) EnabledIf(fn func(ctx context.Context) bool) *Rule[T] {
+	r.conditions = append(r.conditions, func(ctx context.Context, _ T) bool {
+		return fn(ctx)
+	})
+	return r
+}
+
+// Sample makes the rule apply to a random fraction of validations, where rate
+// is between 0 (never) and 1 (always), and returns the rule for chaining. It is
+// meant for expensive validations that are too costly to run every time.
+func (r *Rule[T]) Sample(rate float64) *Rule[T] {
+	r.conditions = append(r.conditions, func(context.Context, T) bool {
+		return rand.Float64() < rate
+	})
+	return r
+}
+
+// SampleBy makes the rule apply to a deterministic fraction of validations,
+// where rate is between 0 (never) and 1 (always), and returns the rule for
+// chaining. Values for which key returns the same string are either always or
+// never sampled, e.g. to consistently sample all requests of a user.
+func (r *Rule[T]) SampleBy(rate float64, key func(ctx context.Context, value T) string) *Rule[T] {
+	r.conditions = append(r.conditions, func(ctx context.Context, value T) bool {
+		h := fnv.New64a()
+		h.Write([]byte(key(ctx, value)))
+		return float64(h.Sum64())/math.MaxUint64 < rate
+	})
+	return r
+}
+
+// Hint sets a remediation hint, e.g. `use ISO 8601, e.g. 2024-01-31`, and
+// returns the rule for chaining. Errors of a rule with a hint are returned as
+// a *RuleError.
+func (r *Rule[T]) Hint(hint string) *Rule[T] {
+	r.hint = hint
+	return r
+}
+
+// DocURL sets a link to the documentation of the validated contract and
+// returns the rule for chaining. Errors of a rule with a documentation URL are
+// returned as a *RuleError. To link a schema, wrap it in a rule, e.g.
+// `NewRule(schema.Validate).DocURL(url)`.
+func (r *Rule[T]) DocURL(url string) *Rule[T] {
+	r.docURL = url
+	return r
+}
+
+// Severity sets the severity of the rule and returns the rule for chaining.
+// Errors of a rule with a severity other than SeverityError don't fail
+// validation. Instead, they are returned as a *RuleError in the Warnings or
+// Infos of ValidateResult, and passed to the Warn function of the profile, if
+// any.
+func (r *Rule[T]) Severity(severity Severity) *Rule[T] {
+	r.severity = severity
+	return r
+}
+
+// enabled reports whether the rule is applied for the given context and value.
+func (r *Rule[T]) enabled(ctx context.Context, value T) bool {
+	for _, condition := range r.conditions {
+		if !condition(ctx, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate validates the value against the rule using a background context.
+func (r *Rule[T]) Validate(value T) error {
+	return r.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the value against the rule if it is enabled for the
+// given context.
+func (r *Rule[T]) ValidateContext(ctx context.Context, value T) error {
+	if !r.enabled(ctx, value) {
+		return nil
+	}
+	err := r.validateFn(ctx, value)
+	if err != nil && r.code != "" {
+		err = &ConstraintError{Code: r.code, Message: err.Error(), Err: err}
+	}
+	if err == nil || (r.hint == "" && r.docURL == "" && r.severity == SeverityError) {
+		return err
+	}
+	ruleErr := &RuleError{Err: err, Hint: r.hint, DocURL: r.docURL, Severity: r.severity}
+	if r.severity != SeverityError {
+		report(ctx, r.severity, ruleErr)
+		return nil
+	}
+	return ruleErr
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The validators and helpers in this file are meant as a first line of input
+// hardening. They don't replace escaping output, e.g. with html/template, or
+// parameterized SQL queries.
+
+// maxFilenameBytes is the maximum length of a file name on common file
+// systems.
+const maxFilenameBytes = 255
+
+// reservedFilenames are file names that are reserved for devices on Windows,
+// with or without an extension.
+var reservedFilenames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// NoHTML adds a validator that checks if the string does not contain HTML
+// markup, i.e. tags (including `<script>`), comments or declarations, or null
+// bytes, and returns the schema for chaining. A `<` that doesn't start markup,
+// e.g. in `1 < 2`, is allowed. See StripHTML to remove markup instead.
+func (s *StringSchema) NoHTML() *StringSchema {
+	s.describe(CodeHTML, false)
+	s.addValidator(func(v string) error {
+		if strings.IndexByte(v, 0) >= 0 || markupIndex(v, 0) >= 0 {
+			return constraintError(CodeHTML, nil, "string must not contain HTML")
+		}
+		return nil
+	})
+	return s
+}
+
+// NoSQLMeta adds a validator that checks if the string does not contain SQL
+// metacharacters, i.e. quotes, backslashes, semicolons, comment sequences
+// (`--`, `/*` and `*/`) or null bytes, and returns the schema for chaining.
+func (s *StringSchema) NoSQLMeta() *StringSchema {
+	s.describe(CodeSQLMeta, false)
+	s.addValidator(func(v string) error {
+		if strings.ContainsAny(v, "'\"`\\;\x00") || strings.Contains(v, "--") ||
+			strings.Contains(v, "/*") || strings.Contains(v, "*/") {
+			return constraintError(CodeSQLMeta, nil, "string must not contain SQL metacharacters")
+		}
+		return nil
+	})
+	return s
+}
+
+// SafeFilename adds a validator that checks if the string is a file name that
+// is safe to use on common file systems, and returns the schema for chaining.
+// The name must be a single path element, i.e. not `.` or `..` and without
+// slashes or backslashes, so that it can't be used for path traversal. It must
+// not contain control characters, such as null bytes, or characters that are
+// invalid on Windows (`<>:"|?*`), must not end with a dot or space, must not
+// be reserved on Windows (e.g. `CON` or `nul.txt`), and must be at most 255
+// bytes long. See SanitizeFilename to make names safe instead.
+func (s *StringSchema) SafeFilename() *StringSchema {
+	s.describe(CodeFilename, true)
+	s.addValidator(func(v string) error {
+		if SanitizeFilename(v) != v {
+			return constraintError(CodeFilename, nil, "string must be a safe file name")
+		}
+		return nil
+	})
+	return s
+}
+
+// StripHTML returns s without HTML markup (see StringSchema.NoHTML) and null
+// bytes. The content of `script` and `style` elements is removed along with
+// their tags; the text of other elements is kept. Entities, such as `&lt;`,
+// are not decoded.
+func StripHTML(s string) string {
+	var sb strings.Builder
+	i := 0
+	for {
+		start := markupIndex(s, i)
+		if start < 0 {
+			sb.WriteString(s[i:])
+			break
+		}
+		sb.WriteString(s[i:start])
+		i = markupEnd(s, start)
+		if name := tagName(s[start:i]); name == "script" || name == "style" {
+			i = closingTagEnd(s, i, name)
+		}
+	}
+	return strings.ReplaceAll(sb.String(), "\x00", "")
+}
+
+// SanitizeFilename returns a safe file name (see StringSchema.SafeFilename)
+// for name, e.g. of an upload. Slashes, backslashes, characters that are
+// invalid on Windows and control characters are replaced with underscores,
+// trailing dots and spaces are removed, reserved names are prefixed with an
+// underscore and long names are truncated, keeping the extension if possible. An empty result, or one that
+// consists of dots only, is replaced with an underscore.
+func SanitizeFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\<>:"|?*`, r) || unicode.IsControl(r) || r == utf8.RuneError {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimRight(name, ". ")
+	if strings.Trim(name, ".") == "" {
+		return "_"
+	}
+	base, _, _ := strings.Cut(name, ".")
+	if reservedFilenames[strings.ToUpper(strings.TrimRight(base, " "))] {
+		name = "_" + name
+	}
+	if len(name) > maxFilenameBytes {
+		ext := ""
+		if i := strings.LastIndexByte(name, '.'); i > 0 && len(name)-i <= 16 {
+			ext = name[i:]
+		}
+		name = truncateUTF8(name[:len(name)-len(ext)], maxFilenameBytes-len(ext)) + ext
+		name = strings.TrimRight(name, ". ")
+	}
+	return name
+}
+
+// truncateUTF8 truncates s to at most n bytes, without splitting runes.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// markupIndex returns the index of the first `<` at or after i that starts a
+// tag, comment or declaration, or -1 if there is none.
+func markupIndex(s string, i int) int {
+	for {
+		j := strings.IndexByte(s[i:], '<')
+		if j < 0 || i+j+1 >= len(s) {
+			return -1
+		}
+		i += j
+		if c := s[i+1]; c == '/' || c == '!' || c == '?' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') {
+			return i
+		}
+		i++
+	}
+}
+
+// markupEnd returns the index after the end of the markup that starts at
+// start, or len(s) if it is not terminated. Quoted attribute values may
+// contain `>`.
+func markupEnd(s string, start int) int {
+	if strings.HasPrefix(s[start:], "<!--") {
+		if end := strings.Index(s[start+4:], "-->"); end >= 0 {
+			return start + 4 + end + 3
+		}
+		return len(s)
+	}
+	var quote byte
+	for i := start + 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// tagName returns the lowercase name of an opening tag, or an empty string if
+// the markup is not an opening tag.
+func tagName(markup string) string {
+	name := strings.TrimPrefix(markup, "<")
+	if end := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}); end >= 0 {
+		name = name[:end]
+	}
+	return strings.ToLower(name)
+}
+
+// closingTagEnd returns the index after the closing tag of the element with
+// the name, starting at i, or len(s) if there is none.
+func closingTagEnd(s string, i int, name string) int {
+	for {
+		j := strings.Index(s[i:], "</")
+		if j < 0 {
+			return len(s)
+		}
+		i += j
+		if rest := s[i+2:]; hasPrefixFoldASCII(rest, name) && (len(rest) == len(name) || !isASCIIAlnum(rest[len(name)])) {
+			return markupEnd(s, i)
+		}
+		i += 2
+	}
+}
+
+// isASCIIAlnum reports whether c is an ASCII letter or digit.
+func isASCIIAlnum(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// hasPrefixFoldASCII reports whether s begins with the lowercase prefix,
+// ignoring the case of ASCII letters. Unlike with strings.ToLower, indices in s
+// stay valid, as the case of non-ASCII characters is not folded.
+func hasPrefixFoldASCII(s, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for k := range len(prefix) {
+		c := s[k]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != prefix[k] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "script", input: "a<script>alert(1)</script>b", want: "ab"},
+		{name: "uppercase closing tag", input: "a<SCRIPT>alert(1)</Script >b", want: "ab"},
+		{name: "unterminated script", input: "a<script>alert(1)", want: "a"},
+		{name: "closing tag of other element", input: "a<style>x</styles>y</style>b", want: "ab"},
+		{
+			// Lowercasing `Ⱥ` makes it longer, which must not shift the
+			// index of the closing tag.
+			name:  "non-ASCII content",
+			input: "<script>" + strings.Repeat("Ⱥ", 20) + "</script>ok",
+			want:  "ok",
+		},
+		{name: "non-ASCII text", input: "<b>Ⱥ</b>", want: "Ⱥ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripHTML(tt.input); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
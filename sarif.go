@@ -0,0 +1,153 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The types below are the subset of SARIF 2.1.0 that WriteSARIF uses.
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// WriteSARIF writes the report to w as a SARIF 2.1.0 log, so that failures
+// show up as annotations in code scanning tools, e.g. of GitHub or GitLab.
+// Each failure is a result with the failure's code as rule ID. The input of a
+// failure is used as file path, and a `:<line>` suffix (e.g.
+// `config/users.json:12`) as line number. The field path is used as logical
+// location.
+func (r *BatchReport) WriteSARIF(w io.Writer) error {
+	codes := make(map[string]struct{})
+	for _, f := range r.Failures {
+		codes[f.Code] = struct{}{}
+	}
+	ruleIDs := slices.Sorted(maps.Keys(codes))
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "valtor",
+			InformationURI: "https://github.com/dstotijn/valtor",
+		}},
+		Results: make([]sarifResult, 0, len(r.Failures)),
+	}
+	for _, id := range ruleIDs {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+
+	for _, f := range r.Failures {
+		path, line := splitInput(f.Input)
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: path}},
+		}
+		if line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+		}
+		if f.Field != "" {
+			location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Field}}
+		}
+		ruleIndex, _ := slices.BinarySearch(ruleIDs, f.Code)
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    f.Code,
+			RuleIndex: ruleIndex,
+			Level:     "error",
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// splitInput splits an input identifier into a path and a line number, which
+// is 0 if the input has no `:<line>` suffix.
+func splitInput(input string) (string, int) {
+	i := strings.LastIndexByte(input, ':')
+	if i < 0 {
+		return input, 0
+	}
+	line, err := strconv.Atoi(input[i+1:])
+	if err != nil || line < 1 {
+		return input, 0
+	}
+	return input[:i], line
+}
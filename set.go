@@ -0,0 +1,88 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import "fmt"
+
+// SetSchema represents a validation schema for slices that are treated as
+// sets: items must be unique, and their order is not significant. The
+// validators of ArraySchema, such as Min and Max, can be used as well. Items
+// are compared like by UniqueItems, so that e.g. slices in a Set[any] are
+// compared by their JSON encoding.
+type SetSchema[T comparable] struct {
+	*ArraySchema[T]
+}
+
+// Set creates a new validation schema for slices that are treated as sets.
+func Set[T comparable](opts ...Option) *SetSchema[T] {
+	return &SetSchema[T]{
+		ArraySchema: Array[T](opts...).uniqueItems(),
+	}
+}
+
+// Subset adds a validator that checks if all items are in the allowed values
+// and returns the schema for chaining. The error lists the allowed values.
+func (s *SetSchema[T]) Subset(allowed ...T) *SetSchema[T] {
+	key := itemKey[T]()
+	set := make(map[any]struct{}, len(allowed))
+	for _, v := range allowed {
+		if k, err := key(v); err == nil {
+			set[k] = struct{}{}
+		}
+	}
+	list := listValues(allowed)
+	params := anyValues(allowed)
+	s.addValidator(func(arr []T) error {
+		for i, item := range arr {
+			k, err := key(item)
+			if err != nil {
+				return fmt.Errorf("failed to marshal set item at index %d: %w", i, err)
+			}
+			if _, ok := set[k]; !ok {
+				return constraintError(CodeOneOf, map[string]any{"index": i, "allowed": params, "actual": item},
+					"set item %#v at index %d is not allowed, must be one of %s", item, i, list)
+			}
+		}
+		return nil
+	})
+	return s
+}
+
+// Superset adds a validator that checks if the required values are all items
+// of the set and returns the schema for chaining.
+func (s *SetSchema[T]) Superset(required ...T) *SetSchema[T] {
+	key := itemKey[T]()
+	s.addValidator(func(arr []T) error {
+		set := make(map[any]struct{}, len(arr))
+		for i, item := range arr {
+			k, err := key(item)
+			if err != nil {
+				return fmt.Errorf("failed to marshal set item at index %d: %w", i, err)
+			}
+			set[k] = struct{}{}
+		}
+		for _, v := range required {
+			k, err := key(v)
+			if err != nil {
+				return fmt.Errorf("failed to marshal required value %#v: %w", v, err)
+			}
+			if _, ok := set[k]; !ok {
+				return constraintError(CodeContains, map[string]any{"value": v}, "set must contain %#v", v)
+			}
+		}
+		return nil
+	})
+	return s
+}
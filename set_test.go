@@ -0,0 +1,42 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import "testing"
+
+func TestSetAny(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  *SetSchema[any]
+		value   []any
+		wantErr bool
+	}{
+		{name: "unique slices", schema: Set[any](), value: []any{[]any{1}, []any{2}}},
+		{name: "duplicate slices", schema: Set[any](), value: []any{[]any{1}, []any{1}}, wantErr: true},
+		{name: "duplicate maps", schema: Set[any](), value: []any{map[string]any{"a": 1}, map[string]any{"a": 1}}, wantErr: true},
+		{name: "subset", schema: Set[any]().Subset([]any{1}, "a"), value: []any{[]any{1}, "a"}},
+		{name: "not a subset", schema: Set[any]().Subset([]any{1}), value: []any{[]any{2}}, wantErr: true},
+		{name: "superset", schema: Set[any]().Superset([]any{1}), value: []any{"a", []any{1}}},
+		{name: "not a superset", schema: Set[any]().Superset([]any{1}), value: []any{"a"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
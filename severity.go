@@ -0,0 +1,107 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"sync"
+)
+
+// Severity is the severity of a rule, which determines whether its errors fail
+// validation.
+type Severity int
+
+const (
+	// SeverityError makes errors of a rule fail validation. This is the default.
+	SeverityError Severity = iota
+	// SeverityWarning makes errors of a rule soft failures, e.g. for the use
+	// of a deprecated field, which are reported but do not fail validation.
+	SeverityWarning
+	// SeverityInfo makes errors of a rule informational, e.g. for suggestions.
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// Result is the result of ValidateResult. It separates errors that fail
+// validation from warnings and informational messages, so that e.g. an API
+// gateway can log warnings without rejecting a request.
+type Result struct {
+	Err      error   // Errors that fail validation, nil if the value is valid.
+	Warnings []error // Errors of rules with SeverityWarning, and of checks not enforced by the profile.
+	Infos    []error // Errors of rules with SeverityInfo.
+}
+
+// Valid reports whether the value passed validation, i.e. whether there are no
+// errors. Warnings and informational messages are not taken into account.
+func (r Result) Valid() bool {
+	return r.Err == nil
+}
+
+type resultKey struct{}
+
+// resultCollector collects the soft failures of a validation.
+type resultCollector struct {
+	mu       sync.Mutex
+	warnings []error
+	infos    []error
+}
+
+// ValidateResult validates the value against the schema with the given
+// context, and returns a Result with both the errors and the soft failures,
+// i.e. the errors of rules with a severity other than SeverityError (see
+// Rule.Severity). Soft failures are only collected when validating with
+// ValidateResult; otherwise, they are passed to the Warn function of the
+// profile, if any.
+func ValidateResult[T any](ctx context.Context, schema Validator[T], value T) Result {
+	c := &resultCollector{}
+	err := validateContext(context.WithValue(ctx, resultKey{}, c), schema, value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Result{
+		Err:      err,
+		Warnings: c.warnings,
+		Infos:    c.infos,
+	}
+}
+
+// report records a soft failure in the result collector of ctx, if any, and
+// passes it to the Warn function of the profile in ctx, if any.
+func report(ctx context.Context, severity Severity, err error) {
+	if c, ok := ctx.Value(resultKey{}).(*resultCollector); ok {
+		c.mu.Lock()
+		if severity == SeverityInfo {
+			c.infos = append(c.infos, err)
+		} else {
+			c.warnings = append(c.warnings, err)
+		}
+		c.mu.Unlock()
+	}
+	if warn := ProfileFromContext(ctx).Warn; warn != nil {
+		warn(ctx, err)
+	}
+}
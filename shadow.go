@@ -0,0 +1,79 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ShadowSchema represents a validation schema that runs a second, typically
+// stricter, schema in "shadow" mode. Shadow failures are reported to hooks but
+// never affect the returned error, so upcoming rule changes can be evaluated
+// against real traffic.
+type ShadowSchema[T any] struct {
+	primary  Validator[T]
+	shadow   Validator[T]
+	hooks    []func(ctx context.Context, value T, err error)
+	failures atomic.Uint64
+}
+
+// Shadow creates a new validation schema that validates against the primary
+// schema, and additionally runs the shadow schema.
+func Shadow[T any](primary, shadow Validator[T]) *ShadowSchema[T] {
+	return &ShadowSchema[T]{
+		primary: primary,
+		shadow:  shadow,
+	}
+}
+
+// OnFailure adds a hook that is called when a value passes the primary schema
+// but fails the shadow schema, and returns the schema for chaining.
+func (s *ShadowSchema[T]) OnFailure(fn func(ctx context.Context, value T, err error)) *ShadowSchema[T] {
+	s.hooks = append(s.hooks, fn)
+	return s
+}
+
+// Failures returns the number of values that passed the primary schema but
+// failed the shadow schema.
+func (s *ShadowSchema[T]) Failures() uint64 {
+	return s.failures.Load()
+}
+
+// Validate validates the value against the schema and returns the error of the
+// primary schema, if any.
+func (s *ShadowSchema[T]) Validate(value T) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the value against the schema with the given context
+// and returns the error of the primary schema, if any.
+func (s *ShadowSchema[T]) ValidateContext(ctx context.Context, value T) error {
+	if err := validateContext(ctx, s.primary, value); err != nil {
+		return err
+	}
+
+	// The errors of the shadow schema don't count against the error budget
+	// of the validation (see WithMaxErrors), as they are not returned.
+	ctx = context.WithValue(ctx, errorBudgetKey{}, (*errorBudget)(nil))
+	if err := validateContext(ctx, s.shadow, value); err != nil {
+		s.failures.Add(1)
+		for _, hook := range s.hooks {
+			hook(ctx, value, err)
+		}
+	}
+
+	return nil
+}
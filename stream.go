@@ -0,0 +1,205 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ValidateTokens reads a single JSON value from the token stream of dec and
+// checks its strings and arrays against the maximum lengths (`maxLength` and
+// `maxItems`) described by the schema (see Describe), as the tokens are read.
+// It returns at the first string or array that is too long, without reading
+// the rest of the value, so that abusive payloads are rejected early and are
+// never decoded into Go values. String lengths are measured in runes, so that
+// strings are only rejected if they are too long in either LengthMode.
+//
+// Violations are returned as a ConstraintError, wrapped in a FieldError with
+// the path of the value, e.g. `tags[3]`, if it is nested. As arrays are not
+// read beyond their maximum length, the actual length of an array that is too
+// long is reported as the maximum plus one. Tokens that can't be
+// read fail with a DecodeError. Values that are not described, e.g. of fields
+// without a nested schema (see FieldOf) or of unions, are read without checks.
+func ValidateTokens(dec *json.Decoder, schema Describer) error {
+	d := schema.Describe()
+	return (&tokenChecker{dec: dec}).check(&d, "", 0)
+}
+
+// ValidateJSONStream reads a JSON document from r, checking it with
+// ValidateTokens as it is read, and then decodes it into a value of type T
+// and validates it against the schema, as ValidateJSON. Unlike ValidateJSON,
+// it stops reading r at the first string or array that exceeds its maximum
+// length. Schemas that don't implement Describer are only validated after
+// decoding.
+func ValidateJSONStream[T any](ctx context.Context, r io.Reader, schema Validator[T]) error {
+	describer, ok := schema.(Describer)
+	if !ok {
+		return validateJSON(ctx, schema, nil, r)
+	}
+	d := describer.Describe()
+	return validateJSON(ctx, schema, &d, r)
+}
+
+// tokenChecker checks the values of a token stream against descriptions and
+// safety limits.
+type tokenChecker struct {
+	dec    *json.Decoder
+	limits limitChecker
+}
+
+func (c *tokenChecker) token() (json.Token, error) {
+	offset := c.dec.InputOffset()
+	tok, err := c.dec.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, &DecodeError{Offset: offset, Err: io.ErrUnexpectedEOF}
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, &DecodeError{Offset: syntaxErr.Offset, Err: err}
+	}
+	if err != nil {
+		return nil, &DecodeError{Offset: c.dec.InputOffset(), Err: err}
+	}
+	return tok, nil
+}
+
+// check reads a value at the depth, i.e. the number of objects and arrays it
+// is nested in, and checks it against d, which is nil if the value is not
+// described.
+func (c *tokenChecker) check(d *Description, path string, depth int) error {
+	tok, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		if err := c.limits.enter(path, depth+1); err != nil {
+			return err
+		}
+		for c.dec.More() {
+			key, err := c.token()
+			if err != nil {
+				return err
+			}
+			name, _ := key.(string)
+			fieldPath := joinPath(path, name)
+			if err := c.limits.item(fieldPath); err != nil {
+				return err
+			}
+			if err := c.limits.str(fieldPath, name); err != nil {
+				return err
+			}
+			if err := c.check(d.field(name), fieldPath, depth+1); err != nil {
+				return err
+			}
+		}
+	case json.Delim('['):
+		if err := c.limits.enter(path, depth+1); err != nil {
+			return err
+		}
+		max, hasMax := d.maxInt("maxItems")
+		for i := 0; c.dec.More(); i++ {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			if hasMax && i >= max {
+				return pathError(path, constraintError(CodeMaxLength, map[string]any{"max": max, "actual": i + 1},
+					"array length must be at most %d, got more", max))
+			}
+			if err := c.limits.item(itemPath); err != nil {
+				return err
+			}
+			if err := c.check(d.items(), itemPath, depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		s, ok := tok.(string)
+		if !ok {
+			return nil
+		}
+		if err := c.limits.str(path, s); err != nil {
+			return err
+		}
+		if max, ok := d.maxInt("maxLength"); ok {
+			if n := utf8.RuneCountInString(s); n > max {
+				return pathError(path, constraintError(CodeMaxLength, map[string]any{"max": max, "actual": n},
+					"length must be at most %d, got %d", max, n))
+			}
+		}
+		return nil
+	}
+
+	// Read the closing delimiter.
+	_, err = c.token()
+	return err
+}
+
+// field returns the description of the field with the name, or nil if it is
+// not described.
+func (d *Description) field(name string) *Description {
+	if d == nil || len(d.Variants) > 0 {
+		return nil
+	}
+	for i := range d.Fields {
+		if d.Fields[i].Name == name {
+			return &d.Fields[i].Description
+		}
+	}
+	return nil
+}
+
+// items returns the description of the items of an array, or nil if they are
+// not described.
+func (d *Description) items() *Description {
+	if d == nil || len(d.Variants) > 0 {
+		return nil
+	}
+	return d.Items
+}
+
+// maxInt returns the lowest integer value of the constraints with the keyword.
+func (d *Description) maxInt(keyword string) (int, bool) {
+	if d == nil || len(d.Variants) > 0 {
+		return 0, false
+	}
+	max, ok := 0, false
+	for _, c := range d.Constraints {
+		if v, isInt := c.Value.(int); isInt && c.Keyword == keyword && (!ok || v < max) {
+			max, ok = v, true
+		}
+	}
+	return max, ok
+}
+
+// joinPath returns the path of a field of the value at path.
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// pathError wraps err in a FieldError with the path, unless the path is empty.
+func pathError(path string, err error) error {
+	if path == "" {
+		return err
+	}
+	return &FieldError{Field: path, Err: err}
+}
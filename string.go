@@ -15,34 +15,73 @@
 package valtor
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dstotijn/valtor/formats"
+)
+
+// LengthMode determines how the length of a string is measured.
+type LengthMode int
+
+const (
+	// LengthBytes measures string length in bytes. This is the default.
+	LengthBytes LengthMode = iota
+	// LengthRunes measures string length in runes (Unicode code points).
+	LengthRunes
 )
 
 // StringSchema represents a validation schema for string values.
 type StringSchema struct {
 	*Schema[string]
-	required bool
+	required   bool
+	lengthMode LengthMode
 }
 
-// String creates a new validation schema for string values.
-func String() *StringSchema {
-	return &StringSchema{
-		Schema: New[string](),
+// String creates a new validation schema for string values, configured with
+// the options.
+func String(opts ...Option) *StringSchema {
+	s := &StringSchema{
+		Schema: New[string](opts...),
 	}
+	s.lengthMode = s.config.lengthMode
+	return s
 }
 
 // Required will make a string value required to be not empty when validated.
 func (s *StringSchema) Required() *StringSchema {
+	s.describe("required", true)
 	s.required = true
 	return s
 }
 
+// LengthMode sets how string length is measured by the Min, Max and Length
+// validators and returns the schema for chaining. It applies to all length
+// validators, regardless of the order in which they were added.
+func (s *StringSchema) LengthMode(mode LengthMode) *StringSchema {
+	s.lengthMode = mode
+	return s
+}
+
+// length returns the length of v according to the schema's length mode.
+func (s *StringSchema) length(v string) int {
+	if s.lengthMode == LengthRunes {
+		return utf8.RuneCountInString(v)
+	}
+	return len(v)
+}
+
 // Min adds a minimum length validator to the schema and returns the schema for chaining.
 func (s *StringSchema) Min(min int) *StringSchema {
-	s.validators = append(s.validators, func(v string) error {
-		if len(v) < min {
-			return fmt.Errorf("length must be at least %d", min)
+	s.describe("minLength", min)
+	s.addValidator(func(v string) error {
+		if n := s.length(v); n < min {
+			return constraintError(CodeMinLength, map[string]any{"min": min, "actual": n},
+				"length must be at least %d, got %d", min, n)
 		}
 		return nil
 	})
@@ -51,9 +90,11 @@ func (s *StringSchema) Min(min int) *StringSchema {
 
 // Max adds a maximum length validator to the schema and returns the schema for chaining.
 func (s *StringSchema) Max(max int) *StringSchema {
-	s.validators = append(s.validators, func(v string) error {
-		if len(v) > max {
-			return fmt.Errorf("length must be at most %d", max)
+	s.describe("maxLength", max)
+	s.addValidator(func(v string) error {
+		if n := s.length(v); n > max {
+			return constraintError(CodeMaxLength, map[string]any{"max": max, "actual": n},
+				"length must be at most %d, got %d", max, n)
 		}
 		return nil
 	})
@@ -62,9 +103,12 @@ func (s *StringSchema) Max(max int) *StringSchema {
 
 // Length adds a length validator to the schema and returns the schema for chaining.
 func (s *StringSchema) Length(length int) *StringSchema {
-	s.validators = append(s.validators, func(v string) error {
-		if len(v) != length {
-			return fmt.Errorf("length must be exactly %d", length)
+	s.describe("minLength", length)
+	s.describe("maxLength", length)
+	s.addValidator(func(v string) error {
+		if n := s.length(v); n != length {
+			return constraintError(CodeLength, map[string]any{"length": length, "actual": n},
+				"length must be exactly %d, got %d", length, n)
 		}
 		return nil
 	})
@@ -73,19 +117,202 @@ func (s *StringSchema) Length(length int) *StringSchema {
 
 // Regexp adds a regular expression pattern validator to the schema and returns the schema for chaining.
 func (s *StringSchema) Regexp(re *regexp.Regexp) *StringSchema {
-	s.validators = append(s.validators, func(v string) error {
+	s.describe("pattern", re.String())
+	s.addValidator(func(v string) error {
 		if !re.MatchString(v) {
-			return fmt.Errorf("string must match pattern %q", re.String())
+			return constraintError(CodePattern, map[string]any{"pattern": re.String()},
+				"string must match pattern %q", re.String())
+		}
+		return nil
+	})
+	return s
+}
+
+// Pattern adds a regular expression pattern validator to the schema and returns
+// the schema for chaining. Unlike Regexp, it takes the pattern as a string,
+// which is compiled once and cached (see CompilePattern), so that schemas that
+// are built repeatedly with the same pattern don't recompile it. An invalid
+// pattern results in an error.
+func (s *StringSchema) Pattern(pattern string) (*StringSchema, error) {
+	re, err := CompilePattern(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return s.Regexp(re), nil
+}
+
+// Contains adds a validator that checks if the string contains the substring
+// and returns the schema for chaining.
+func (s *StringSchema) Contains(substr string) *StringSchema {
+	s.describe(CodeContains, substr)
+	s.addValidator(func(v string) error {
+		if !strings.Contains(v, substr) {
+			return constraintError(CodeContains, map[string]any{"substr": substr}, "string must contain %q", substr)
+		}
+		return nil
+	})
+	return s
+}
+
+// NotContains adds a validator that checks if the string does not contain the
+// substring and returns the schema for chaining.
+func (s *StringSchema) NotContains(substr string) *StringSchema {
+	s.describe(CodeNotContains, substr)
+	s.addValidator(func(v string) error {
+		if strings.Contains(v, substr) {
+			return constraintError(CodeNotContains, map[string]any{"substr": substr}, "string must not contain %q", substr)
+		}
+		return nil
+	})
+	return s
+}
+
+// HasPrefix adds a validator that checks if the string begins with the prefix
+// and returns the schema for chaining.
+func (s *StringSchema) HasPrefix(prefix string) *StringSchema {
+	s.describe(CodePrefix, prefix)
+	s.addValidator(func(v string) error {
+		if !strings.HasPrefix(v, prefix) {
+			return constraintError(CodePrefix, map[string]any{"prefix": prefix}, "string must start with %q", prefix)
+		}
+		return nil
+	})
+	return s
+}
+
+// HasSuffix adds a validator that checks if the string ends with the suffix and
+// returns the schema for chaining.
+func (s *StringSchema) HasSuffix(suffix string) *StringSchema {
+	s.describe(CodeSuffix, suffix)
+	s.addValidator(func(v string) error {
+		if !strings.HasSuffix(v, suffix) {
+			return constraintError(CodeSuffix, map[string]any{"suffix": suffix}, "string must end with %q", suffix)
 		}
 		return nil
 	})
 	return s
 }
 
+// Alphanumeric adds a validator that checks if the string only contains ASCII
+// letters and digits and returns the schema for chaining.
+func (s *StringSchema) Alphanumeric() *StringSchema {
+	s.describe(CodeAlphanumeric, true)
+	s.addValidator(func(v string) error {
+		for _, r := range v {
+			if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+				return constraintError(CodeAlphanumeric, nil, "string must only contain letters and digits")
+			}
+		}
+		return nil
+	})
+	return s
+}
+
+// ASCII adds a validator that checks if the string only contains ASCII
+// characters and returns the schema for chaining.
+func (s *StringSchema) ASCII() *StringSchema {
+	s.describe(CodeASCII, true)
+	s.addValidator(func(v string) error {
+		for _, r := range v {
+			if r > unicode.MaxASCII {
+				return constraintError(CodeASCII, nil, "string must only contain ASCII characters")
+			}
+		}
+		return nil
+	})
+	return s
+}
+
+// NoControlChars adds a validator that checks if the string does not contain
+// control characters (e.g. NUL or escape characters) and returns the schema for
+// chaining.
+func (s *StringSchema) NoControlChars() *StringSchema {
+	s.describe(CodeControlChars, false)
+	s.addValidator(func(v string) error {
+		if strings.IndexFunc(v, unicode.IsControl) >= 0 {
+			return constraintError(CodeControlChars, nil, "string must not contain control characters")
+		}
+		return nil
+	})
+	return s
+}
+
+// Format adds a validator that checks if the string matches the named format
+// (see package formats for the available formats) and returns the schema for
+// chaining. The format is looked up in the default format registry when Format
+// is called, so custom formats must be registered before. Validating against an
+// unknown format always fails. Invalid values only fail if the profile in the
+// validation context asserts formats (see Profile).
+func (s *StringSchema) Format(name string) *StringSchema {
+	s.describe("format", name)
+	fn, ok := formats.Lookup(name)
+	s.validators = append(s.validators, func(ctx context.Context, v string) error {
+		if !ok {
+			return constraintError(CodeFormat, map[string]any{"format": name}, "unknown format %q", name)
+		}
+		return enforce(ctx, ProfileFromContext(ctx).AssertFormats, fn(v))
+	})
+	return s
+}
+
+// HexColor adds a validator that checks if the string is a hexadecimal color,
+// e.g. `#00aaff`, and returns the schema for chaining. It is equivalent to
+// Format("hex-color").
+func (s *StringSchema) HexColor() *StringSchema {
+	return s.Format("hex-color")
+}
+
+// RGBColor adds a validator that checks if the string is a color in CSS
+// functional RGB notation, e.g. `rgb(0, 170, 255)`, and returns the schema for
+// chaining. It is equivalent to Format("rgb-color").
+func (s *StringSchema) RGBColor() *StringSchema {
+	return s.Format("rgb-color")
+}
+
+// LanguageTag adds a validator that checks if the string is a well-formed BCP
+// 47 language tag, e.g. `en-US`, and returns the schema for chaining. It is
+// equivalent to Format("language-tag"), which is registered by
+// valtorlocale.RegisterFormats, so that the core doesn't depend on the
+// language data of golang.org/x/text.
+func (s *StringSchema) LanguageTag() *StringSchema {
+	return s.Format("language-tag")
+}
+
+// Locale adds a validator that checks if the string is a POSIX locale name,
+// e.g. `en_US.UTF-8`, and returns the schema for chaining. It is equivalent to
+// Format("locale"), which is registered by valtorlocale.RegisterFormats.
+func (s *StringSchema) Locale() *StringSchema {
+	return s.Format("locale")
+}
+
+// TimeZone adds a validator that checks if the string is an IANA time zone
+// name, e.g. `Europe/Amsterdam`, and returns the schema for chaining. It is
+// equivalent to Format("timezone").
+func (s *StringSchema) TimeZone() *StringSchema {
+	return s.Format("timezone")
+}
+
+// OneOf adds a validator that checks if the string is one of the allowed
+// values and returns the schema for chaining. Lookups take constant time, so
+// it is suitable for large sets of values. The error message lists the first
+// 10 allowed values, and the ConstraintError has all of them as `allowed`
+// parameter.
+func (s *StringSchema) OneOf(values ...string) *StringSchema {
+	s.describe("enum", values)
+	s.addValidator(oneOf(values))
+	return s
+}
+
 // Validate validates the string against the schema and returns an error if the string is not valid.
 func (s *StringSchema) Validate(value string) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext validates the string against the schema with the given
+// context and returns an error if the string is not valid.
+func (s *StringSchema) ValidateContext(ctx context.Context, value string) error {
 	if value == "" && s.required {
 		return ErrValueRequired
 	}
-	return s.Schema.Validate(value)
+	return s.Schema.ValidateContext(ctx, value)
 }
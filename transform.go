@@ -0,0 +1,136 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrTransformNotApplied is returned by Run for a field added with FieldOf
+// whose schema has transformations, which Run can't write back to the field.
+// Use FieldRef for such fields.
+var ErrTransformNotApplied = errors.New("transformations of field are not applied, use FieldRef")
+
+// Output is the result of Run. Besides the errors and soft failures of the
+// validation (see Result), it holds the transformed value.
+type Output[T any] struct {
+	Value T // Value after the transformations of the schema, see Schema.Transform.
+	Result
+}
+
+// transformer is implemented by schemas with transformations. Schemas that
+// embed *Schema[T], such as *StringSchema and *ObjectSchema[T], implement it
+// as well.
+type transformer[T any] interface {
+	transform(ctx context.Context, value T) T
+	hasTransforms() bool
+}
+
+// Transform adds a transformation, e.g. strings.TrimSpace or StripHTML, that
+// Run applies to values before they are validated, and returns the schema for
+// chaining. Transformations are applied in the order in which they were
+// added. Validate and ValidateContext validate values as is, without
+// transforming them.
+//
+// The transformations of nested schemas are applied as well, to the items of
+// arrays (see ArraySchema.ItemsOf), to the values of pointers (see Ptr) and to
+// fields added with FieldRef.
+func (s *Schema[T]) Transform(fn func(T) T) *Schema[T] {
+	return s.TransformContext(func(_ context.Context, value T) T {
+		return fn(value)
+	})
+}
+
+// TransformContext is like Transform, but fn receives the context passed to
+// Run, e.g. to transform values depending on the profile (see
+// ProfileFromContext).
+func (s *Schema[T]) TransformContext(fn func(ctx context.Context, value T) T) *Schema[T] {
+	s.transforms = append(s.transforms, fn)
+	return s
+}
+
+// transform applies the transformations of the schema to the value.
+func (s *Schema[T]) transform(ctx context.Context, value T) T {
+	for _, fn := range s.transforms {
+		value = fn(ctx, value)
+	}
+	return value
+}
+
+// TransformValue applies the transformations of the schema to the value, as Run
+// does, without validating it, e.g. to apply the transformations of a nested
+// schema in a transformation of its parent.
+func (s *Schema[T]) TransformValue(value T) T {
+	return s.transform(context.Background(), value)
+}
+
+// TransformValueContext is like TransformValue, but passes ctx on to the
+// transformations, e.g. in a transformation added with TransformContext.
+func (s *Schema[T]) TransformValueContext(ctx context.Context, value T) T {
+	return s.transform(ctx, value)
+}
+
+// hasTransforms reports whether the schema has transformations.
+func (s *Schema[T]) hasTransforms() bool {
+	return len(s.transforms) > 0
+}
+
+// TrimSpace adds a transformation that removes leading and trailing white
+// space (see Run), and returns the schema for chaining.
+func (s *StringSchema) TrimSpace() *StringSchema {
+	s.Transform(strings.TrimSpace)
+	return s
+}
+
+// Run applies the transformations of the schema to the value (see
+// Schema.Transform), validates the transformed value with the given context,
+// and returns an Output with the transformed value, the errors and the soft
+// failures (see ValidateResult), e.g. to store the sanitized value of a
+// request. The value is returned even if it is invalid.
+func Run[T any](ctx context.Context, schema Validator[T], value T) Output[T] {
+	if t, ok := schema.(transformer[T]); ok {
+		value = t.transform(ctx, value)
+	}
+	return Output[T]{
+		Value:  value,
+		Result: ValidateResult(context.WithValue(ctx, runKey{}, true), schema, value),
+	}
+}
+
+type runKey struct{}
+
+// FieldRef adds a field validator for the field that ref points to, like
+// FieldOf, and returns the schema for chaining. Unlike with FieldOf, Run
+// applies the transformations of the field's schema (see Schema.Transform)
+// and writes the transformed value back to the field, before the object is
+// validated.
+func FieldRef[T any, F any](s *ObjectSchema[T], fieldName string, ref func(*T) *F, schema Validator[F]) *ObjectSchema[T] {
+	validate := contextValidate(schema)
+	fieldOf(s, fieldName, schema, func(ctx context.Context, value T) error {
+		return validate(ctx, *ref(&value))
+	})
+	if t, ok := schema.(transformer[F]); ok {
+		s.TransformContext(func(ctx context.Context, value T) T {
+			if t.hasTransforms() {
+				field := ref(&value)
+				*field = t.transform(ctx, *field)
+			}
+			return value
+		})
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRunNested(t *testing.T) {
+	type user struct {
+		Name    string
+		Tags    []string
+		Nick    *string
+		Friends []user
+	}
+
+	nameSchema := String().TrimSpace().Required()
+	userSchema := Object[user]()
+	FieldRef(userSchema, "name", func(u *user) *string { return &u.Name }, nameSchema)
+	FieldRef(userSchema, "tags", func(u *user) *[]string { return &u.Tags }, Array[string]().ItemsOf(String().TrimSpace()))
+	FieldRef(userSchema, "nick", func(u *user) **string { return &u.Nick }, Ptr(String().TrimSpace()))
+	FieldRef(userSchema, "friends", func(u *user) *[]user { return &u.Friends }, Array[user]().ItemsOf(userSchema))
+
+	getterSchema := Object[user]()
+	FieldOf(getterSchema, "name", func(u user) string { return u.Name }, nameSchema)
+
+	nick := " gopher "
+	tests := []struct {
+		name    string
+		schema  Validator[user]
+		value   user
+		want    user
+		wantErr error
+	}{
+		{
+			name:   "fields",
+			schema: userSchema,
+			value:  user{Name: " Gopher ", Tags: []string{" a", "b "}, Nick: &nick},
+			want:   user{Name: "Gopher", Tags: []string{"a", "b"}, Nick: ptrTo("gopher")},
+		},
+		{
+			name:   "nested objects",
+			schema: userSchema,
+			value:  user{Name: "a", Friends: []user{{Name: " b "}}},
+			want:   user{Name: "a", Friends: []user{{Name: "b"}}},
+		},
+		{
+			name:    "transformed value is validated",
+			schema:  userSchema,
+			value:   user{Name: "a", Friends: []user{{Name: "   "}}},
+			want:    user{Name: "a", Friends: []user{{Name: ""}}},
+			wantErr: ErrValueRequired,
+		},
+		{
+			name:    "getter",
+			schema:  getterSchema,
+			value:   user{Name: "   "},
+			want:    user{Name: "   "},
+			wantErr: ErrTransformNotApplied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := Run(context.Background(), tt.schema, tt.value)
+			if !reflect.DeepEqual(out.Value, tt.want) {
+				t.Errorf("got value %+v, want %+v", out.Value, tt.want)
+			}
+			if tt.wantErr == nil && out.Err != nil || !errors.Is(out.Err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", out.Err, tt.wantErr)
+			}
+		})
+	}
+
+	if *tests[0].value.Nick != " gopher " || tests[0].value.Tags[0] != " a" {
+		t.Error("Run changed the input value")
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -14,9 +14,22 @@
 
 package valtor
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
-var ErrValueRequired = errors.New("value is required")
+var (
+	ErrValueRequired = errors.New("value is required")
+	ErrUnknownField  = errors.New("unknown field")
+	ErrUnknownSchema = errors.New("unknown schema")
+	ErrDeprecated    = errors.New("value is deprecated")
+	ErrReadOnly      = errors.New("field is read-only")
+	ErrWriteOnly     = errors.New("field is write-only")
+)
 
 // Validator is an interface for validating a value.
 // The Validate method is implemented by all validation schemas.
@@ -24,31 +37,169 @@ type Validator[T any] interface {
 	Validate(value T) error
 }
 
+// ContextValidator is an interface for validating a value with a context.
+// The ValidateContext method is implemented by all validation schemas.
+type ContextValidator[T any] interface {
+	ValidateContext(ctx context.Context, value T) error
+}
+
+// PresenceValidator is implemented by schemas that tell a missing value apart
+// from a zero value, such as NumberSchema.
+type PresenceValidator[T any] interface {
+	ValidatePresence(value T, present bool) error
+}
+
 // Schema represents a base type for all validation schemas.
-// It implements the Validator interface.
+// It implements the Validator and ContextValidator interfaces.
 type Schema[T any] struct {
-	validators []func(T) error
+	validators  []func(context.Context, T) error
+	transforms  []func(context.Context, T) T // Applied by Run, see Transform.
+	constraints []Constraint
+	limits      Limits
+	title       string
+	description string
+	config      config
 }
 
-// New creates a new validation schema for type T.
-func New[T any]() *Schema[T] {
+// New creates a new validation schema for type T, configured with the options.
+func New[T any](opts ...Option) *Schema[T] {
 	return &Schema[T]{
-		validators: make([]func(T) error, 0),
+		validators: make([]func(context.Context, T) error, 0),
+		config:     newConfig(opts),
 	}
 }
 
 // Validate runs all validators against the value and returns the first error encountered, if any.
 func (s *Schema[T]) Validate(value T) error {
+	return s.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext runs all validators against the value with the given context
+// and returns the first error encountered, if any, or all errors with
+// WithErrorCollector.
+func (s *Schema[T]) ValidateContext(ctx context.Context, value T) error {
+	ctx, c := newErrorCollector(ctx, s.config.collect, s.config.maxErrors)
 	for _, validator := range s.validators {
-		if err := validator(value); err != nil {
-			return err
+		if c.add(s.message(validator(ctx, value))) {
+			break
 		}
 	}
+	if err := c.err(); err != nil {
+		return s.annotate(err)
+	}
 	return nil
 }
 
 // Custom adds a custom validation function to the schema and returns the schema for chaining.
 func (s *Schema[T]) Custom(fn func(T) error) *Schema[T] {
-	s.validators = append(s.validators, fn)
+	s.addValidator(fn)
 	return s
 }
+
+// Rule adds a context-aware validator, such as a Rule, to the schema and
+// returns the schema for chaining.
+func (s *Schema[T]) Rule(rule ContextValidator[T]) *Schema[T] {
+	s.validators = append(s.validators, rule.ValidateContext)
+	return s
+}
+
+// Deprecated adds a rule that reports values other than the zero value of T as
+// deprecated, with the message (e.g. `use X instead`) as hint, and returns the
+// schema for chaining. The rule has SeverityWarning, so it doesn't fail
+// validation (see ValidateResult). Its error wraps ErrDeprecated.
+func (s *Schema[T]) Deprecated(message string) *Schema[T] {
+	return s.Rule(deprecationRule[T](message))
+}
+
+func deprecationRule[T any](message string) *Rule[T] {
+	return NewRule(func(value T) error {
+		if reflect.ValueOf(&value).Elem().IsZero() {
+			return nil
+		}
+		return ErrDeprecated
+	}).Hint(message).Severity(SeverityWarning)
+}
+
+// validateContext validates the value with the validator, passing the context
+// if the validator implements ContextValidator.
+func validateContext[T any](ctx context.Context, validator Validator[T], value T) error {
+	if cv, ok := validator.(ContextValidator[T]); ok {
+		return cv.ValidateContext(ctx, value)
+	}
+	return validator.Validate(value)
+}
+
+// contextValidate returns a function that validates values with the context,
+// like validateContext, but checks whether validator implements
+// ContextValidator only once, e.g. for the validator of a field or of items.
+func contextValidate[T any](validator Validator[T]) func(context.Context, T) error {
+	if cv, ok := validator.(ContextValidator[T]); ok {
+		return cv.ValidateContext
+	}
+	return func(_ context.Context, value T) error {
+		return validator.Validate(value)
+	}
+}
+
+// addValidator adds a validator that does not depend on the context.
+func (s *Schema[T]) addValidator(fn func(T) error) {
+	s.validators = append(s.validators, func(_ context.Context, value T) error {
+		return fn(value)
+	})
+}
+
+// maxListedValues is the maximum number of allowed values that are listed in
+// the error message of a OneOf validator.
+const maxListedValues = 10
+
+// listValues formats values for an error message, e.g. `"a", "b", "c"`. See
+// ListValues.
+func listValues[T any](values []T) string {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = fmt.Sprintf("%#v", v)
+	}
+	return ListValues(formatted)
+}
+
+// ListValues joins formatted values for an error message, as OneOf does, e.g.
+// `"a", "b", "c"`. After 10 values, the list is truncated, e.g. `1, 2, ...
+// (and 5 more)`.
+func ListValues(formatted []string) string {
+	listed := formatted[:min(len(formatted), maxListedValues)]
+	list := strings.Join(listed, ", ")
+	if len(listed) < len(formatted) {
+		list += fmt.Sprintf(", ... (and %d more)", len(formatted)-len(listed))
+	}
+	return list
+}
+
+// anyValues returns values as a []any, for the parameters of an error.
+func anyValues[T any](values []T) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
+// oneOf creates a validator that checks if a value is one of the allowed
+// values. The values are put in a set once, so that validation takes constant
+// time regardless of the number of allowed values. The error lists the
+// allowed values and has them as `allowed` parameter.
+func oneOf[T comparable](values []T) func(T) error {
+	allowed := make(map[T]struct{}, len(values))
+	for _, v := range values {
+		allowed[v] = struct{}{}
+	}
+
+	message := "value must be one of " + listValues(values)
+	params := anyValues(values)
+
+	return func(v T) error {
+		if _, ok := allowed[v]; !ok {
+			return constraintError(CodeOneOf, map[string]any{"allowed": params, "actual": v}, "%s", message)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorcel compiles CEL expressions (see https://cel.dev), e.g.
+// `self.end > self.start`, into valtor rules, so that business rules can be
+// stored in configuration instead of compiled into Go code.
+//
+// The validated value is available as `self`. Fields of structs are accessed
+// by their JSON names, or else by their Go names, and time.Time and
+// time.Duration values are CEL timestamps and durations.
+//
+// The package is a module of its own, so that only programs that use it
+// depend on cel-go.
+package valtorcel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+var ErrInvalidExpression = errors.New("invalid CEL expression")
+
+// Rule is a CEL expression with the message of its error, e.g.
+// `{"rule": "self.end > self.start", "message": "end must be after start"}`.
+type Rule = valtor.ExpressionRule
+
+type config struct {
+	envOpts   []cel.EnvOption
+	costLimit uint64
+}
+
+// Option configures how expressions are compiled.
+type Option func(*config)
+
+// WithEnv adds options to the CEL environment of expressions, e.g. to declare
+// custom functions or to enable extension libraries such as ext.Strings.
+func WithEnv(opts ...cel.EnvOption) Option {
+	return func(cfg *config) {
+		cfg.envOpts = append(cfg.envOpts, opts...)
+	}
+}
+
+// WithCostLimit limits the cost of evaluating an expression, e.g. for
+// expressions from an untrusted source. Evaluations that exceed the limit
+// fail.
+func WithCostLimit(limit uint64) Option {
+	return func(cfg *config) {
+		cfg.costLimit = limit
+	}
+}
+
+// Compile compiles a rule into a valtor rule for values of type T, e.g. to be
+// added to an object schema with Rule to check multiple fields at once. It
+// fails with ErrInvalidExpression if the expression doesn't parse, doesn't
+// type-check against T, or doesn't evaluate to a bool. When the expression
+// evaluates to false, the rule fails with rule.Failure(). Evaluation is
+// canceled with the context of ValidateContext.
+func Compile[T any](rule Rule, opts ...Option) (*valtor.Rule[T], error) {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	env, err := cel.NewEnv(append(selfOptions(reflect.TypeFor[T]()), cfg.envOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, issues := env.Compile(rule.Expr)
+	if err := issues.Err(); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidExpression, rule.Expr, err)
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("%w %q: evaluates to %v instead of bool", ErrInvalidExpression, rule.Expr, t)
+	}
+
+	progOpts := []cel.ProgramOption{cel.InterruptCheckFrequency(100)}
+	if cfg.costLimit > 0 {
+		progOpts = append(progOpts, cel.CostLimit(cfg.costLimit))
+	}
+	prg, err := env.Program(ast, progOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidExpression, rule.Expr, err)
+	}
+
+	return valtor.NewContextRule(func(ctx context.Context, value T) error {
+		out, _, err := prg.ContextEval(ctx, map[string]any{"self": value})
+		if err != nil {
+			return fmt.Errorf("failed to evaluate %q: %w", rule.Expr, err)
+		}
+		ok, isBool := out.Value().(bool)
+		if !isBool {
+			return fmt.Errorf("failed to evaluate %q: got %v instead of bool", rule.Expr, out.Type())
+		}
+		if !ok {
+			return rule.Failure()
+		}
+		return nil
+	}), nil
+}
+
+// MustCompile is like Compile, but panics if the rule can't be compiled.
+func MustCompile[T any](rule Rule, opts ...Option) *valtor.Rule[T] {
+	r, err := Compile[T](rule, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// selfOptions returns the options that declare `self` as a value of type t.
+// Structs (and pointers to them) are declared with their fields, so that
+// expressions are type-checked. Values of other types are dynamic.
+func selfOptions(t reflect.Type) []cel.EnvOption {
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct || st.Name() == "" {
+		return []cel.EnvOption{cel.Variable("self", cel.DynType)}
+	}
+
+	pkg := st.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return []cel.EnvOption{
+		ext.NativeTypes(t, ext.ParseStructTags(true), ext.ParseStructTag("json")),
+		cel.Variable("self", cel.ObjectType(pkg+"."+st.Name())),
+	}
+}
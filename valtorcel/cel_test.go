@@ -0,0 +1,250 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorcel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/valtor"
+	"github.com/google/cel-go/ext"
+)
+
+type booking struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Guests int       `json:"guests,omitempty"`
+	Rooms  []string
+}
+
+func TestCompile(t *testing.T) {
+	start := time.Date(2025, 6, 1, 14, 0, 0, 0, time.UTC)
+	valid := booking{Start: start, End: start.Add(24 * time.Hour), Guests: 2, Rooms: []string{"101"}}
+	invalid := booking{Start: start, End: start, Guests: 0}
+
+	tests := []struct {
+		name    string
+		rule    Rule
+		opts    []Option
+		valid   booking
+		invalid booking
+		wantMsg string
+	}{
+		{
+			name:    "timestamps",
+			rule:    Rule{Expr: "self.end > self.start"},
+			valid:   valid,
+			invalid: invalid,
+			wantMsg: "value must satisfy self.end > self.start",
+		},
+		{
+			name:    "duration",
+			rule:    Rule{Expr: "self.end - self.start >= duration('24h')", Message: "must be at least one night"},
+			valid:   valid,
+			invalid: invalid,
+			wantMsg: "must be at least one night",
+		},
+		{
+			name:    "Go field name",
+			rule:    Rule{Expr: "size(self.Rooms) > 0 && self.guests <= size(self.Rooms) * 4"},
+			valid:   valid,
+			invalid: invalid,
+			wantMsg: "value must satisfy size(self.Rooms) > 0 && self.guests <= size(self.Rooms) * 4",
+		},
+		{
+			name:    "environment options",
+			rule:    Rule{Expr: "self.Rooms.all(r, r.lowerAscii() == r)"},
+			opts:    []Option{WithEnv(ext.Strings())},
+			valid:   valid,
+			invalid: booking{Rooms: []string{"A1"}},
+			wantMsg: "value must satisfy self.Rooms.all(r, r.lowerAscii() == r)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Compile[booking](tt.rule, tt.opts...)
+			if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+			if err := rule.Validate(tt.valid); err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+
+			err = rule.Validate(tt.invalid)
+			var constraintErr *valtor.ConstraintError
+			if !errors.As(err, &constraintErr) {
+				t.Fatalf("expected *valtor.ConstraintError, got %v", err)
+			}
+			if constraintErr.Code != valtor.CodeExpression {
+				t.Errorf("expected code %q, got %q", valtor.CodeExpression, constraintErr.Code)
+			}
+			if constraintErr.Params["expression"] != tt.rule.Expr {
+				t.Errorf("expected expression %q, got %v", tt.rule.Expr, constraintErr.Params["expression"])
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("expected message %q, got %q", tt.wantMsg, err)
+			}
+		})
+	}
+}
+
+func TestCompileDynamic(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		value   any
+		wantErr bool
+	}{
+		{name: "map valid", expr: "self.max >= self.min", value: map[string]any{"min": 1, "max": 2}},
+		{name: "map invalid", expr: "self.max >= self.min", value: map[string]any{"min": 3, "max": 2}, wantErr: true},
+		{name: "map missing key", expr: "self.max >= self.min", value: map[string]any{"min": 3}, wantErr: true},
+		{name: "string valid", expr: "self.startsWith('SKU-')", value: "SKU-1"},
+		{name: "string invalid", expr: "self.startsWith('SKU-')", value: "1", wantErr: true},
+		{name: "not a bool", expr: "self", value: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := MustCompile[any](Rule{Expr: tt.expr})
+			err := rule.Validate(tt.value)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "syntax", expr: "self.end >"},
+		{name: "unknown field", expr: "self.finish > self.start"},
+		{name: "type mismatch", expr: "self.guests > self.start"},
+		{name: "not a bool", expr: "self.guests"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile[booking](Rule{Expr: tt.expr})
+			if !errors.Is(err, ErrInvalidExpression) {
+				t.Errorf("expected error %q, got %v", ErrInvalidExpression, err)
+			}
+		})
+	}
+}
+
+func TestCompileObjectSchema(t *testing.T) {
+	schema := valtor.Object[*booking]()
+	schema.Field("guests", func(b *booking) error {
+		return valtor.Number[int]().Min(1).Validate(b.Guests)
+	})
+	schema.Rule(MustCompile[*booking](Rule{Expr: "self.end > self.start", Message: "end must be after start"}))
+
+	start := time.Date(2025, 6, 1, 14, 0, 0, 0, time.UTC)
+	if err := schema.Validate(&booking{Start: start, End: start.Add(time.Hour), Guests: 1}); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	err := schema.Validate(&booking{Start: start, End: start, Guests: 1})
+	if err == nil || err.Error() != "end must be after start" {
+		t.Errorf("expected error %q, got %v", "end must be after start", err)
+	}
+}
+
+func TestCompileCostLimit(t *testing.T) {
+	rule := MustCompile[any](Rule{Expr: "self.all(x, self.all(y, x != y || x == y))"}, WithCostLimit(100))
+	values := make([]any, 100)
+	for i := range values {
+		values[i] = i
+	}
+	if err := rule.ValidateContext(context.Background(), values); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestPackCostLimit(t *testing.T) {
+	params := json.RawMessage(`"self.all(x, self.all(y, x != y || x == y))"`)
+	values := make([]any, 500)
+	for i := range values {
+		values[i] = json.Number("1")
+	}
+
+	for _, pack := range []Pack{{}, {CostLimit: 100}} {
+		validateFn, err := pack.Extensions()[0].Parse(params)
+		if err != nil {
+			t.Fatalf("failed to parse params: %v", err)
+		}
+		err = validateFn(values)
+		if err == nil || errors.As(err, new(*valtor.ConstraintError)) {
+			t.Errorf("expected evaluation error with cost limit %d, got %v", pack.CostLimit, err)
+		}
+		if err := validateFn(values[:2]); err != nil {
+			t.Errorf("expected no error for small list with cost limit %d, got %q", pack.CostLimit, err)
+		}
+	}
+}
+
+func TestPack(t *testing.T) {
+	registry := valtor.NewExtensionRegistry()
+	if err := registry.Use(Pack{}); err != nil {
+		t.Fatalf("failed to register pack: %v", err)
+	}
+	ext, ok := registry.Lookup("x-cel")
+	if !ok {
+		t.Fatal("expected extension x-cel to be registered")
+	}
+
+	tests := []struct {
+		name     string
+		params   string
+		value    any
+		wantErr  string
+		parseErr bool
+	}{
+		{name: "expression", params: `"self.max >= self.min"`, value: map[string]any{"min": json.Number("1"), "max": json.Number("2")}},
+		{name: "numbers", params: `"self.max >= self.min"`, value: map[string]any{"min": json.Number("9"), "max": json.Number("10.5")}},
+		{name: "expression invalid", params: `"self.max >= self.min"`, value: map[string]any{"min": json.Number("2"), "max": json.Number("1")}, wantErr: "value must satisfy self.max >= self.min"},
+		{name: "rule", params: `{"rule": "size(self) <= 2", "message": "too many tags"}`, value: []any{"a", "b", "c"}, wantErr: "too many tags"},
+		{name: "invalid expression", params: `"self >"`, parseErr: true},
+		{name: "invalid params", params: `42`, parseErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateFn, err := ext.Parse(json.RawMessage(tt.params))
+			if (err != nil) != tt.parseErr {
+				t.Fatalf("expected parse error: %v, got %v", tt.parseErr, err)
+			}
+			if err != nil {
+				return
+			}
+			err = validateFn(tt.value)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorcel
+
+import (
+	"encoding/json"
+
+	"github.com/dstotijn/valtor"
+)
+
+// Pack adds the `x-cel` extension, which checks values of JSON documents, e.g.
+// against `"self.max >= self.min"`, once registered with valtor.Use. Its
+// parameters are a Rule or a CEL expression. JSON numbers are converted to CEL
+// ints or doubles.
+//
+// As expressions in schema documents may come from an untrusted source, their
+// evaluation cost is limited (see WithCostLimit).
+type Pack struct {
+	// CostLimit is the cost limit of expressions. Defaults to
+	// DefaultCostLimit.
+	CostLimit uint64
+}
+
+// DefaultCostLimit is the default cost limit of the expressions of Pack. It
+// allows e.g. a comparison of all pairs of a list of 300 items.
+const DefaultCostLimit = 1_000_000
+
+func (p Pack) Extensions() []valtor.Extension {
+	costLimit := p.CostLimit
+	if costLimit == 0 {
+		costLimit = DefaultCostLimit
+	}
+
+	return []valtor.Extension{{
+		Name: "x-cel",
+		Parse: func(params json.RawMessage) (func(any) error, error) {
+			var rule Rule
+			if err := json.Unmarshal(params, &rule); err != nil {
+				return nil, err
+			}
+			r, err := Compile[any](rule, WithCostLimit(costLimit))
+			if err != nil {
+				return nil, err
+			}
+			return func(value any) error {
+				return r.Validate(fromJSON(value))
+			}, nil
+		},
+	}}
+}
+
+// fromJSON converts the json.Number values of a decoded JSON value to int64
+// or float64, so that they are numbers in CEL rather than strings.
+func fromJSON(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = fromJSON(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, e := range v {
+			s[i] = fromJSON(e)
+		}
+		return s
+	default:
+		return value
+	}
+}
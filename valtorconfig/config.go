@@ -0,0 +1,341 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorconfig builds validation schemas at runtime from a declarative
+// validation config in YAML or JSON, which lists the rules of each field, e.g.:
+//
+//	fields:
+//	  name:
+//	    type: string
+//	    rules:
+//	      - required
+//	      - min_length: 2
+//	  age:
+//	    type: integer
+//	    rules:
+//	      - min: 18
+//	  tags:
+//	    type: array
+//	    items:
+//	      type: string
+//	    rules:
+//	      - unique
+//
+// It is a lighter-weight alternative to JSON Schema for configs that are
+// edited by hand. The built-in rules are named after the codes of the
+// constraints they check (see valtor.Codes): `required`, `min_length`,
+// `max_length` and `length` of strings and arrays, `min` and `max` of
+// numbers, `pattern`, `format` and `one_of` (with a list of allowed values),
+// `unique` of arrays, and `min_properties` and `max_properties` of objects.
+// Other rule names are looked up in an extension registry, e.g. `x-phone` of
+// package valtorphone, or `x-expr` of package valtorexpr for rules that
+// compare fields.
+//
+// The package is a module of its own, so that the YAML decoder is only a
+// dependency of programs that read configs.
+package valtorconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+	"github.com/dstotijn/valtor/valtorjsonschema"
+	"github.com/invopop/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid validation config")
+	ErrUnknownRule   = errors.New("unknown rule")
+)
+
+// Field is the configuration of a field, or of the validated value itself.
+type Field struct {
+	Type        string           `json:"type,omitempty"` // `string`, `integer`, `number`, `boolean`, `object` or `array`, or empty for any type.
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Rules       []Rule           `json:"rules,omitempty"`
+	Fields      map[string]Field `json:"fields,omitempty"` // Fields of an object.
+	Items       *Field           `json:"items,omitempty"`  // Items of an array.
+}
+
+// Rule is a named rule with its parameters, e.g. `{"min_length": 2}`. Rules
+// without parameters, such as `required`, can be written as a string, which is
+// the same as passing `true` as parameters.
+type Rule struct {
+	Name   string
+	Params json.RawMessage
+}
+
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*r = Rule{Name: name, Params: json.RawMessage("true")}
+		return nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil || len(m) != 1 {
+		return fmt.Errorf("expected rule name or object with a single rule, got %s", data)
+	}
+	for name, params := range m {
+		*r = Rule{Name: name, Params: params}
+	}
+	return nil
+}
+
+func (r Rule) MarshalJSON() ([]byte, error) {
+	if bytes.Equal(r.Params, []byte("true")) {
+		return json.Marshal(r.Name)
+	}
+	return json.Marshal(map[string]json.RawMessage{r.Name: r.Params})
+}
+
+type config struct {
+	extensions *valtor.ExtensionRegistry
+}
+
+// Option configures how a validation config is built.
+type Option func(*config)
+
+// WithExtensions sets the registry used to look up rules that are not
+// built-in. Defaults to valtor.DefaultExtensions.
+func WithExtensions(registry *valtor.ExtensionRegistry) Option {
+	return func(cfg *config) {
+		cfg.extensions = registry
+	}
+}
+
+// Parse parses a validation config in YAML or JSON and builds a validation
+// schema for type T, typically any for decoded JSON values. See Build.
+func Parse[T any](data []byte, opts ...Option) (*valtor.Schema[T], error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	// Decode via JSON, so that the field and rule types only need to support
+	// one encoding.
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var field Field
+	if err := dec.Decode(&field); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return Build[T](field, opts...)
+}
+
+// Build builds a validation schema for type T from the configuration of the
+// validated value. Fields are validated in sorted order. It fails with
+// ErrUnknownRule if a rule is neither built-in nor registered as an extension,
+// and with ErrInvalidConfig if a rule has invalid parameters or doesn't apply
+// to the type of its field.
+func Build[T any](field Field, opts ...Option) (*valtor.Schema[T], error) {
+	cfg := &config{extensions: valtor.DefaultExtensions}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	schema, required, err := cfg.jsonSchema(field, "")
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return nil, requiredError("")
+	}
+	return valtorjsonschema.ParseJSONSchema[T](*schema,
+		valtorjsonschema.WithExtensions(cfg.extensions),
+		valtorjsonschema.WithStrict(),
+	)
+}
+
+// jsonSchema converts the configuration of the field at path, e.g.
+// `address.zip`, to a JSON Schema, and reports whether the field is required.
+func (cfg *config) jsonSchema(field Field, path string) (*jsonschema.Schema, bool, error) {
+	schema := &jsonschema.Schema{
+		Type:        field.Type,
+		Title:       field.Title,
+		Description: field.Description,
+	}
+
+	if len(field.Fields) > 0 {
+		if schema.Type == "" {
+			schema.Type = "object"
+		}
+		if schema.Type != "object" {
+			return nil, false, fmt.Errorf("%w: field %q of type %s has fields", ErrInvalidConfig, path, schema.Type)
+		}
+		schema.Properties = jsonschema.NewProperties()
+		for _, name := range slices.Sorted(maps.Keys(field.Fields)) {
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			fieldSchema, required, err := cfg.jsonSchema(field.Fields[name], fieldPath)
+			if err != nil {
+				return nil, false, err
+			}
+			schema.Properties.Set(name, fieldSchema)
+			if required {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+
+	if field.Items != nil {
+		if schema.Type == "" {
+			schema.Type = "array"
+		}
+		if schema.Type != "array" {
+			return nil, false, fmt.Errorf("%w: field %q of type %s has items", ErrInvalidConfig, path, schema.Type)
+		}
+		items, required, err := cfg.jsonSchema(*field.Items, path+"[]")
+		if err != nil {
+			return nil, false, err
+		}
+		if required {
+			return nil, false, requiredError(path + "[]")
+		}
+		schema.Items = items
+	}
+
+	var required bool
+	for _, rule := range field.Rules {
+		if rule.Name == valtor.CodeRequired {
+			if err := json.Unmarshal(rule.Params, &required); err != nil {
+				return nil, false, ruleError(rule, path, err)
+			}
+			continue
+		}
+		if err := cfg.applyRule(schema, rule, path); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if schema.Type == "" {
+		// Fields of any type are a union of all types, as valtorjsonschema
+		// requires a type.
+		for _, typ := range []string{"string", "number", "boolean", "object", "array", "null"} {
+			schema.AnyOf = append(schema.AnyOf, &jsonschema.Schema{Type: typ})
+		}
+	}
+	return schema, required, nil
+}
+
+// applyRule sets the JSON Schema keywords of a rule.
+func (cfg *config) applyRule(schema *jsonschema.Schema, rule Rule, path string) error {
+	var err error
+	switch rule.Name {
+	case valtor.CodeMinLength, valtor.CodeMaxLength, valtor.CodeLength:
+		var n uint64
+		if err = json.Unmarshal(rule.Params, &n); err != nil {
+			break
+		}
+		minLength, maxLength := &schema.MinLength, &schema.MaxLength
+		switch schema.Type {
+		case "array":
+			minLength, maxLength = &schema.MinItems, &schema.MaxItems
+		case "string":
+		default:
+			return typeError(rule, path, "string", "array")
+		}
+		if rule.Name != valtor.CodeMaxLength {
+			*minLength = &n
+		}
+		if rule.Name != valtor.CodeMinLength {
+			*maxLength = &n
+		}
+	case valtor.CodeMin, valtor.CodeMax:
+		if schema.Type != "number" && schema.Type != "integer" {
+			return typeError(rule, path, "number", "integer")
+		}
+		var n json.Number
+		if err = json.Unmarshal(rule.Params, &n); err != nil {
+			break
+		}
+		if rule.Name == valtor.CodeMin {
+			schema.Minimum = n
+		} else {
+			schema.Maximum = n
+		}
+	case valtor.CodePattern:
+		if schema.Type != "string" {
+			return typeError(rule, path, "string")
+		}
+		err = json.Unmarshal(rule.Params, &schema.Pattern)
+	case valtor.CodeFormat:
+		err = json.Unmarshal(rule.Params, &schema.Format)
+	case valtor.CodeOneOf:
+		dec := json.NewDecoder(bytes.NewReader(rule.Params))
+		dec.UseNumber()
+		if err = dec.Decode(&schema.Enum); err == nil && len(schema.Enum) == 0 {
+			err = errors.New("expected non-empty list of allowed values")
+		}
+	case valtor.CodeUnique:
+		if schema.Type != "array" {
+			return typeError(rule, path, "array")
+		}
+		err = json.Unmarshal(rule.Params, &schema.UniqueItems)
+	case valtor.CodeMinProperties, valtor.CodeMaxProperties:
+		if schema.Type != "object" {
+			return typeError(rule, path, "object")
+		}
+		var n uint64
+		if err = json.Unmarshal(rule.Params, &n); err != nil {
+			break
+		}
+		if rule.Name == valtor.CodeMinProperties {
+			schema.MinProperties = &n
+		} else {
+			schema.MaxProperties = &n
+		}
+	default:
+		if _, ok := cfg.extensions.Lookup(rule.Name); !ok {
+			return fmt.Errorf("%w %q of field %q", ErrUnknownRule, rule.Name, path)
+		}
+		var params any
+		if err = json.Unmarshal(rule.Params, &params); err != nil {
+			break
+		}
+		if schema.Extras == nil {
+			schema.Extras = make(map[string]any)
+		}
+		schema.Extras[rule.Name] = params
+	}
+	if err != nil {
+		return ruleError(rule, path, err)
+	}
+	return nil
+}
+
+func ruleError(rule Rule, path string, err error) error {
+	return fmt.Errorf("%w: invalid parameters of rule %q of field %q: %w", ErrInvalidConfig, rule.Name, path, err)
+}
+
+// requiredError is returned for the rule `required` of the validated value or
+// of array items, which only applies to the fields of objects.
+func requiredError(path string) error {
+	return fmt.Errorf("%w: rule %q of field %q only applies to fields of objects", ErrInvalidConfig, valtor.CodeRequired, path)
+}
+
+func typeError(rule Rule, path string, types ...string) error {
+	return fmt.Errorf("%w: rule %q of field %q requires type %s", ErrInvalidConfig, rule.Name, path, strings.Join(types, " or "))
+}
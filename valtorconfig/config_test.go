@@ -0,0 +1,273 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+	"github.com/dstotijn/valtor/valtorexpr"
+)
+
+const signupConfig = `
+fields:
+  name:
+    type: string
+    description: Display name
+    rules:
+      - required
+      - min_length: 2
+      - max_length: 20
+  age:
+    type: integer
+    rules:
+      - min: 18
+      - max: 130
+  plan:
+    rules:
+      - one_of: [free, pro]
+  email:
+    type: string
+    rules:
+      - required
+      - format: email
+  tags:
+    type: array
+    items:
+      type: string
+      rules:
+        - pattern: "^[a-z]+$"
+    rules:
+      - max_length: 2
+      - unique
+  limits:
+    fields:
+      min:
+        type: integer
+      max:
+        type: integer
+    rules:
+      - x-expr: "max >= min"
+`
+
+func TestParse(t *testing.T) {
+	extensions := valtor.NewExtensionRegistry()
+	if err := extensions.Use(valtorexpr.Pack{}); err != nil {
+		t.Fatalf("failed to register pack: %v", err)
+	}
+	schema, err := Parse[any]([]byte(signupConfig), WithExtensions(extensions))
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			value: `{"name": "Gopher", "age": 30, "plan": "pro", "email": "gopher@example.com", "tags": ["go", "dev"], "limits": {"min": 1, "max": 2}}`,
+		},
+		{
+			name:  "optional fields absent",
+			value: `{"name": "Gopher", "email": "gopher@example.com"}`,
+		},
+		{
+			name:    "required",
+			value:   `{"name": "Gopher"}`,
+			wantErr: `validation failed for field "email": value is required`,
+		},
+		{
+			name:    "min length",
+			value:   `{"name": "G", "email": "gopher@example.com"}`,
+			wantErr: `validation failed for field "name": length must be at least 2, got 1`,
+		},
+		{
+			name:    "min",
+			value:   `{"name": "Gopher", "email": "gopher@example.com", "age": 17}`,
+			wantErr: `validation failed for field "age": value must be at least 18`,
+		},
+		{
+			name:    "integer",
+			value:   `{"name": "Gopher", "email": "gopher@example.com", "age": 17.5}`,
+			wantErr: `validation failed for field "age"`,
+		},
+		{
+			name:    "one of",
+			value:   `{"name": "Gopher", "email": "gopher@example.com", "plan": "gold"}`,
+			wantErr: `validation failed for field "plan"`,
+		},
+		{
+			name:    "format",
+			value:   `{"name": "Gopher", "email": "gopher"}`,
+			wantErr: `validation failed for field "email"`,
+		},
+		{
+			name:    "items",
+			value:   `{"name": "Gopher", "email": "gopher@example.com", "tags": ["Go"]}`,
+			wantErr: `validation failed for field "tags": invalid item at index 0`,
+		},
+		{
+			name:    "max items",
+			value:   `{"name": "Gopher", "email": "gopher@example.com", "tags": ["a", "b", "c"]}`,
+			wantErr: `validation failed for field "tags"`,
+		},
+		{
+			name:    "unique",
+			value:   `{"name": "Gopher", "email": "gopher@example.com", "tags": ["a", "a"]}`,
+			wantErr: `validation failed for field "tags"`,
+		},
+		{
+			name:    "extension",
+			value:   `{"name": "Gopher", "email": "gopher@example.com", "limits": {"min": 2, "max": 1}}`,
+			wantErr: `validation failed for field "limits": value must satisfy max >= min`,
+		},
+		{
+			name:    "type",
+			value:   `{"name": 42, "email": "gopher@example.com"}`,
+			wantErr: `validation failed for field "name"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := json.NewDecoder(strings.NewReader(tt.value))
+			dec.UseNumber()
+			var value any
+			if err := dec.Decode(&value); err != nil {
+				t.Fatalf("failed to decode value: %v", err)
+			}
+
+			err := schema.Validate(value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %q", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("expected error starting with %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	schema, err := Parse[any]([]byte(`{"type": "string", "rules": [{"pattern": "^[A-Z]+$"}, {"length": 3}]}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+	if err := schema.Validate("EUR"); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := schema.Validate("EURO"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "invalid YAML",
+			config:  "fields: [",
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown key",
+			config:  "fields:\n  name:\n    rule: [required]",
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown rule",
+			config:  "fields:\n  name:\n    rules: [x-unknown]",
+			wantErr: ErrUnknownRule,
+			wantMsg: `unknown rule "x-unknown" of field "name"`,
+		},
+		{
+			name:    "invalid rule",
+			config:  "fields:\n  name:\n    rules: [{min_length: 1, max_length: 2}]",
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "invalid parameters",
+			config:  "fields:\n  name:\n    type: string\n    rules: [{min_length: two}]",
+			wantErr: ErrInvalidConfig,
+			wantMsg: `invalid validation config: invalid parameters of rule "min_length" of field "name"`,
+		},
+		{
+			name:    "rule for other type",
+			config:  "fields:\n  address:\n    fields:\n      zip:\n        type: integer\n        rules: [{pattern: '^[0-9]+$'}]",
+			wantErr: ErrInvalidConfig,
+			wantMsg: `invalid validation config: rule "pattern" of field "address.zip" requires type string`,
+		},
+		{
+			name:    "fields of other type",
+			config:  "type: string\nfields:\n  name: {}",
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "required root",
+			config:  "rules: [required]\nfields:\n  name: {}",
+			wantErr: ErrInvalidConfig,
+			wantMsg: `invalid validation config: rule "required" of field "" only applies to fields of objects`,
+		},
+		{
+			name:    "required items",
+			config:  "fields:\n  tags:\n    items:\n      type: string\n      rules: [required]",
+			wantErr: ErrInvalidConfig,
+			wantMsg: `invalid validation config: rule "required" of field "tags[]" only applies to fields of objects`,
+		},
+		{
+			name:    "empty one of",
+			config:  "rules: [{one_of: []}]",
+			wantErr: ErrInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse[any]([]byte(tt.config))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+			if tt.wantMsg != "" && !strings.HasPrefix(err.Error(), tt.wantMsg) {
+				t.Errorf("expected error starting with %q, got %q", tt.wantMsg, err)
+			}
+		})
+	}
+}
+
+func TestRuleJSON(t *testing.T) {
+	var rules []Rule
+	if err := json.Unmarshal([]byte(`["required", {"min_length": 2}]`), &rules); err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+	if got, want := string(data), `["required",{"min_length":2}]`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
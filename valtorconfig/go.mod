@@ -0,0 +1,16 @@
+module github.com/dstotijn/valtor/valtorconfig
+
+go 1.24.0
+
+require (
+	github.com/dstotijn/valtor v0.0.0-20261018044750-95b85c532fb9
+	github.com/invopop/jsonschema v0.13.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/bahlo/generic-list-go v0.2.0 // indirect
+	github.com/buger/jsonparser v1.1.1 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
+	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
+)
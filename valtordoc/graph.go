@@ -0,0 +1,207 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtordoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+// WriteMermaid writes the object graph of a schema to w as a Mermaid class
+// diagram. Every object, i.e. every description with fields, is rendered as a
+// class with a member per field, e.g. `email: string, required`, and nested
+// objects are connected to the object of their field. Items of arrays are
+// connected with a `*` cardinality, and each variant of a union (see
+// valtor.WhenType) gets its own connection. Objects with the same description
+// are rendered once, so that a schema that is reused for multiple fields is
+// rendered as a single class with multiple connections.
+//
+// The name, converted to a Go name, is the name of the class of the schema
+// itself. Nested classes are named after their field, e.g. `ShippingAddress`
+// for `shipping_address`, with a numeric suffix if that name is taken.
+func WriteMermaid(w io.Writer, name string, schema valtor.Describer) error {
+	g := newGraph(name, schema.Describe())
+	var sb strings.Builder
+	sb.WriteString("classDiagram\n")
+	for _, n := range g.nodes {
+		if len(n.d.Fields) == 0 {
+			fmt.Fprintf(&sb, "  class %s\n", n.name)
+			continue
+		}
+		fmt.Fprintf(&sb, "  class %s {\n", n.name)
+		for _, field := range n.d.Fields {
+			fmt.Fprintf(&sb, "    %s\n", escapeMermaid(field.Name+": "+field.Description.String()))
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, e := range g.edges {
+		cardinality := ""
+		if e.many {
+			cardinality = `"*" `
+		}
+		label := ""
+		if e.label != "" {
+			label = " : " + escapeMermaid(e.label)
+		}
+		fmt.Fprintf(&sb, "  %s --> %s%s%s\n", e.from, cardinality, e.to, label)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteDOT writes the object graph of a schema to w as a Graphviz DOT digraph,
+// with a record node per object and an edge per nested object, labeled with
+// the name of the field, or with the name followed by `[]` for items of arrays.
+// See WriteMermaid for how objects are found and named.
+func WriteDOT(w io.Writer, name string, schema valtor.Describer) error {
+	g := newGraph(name, schema.Describe())
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", g.nodes[0].name)
+	sb.WriteString("  node [shape=record];\n")
+	for _, n := range g.nodes {
+		var fields strings.Builder
+		for _, field := range n.d.Fields {
+			fields.WriteString(escapeRecord(field.Name+": "+field.Description.String()) + `\l`)
+		}
+		label := n.name
+		if fields.Len() > 0 {
+			label = "{" + n.name + "|" + fields.String() + "}"
+		}
+		fmt.Fprintf(&sb, "  %s [label=%s];\n", n.name, quoteDOT(label))
+	}
+	for _, e := range g.edges {
+		label := e.label
+		if e.many {
+			label += "[]"
+		}
+		if label == "" {
+			fmt.Fprintf(&sb, "  %s -> %s;\n", e.from, e.to)
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s -> %s [label=%s];\n", e.from, e.to, quoteDOT(label))
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// graph is the object graph of a schema, with the schema itself as first node.
+type graph struct {
+	nodes []*node
+	edges []edge
+	byKey map[string]*node
+	names map[string]bool
+}
+
+type node struct {
+	name string
+	d    valtor.Description
+}
+
+type edge struct {
+	from, to string
+	label    string
+	many     bool
+}
+
+func newGraph(name string, d valtor.Description) *graph {
+	g := &graph{
+		byKey: make(map[string]*node),
+		names: make(map[string]bool),
+	}
+	root, _ := g.node(goName(name), d)
+	g.walkNested(root, d)
+	return g
+}
+
+// node returns the node of the description, which is added with the given
+// name, or with a numeric suffix if the name is taken, unless a node with the
+// same description exists.
+func (g *graph) node(name string, d valtor.Description) (*node, bool) {
+	b, _ := json.Marshal(d)
+	if n, ok := g.byKey[string(b)]; ok {
+		return n, false
+	}
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	n := &node{name: unique, d: d}
+	g.nodes = append(g.nodes, n)
+	g.byKey[string(b)] = n
+	g.names[unique] = true
+	return n, true
+}
+
+// walk adds the objects of a field, array item or variant of from, with an
+// edge from from to each of them. Objects are named after the label, or get
+// the given name if the label is empty.
+func (g *graph) walk(from *node, label, name string, many bool, d valtor.Description) {
+	if len(d.Fields) == 0 {
+		if d.Items != nil {
+			g.walk(from, label, name, true, *d.Items)
+		}
+		for _, variant := range d.Variants {
+			g.walk(from, label, name, many, variant)
+		}
+		return
+	}
+	if label != "" {
+		name = goName(label)
+	}
+	n, added := g.node(name, d)
+	g.edges = append(g.edges, edge{from: from.name, to: n.name, label: label, many: many})
+	if added {
+		g.walkNested(n, d)
+	}
+}
+
+func (g *graph) walkNested(n *node, d valtor.Description) {
+	for _, field := range d.Fields {
+		g.walk(n, field.Name, "", false, field.Description)
+	}
+	if len(d.Fields) > 0 {
+		return
+	}
+	if d.Items != nil {
+		g.walk(n, "", n.name+"Item", true, *d.Items)
+	}
+	for _, variant := range d.Variants {
+		g.walk(n, "", n.name+"Variant", false, variant)
+	}
+}
+
+// escapeMermaid replaces the characters that delimit class bodies in Mermaid
+// with entity codes.
+func escapeMermaid(s string) string {
+	return strings.NewReplacer("{", "#123;", "}", "#125;").Replace(s)
+}
+
+// quoteDOT returns s as a quoted DOT string. Unlike Go strings, DOT strings
+// only escape double quotes, so that escape sequences such as `\l` are kept.
+func quoteDOT(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// escapeRecord escapes the characters that have a special meaning in the
+// labels of DOT record nodes.
+func escapeRecord(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`).Replace(s)
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtordoc
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+type address struct {
+	Street string
+	Zip    string
+}
+
+type card struct {
+	Number string
+}
+
+type lineItem struct {
+	SKU      string
+	Quantity int
+}
+
+type order struct {
+	ID       string
+	Shipping address
+	Billing  address
+	Items    []lineItem
+	Payment  any
+}
+
+func orderSchema() *valtor.ObjectSchema[order] {
+	addressSchema := valtor.Object[address]()
+	valtor.FieldOf(addressSchema, "street", func(a address) string { return a.Street }, valtor.String().Required())
+	valtor.FieldOf(addressSchema, "zip", func(a address) string { return a.Zip }, valtor.String().Length(6))
+
+	itemSchema := valtor.Object[lineItem]()
+	valtor.FieldOf(itemSchema, "sku", func(i lineItem) string { return i.SKU }, valtor.String().Required())
+	valtor.FieldOf(itemSchema, "quantity", func(i lineItem) int { return i.Quantity }, valtor.Number[int]().Min(1))
+
+	cardSchema := valtor.Object[card]()
+	valtor.FieldOf(cardSchema, "number", func(c card) string { return c.Number }, valtor.String().Length(16))
+	paymentSchema := valtor.Any().Required()
+	valtor.WhenType(paymentSchema, cardSchema)
+	valtor.WhenType(paymentSchema, valtor.String().OneOf("cash", "invoice"))
+
+	schema := valtor.Object[order]()
+	valtor.FieldOf(schema, "id", func(o order) string { return o.ID }, valtor.String().Required())
+	valtor.FieldOf(schema, "shipping_address", func(o order) address { return o.Shipping }, addressSchema)
+	valtor.FieldOf(schema, "billing_address", func(o order) address { return o.Billing }, addressSchema)
+	valtor.FieldOf(schema, "items", func(o order) []lineItem { return o.Items },
+		valtor.Array[lineItem]().Min(1).ItemsOf(itemSchema))
+	valtor.FieldOf(schema, "payment", func(o order) any { return o.Payment }, paymentSchema)
+	return schema
+}
+
+func TestGraph(t *testing.T) {
+	tests := []struct {
+		name   string
+		write  func(w io.Writer, name string, schema valtor.Describer) error
+		golden string
+	}{
+		{name: "mermaid", write: WriteMermaid, golden: "testdata/order.mmd.golden"},
+		{name: "dot", write: WriteDOT, golden: "testdata/order.dot.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := os.ReadFile(tt.golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := tt.write(&buf, "order", orderSchema()); err != nil {
+				t.Fatalf("failed to write graph: %v", err)
+			}
+			if got := buf.String(); got != string(want) {
+				t.Errorf("graph does not match %s, got:\n%s", tt.golden, got)
+			}
+		})
+	}
+}
+
+func TestGraphUnion(t *testing.T) {
+	cardSchema := valtor.Object[card]()
+	valtor.FieldOf(cardSchema, "number", func(c card) string { return c.Number }, valtor.String())
+	ibanSchema := valtor.Object[address]()
+	valtor.FieldOf(ibanSchema, "street", func(a address) string { return a.Street }, valtor.String())
+	schema := valtor.Any()
+	valtor.WhenType(schema, cardSchema)
+	valtor.WhenType(schema, ibanSchema)
+
+	var buf bytes.Buffer
+	if err := WriteMermaid(&buf, "payment", schema); err != nil {
+		t.Fatalf("failed to write graph: %v", err)
+	}
+	want := `classDiagram
+  class Payment
+  class PaymentVariant {
+    number: string
+  }
+  class PaymentVariant2 {
+    street: string
+  }
+  Payment --> PaymentVariant
+  Payment --> PaymentVariant2
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEscapeRecord(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: `a|b`, want: `a\|b`},
+		{in: `{x}`, want: `\{x\}`},
+		{in: `<p>`, want: `\<p\>`},
+		{in: `a\b`, want: `a\\b`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeRecord(tt.in); got != tt.want {
+			t.Errorf("escapeRecord(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtordoc describes the constraints of valtor object schemas as
+// suggested Go struct tags and doc comments, to keep the documentation of
+// structs in sync with their runtime validation.
+//
+// Tags use the keywords of the `jsonschema` struct tag, so that they can be
+// read back by valtorgen and by github.com/invopop/jsonschema.
+//
+// WriteMermaid and WriteDOT render the objects of a schema and how they are
+// nested as a diagram, to visualize large validation contracts.
+package valtordoc
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/dstotijn/valtor"
+)
+
+// Field describes a field of an object schema.
+type Field struct {
+	Name    string // Field name, e.g. `email`.
+	GoName  string // Suggested Go name of the field, e.g. `Email`.
+	Tag     string // Suggested struct tag, e.g. `json:"email" jsonschema:"required,format=email"`.
+	Comment string // Suggested doc comment, e.g. `Email is required and is a valid email.`
+}
+
+// Fields describes the fields of an object schema, as returned by
+// valtor.ObjectSchema.FieldConstraints. Constraints without a `jsonschema`
+// tag keyword, such as StringSchema.HasPrefix, are only described in the
+// comment. The comment is empty for fields without known constraints.
+func Fields(fields iter.Seq2[string, []valtor.Constraint]) []Field {
+	var result []Field
+	for name, constraints := range fields {
+		field := Field{
+			Name:   name,
+			GoName: goName(name),
+			Tag:    structTag(name, constraints),
+		}
+		var phrases []string
+		for _, c := range constraints {
+			phrases = append(phrases, phrase(c))
+		}
+		if len(phrases) > 0 {
+			field.Comment = field.GoName + " " + joinPhrases(phrases) + "."
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+// Write writes the described fields of an object schema (see Fields) to w as
+// a table with the Go name, the suggested struct tag and the doc comment of
+// each field.
+func Write(w io.Writer, fields iter.Seq2[string, []valtor.Constraint]) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	for _, field := range Fields(fields) {
+		line := fmt.Sprintf("%s\t`%s`", field.GoName, field.Tag)
+		if field.Comment != "" {
+			line += "\t// " + field.Comment
+		}
+		fmt.Fprintln(tw, line)
+	}
+	return tw.Flush()
+}
+
+// tagKeywords are the constraint keywords that are supported by the
+// `jsonschema` struct tag.
+var tagKeywords = map[string]bool{
+	"minLength": true, "maxLength": true, "pattern": true, "format": true,
+	"minimum": true, "maximum": true, "exclusiveMinimum": true,
+	"exclusiveMaximum": true, "multipleOf": true, "minItems": true,
+	"maxItems": true, "uniqueItems": true, "enum": true,
+}
+
+func structTag(name string, constraints []valtor.Constraint) string {
+	var keywords []string
+	for _, c := range constraints {
+		switch {
+		case c.Keyword == "required":
+			keywords = append(keywords, "required")
+		case c.Keyword == "enum":
+			for _, v := range values(c.Value) {
+				keywords = append(keywords, "enum="+escape(fmt.Sprint(v)))
+			}
+		case tagKeywords[c.Keyword]:
+			keywords = append(keywords, c.Keyword+"="+escape(fmt.Sprint(c.Value)))
+		}
+	}
+
+	tag := fmt.Sprintf("json:%q", name)
+	if len(keywords) > 0 {
+		tag += fmt.Sprintf(" jsonschema:%q", strings.Join(keywords, ","))
+	}
+	return tag
+}
+
+// escape escapes the commas of a `jsonschema` tag value.
+func escape(s string) string {
+	return strings.ReplaceAll(s, ",", `\,`)
+}
+
+// phrase describes a constraint, e.g. `has at most 5 characters`.
+func phrase(c valtor.Constraint) string {
+	switch c.Keyword {
+	case "required":
+		return "is required"
+	case "minLength":
+		return fmt.Sprintf("has at least %v characters", c.Value)
+	case "maxLength":
+		return fmt.Sprintf("has at most %v characters", c.Value)
+	case "minItems":
+		return fmt.Sprintf("has at least %v items", c.Value)
+	case "maxItems":
+		return fmt.Sprintf("has at most %v items", c.Value)
+	case "minimum":
+		return fmt.Sprintf("is at least %v", c.Value)
+	case "maximum":
+		return fmt.Sprintf("is at most %v", c.Value)
+	case "exclusiveMinimum":
+		return fmt.Sprintf("is greater than %v", c.Value)
+	case "exclusiveMaximum":
+		return fmt.Sprintf("is less than %v", c.Value)
+	case "multipleOf":
+		if fmt.Sprint(c.Value) == "1" {
+			return "is a whole number"
+		}
+		return fmt.Sprintf("is a multiple of %v", c.Value)
+	case "pattern":
+		return fmt.Sprintf("matches `%v`", c.Value)
+	case "format":
+		return fmt.Sprintf("is a valid %v", c.Value)
+	case "uniqueItems":
+		return "has unique items"
+	case "const":
+		return fmt.Sprintf("is %v", c.Value)
+	case "enum":
+		var listed []string
+		for _, v := range values(c.Value) {
+			listed = append(listed, fmt.Sprintf("%#v", v))
+		}
+		return "is one of " + strings.Join(listed, ", ")
+	case valtor.CodeContains:
+		return fmt.Sprintf("contains %q", c.Value)
+	case valtor.CodeNotContains:
+		return fmt.Sprintf("does not contain %q", c.Value)
+	case valtor.CodePrefix:
+		return fmt.Sprintf("starts with %q", c.Value)
+	case valtor.CodeSuffix:
+		return fmt.Sprintf("ends with %q", c.Value)
+	case valtor.CodeAlphanumeric:
+		return "only contains letters and digits"
+	case valtor.CodeASCII:
+		return "only contains ASCII characters"
+	case valtor.CodeControlChars:
+		return "does not contain control characters"
+	case valtor.CodeHTML:
+		return "does not contain HTML"
+	case valtor.CodeSQLMeta:
+		return "does not contain SQL metacharacters"
+	case valtor.CodeFilename:
+		return "is a safe file name"
+	case valtor.CodeOneOf:
+		return "is in the list of allowed values"
+	case valtor.CodeDenied:
+		return "is not in the denylist"
+	case valtor.CodeDeniedWord:
+		return "does not contain denied words"
+	case valtor.CodeExpression:
+		return fmt.Sprintf("satisfies `%v`", c.Value)
+	case valtor.CodeNotBetween:
+		bounds := values(c.Value)
+		if len(bounds) == 2 {
+			return fmt.Sprintf("is not between %v and %v", bounds[0], bounds[1])
+		}
+	case valtor.CodeNonZero:
+		return "is not zero"
+	case valtor.CodeFinite:
+		return "is finite"
+	case valtor.CodeSorted:
+		return "is sorted"
+	}
+	return fmt.Sprintf("%s %v", c.Keyword, c.Value)
+}
+
+// values returns the items of a slice value, such as the values of `enum`.
+func values(v any) []any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return []any{v}
+	}
+	result := make([]any, rv.Len())
+	for i := range result {
+		result[i] = rv.Index(i).Interface()
+	}
+	return result
+}
+
+// joinPhrases joins phrases as a list, e.g. `a, b and c`.
+func joinPhrases(phrases []string) string {
+	if len(phrases) == 1 {
+		return phrases[0]
+	}
+	return strings.Join(phrases[:len(phrases)-1], ", ") + " and " + phrases[len(phrases)-1]
+}
+
+// commonInitialisms are written in upper case in Go names.
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+	"JSON": true, "SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// goName returns a Go name for a field name, e.g. `UserID` for `user_id`.
+func goName(name string) string {
+	var sb strings.Builder
+	for part := range strings.FieldsFuncSeq(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if upper := strings.ToUpper(part); commonInitialisms[upper] {
+			sb.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	s := sb.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
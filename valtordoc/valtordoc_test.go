@@ -0,0 +1,138 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtordoc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+type user struct {
+	ID       string
+	Email    string
+	Age      int
+	Tags     []string
+	Nickname string
+}
+
+func userSchema() *valtor.ObjectSchema[user] {
+	schema := valtor.Object[user]()
+	valtor.FieldOf(schema, "id", func(u user) string { return u.ID }, valtor.String().Required().HasPrefix("usr_"))
+	valtor.FieldOf(schema, "email", func(u user) string { return u.Email }, valtor.String().Required().Max(254).Format("email"))
+	valtor.FieldOf(schema, "age", func(u user) int { return u.Age }, valtor.Number[int]().Between(18, 130))
+	valtor.FieldOf(schema, "tags", func(u user) []string { return u.Tags }, valtor.Array[string]().Max(5).UniqueItems())
+	schema.Field("nickname", func(u user) error { return nil })
+	return schema
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantGoName  string
+		wantTag     string
+		wantComment string
+	}{
+		{
+			name:        "id",
+			wantGoName:  "ID",
+			wantTag:     `json:"id" jsonschema:"required"`,
+			wantComment: `ID is required and starts with "usr_".`,
+		},
+		{
+			name:        "email",
+			wantGoName:  "Email",
+			wantTag:     `json:"email" jsonschema:"required,maxLength=254,format=email"`,
+			wantComment: "Email is required, has at most 254 characters and is a valid email.",
+		},
+		{
+			name:        "age",
+			wantGoName:  "Age",
+			wantTag:     `json:"age" jsonschema:"minimum=18,maximum=130"`,
+			wantComment: "Age is at least 18 and is at most 130.",
+		},
+		{
+			name:        "tags",
+			wantGoName:  "Tags",
+			wantTag:     `json:"tags" jsonschema:"maxItems=5,uniqueItems=true"`,
+			wantComment: "Tags has at most 5 items and has unique items.",
+		},
+		{
+			name:       "nickname",
+			wantGoName: "Nickname",
+			wantTag:    `json:"nickname"`,
+		},
+	}
+
+	fields := Fields(userSchema().FieldConstraints())
+	if len(fields) != len(tests) {
+		t.Fatalf("expected %d fields, got %d", len(tests), len(fields))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fields[i]
+			if got.Name != tt.name {
+				t.Errorf("expected name %q, got %q", tt.name, got.Name)
+			}
+			if got.GoName != tt.wantGoName {
+				t.Errorf("expected Go name %q, got %q", tt.wantGoName, got.GoName)
+			}
+			if got.Tag != tt.wantTag {
+				t.Errorf("expected tag %q, got %q", tt.wantTag, got.Tag)
+			}
+			if got.Comment != tt.wantComment {
+				t.Errorf("expected comment %q, got %q", tt.wantComment, got.Comment)
+			}
+		})
+	}
+}
+
+func TestFieldsEnumAndPattern(t *testing.T) {
+	schema := valtor.Object[map[string]any]()
+	valtor.FieldOf(schema, "role", func(map[string]any) string { return "" }, valtor.String().OneOf("admin", "a,b"))
+	valtor.FieldOf(schema, "code", func(map[string]any) string { return "" }, valtor.String().Length(3))
+
+	fields := Fields(schema.FieldConstraints())
+
+	wantTag := `json:"role" jsonschema:"enum=admin,enum=a\\,b"`
+	if fields[0].Tag != wantTag {
+		t.Errorf("expected tag %q, got %q", wantTag, fields[0].Tag)
+	}
+	wantComment := `Role is one of "admin", "a,b".`
+	if fields[0].Comment != wantComment {
+		t.Errorf("expected comment %q, got %q", wantComment, fields[0].Comment)
+	}
+	wantTag = `json:"code" jsonschema:"minLength=3,maxLength=3"`
+	if fields[1].Tag != wantTag {
+		t.Errorf("expected tag %q, got %q", wantTag, fields[1].Tag)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, userSchema().FieldConstraints()); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+
+	want := "ID       `json:\"id\" jsonschema:\"required\"`                               // ID is required and starts with \"usr_\".\n" +
+		"Email    `json:\"email\" jsonschema:\"required,maxLength=254,format=email\"` // Email is required, has at most 254 characters and is a valid email.\n" +
+		"Age      `json:\"age\" jsonschema:\"minimum=18,maximum=130\"`                // Age is at least 18 and is at most 130.\n" +
+		"Tags     `json:\"tags\" jsonschema:\"maxItems=5,uniqueItems=true\"`          // Tags has at most 5 items and has unique items.\n" +
+		"Nickname `json:\"nickname\"`\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorevent validates event and command payloads by type name, for
+// use in event-driven services.
+package valtorevent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dstotijn/valtor"
+)
+
+var ErrUnknownType = errors.New("unknown type")
+
+// UnknownTypePolicy determines how payloads of unregistered types are handled.
+type UnknownTypePolicy int
+
+const (
+	// RejectUnknown fails validation of payloads with an unregistered type.
+	RejectUnknown UnknownTypePolicy = iota
+	// AllowUnknown accepts payloads with an unregistered type without validation.
+	AllowUnknown
+)
+
+// Stats holds validation counts for a single type.
+type Stats struct {
+	Valid   uint64
+	Invalid uint64
+}
+
+type counters struct {
+	valid   atomic.Uint64
+	invalid atomic.Uint64
+}
+
+type entry struct {
+	validateFn func([]byte) error
+	counters   *counters
+}
+
+// Registry maps event or command type names to validation schemas.
+type Registry struct {
+	mu            sync.RWMutex
+	entries       map[string]entry
+	unknownPolicy UnknownTypePolicy
+	unknown       counters
+}
+
+// NewRegistry creates a new registry that rejects unknown types.
+func NewRegistry() *Registry {
+	return &Registry{
+		entries: make(map[string]entry),
+	}
+}
+
+// UnknownTypePolicy sets the policy for payloads of unregistered types and
+// returns the registry for chaining.
+func (r *Registry) UnknownTypePolicy(policy UnknownTypePolicy) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.unknownPolicy = policy
+	return r
+}
+
+// Register registers a schema for a type name. Payloads of the type are decoded
+// as JSON into a value of type T before being validated, like with
+// valtor.Schema.ValidateJSON, so that numbers decoded into interface values
+// keep their precision and payloads that cannot be decoded fail with a
+// *valtor.DecodeError. Registering a type name that already has a schema
+// replaces it.
+func Register[T any](r *Registry, typeName string, schema valtor.Validator[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[typeName] = entry{
+		validateFn: func(payload []byte) error {
+			value, err := valtor.DecodeJSON[T](bytes.NewReader(payload))
+			if err != nil {
+				return err
+			}
+			return schema.Validate(value)
+		},
+		counters: &counters{},
+	}
+}
+
+// ValidateEnvelope validates a JSON payload against the schema registered for
+// the type name.
+func (r *Registry) ValidateEnvelope(typeName string, payload []byte) error {
+	r.mu.RLock()
+	e, ok := r.entries[typeName]
+	policy := r.unknownPolicy
+	r.mu.RUnlock()
+
+	if !ok {
+		if policy == AllowUnknown {
+			r.unknown.valid.Add(1)
+			return nil
+		}
+		r.unknown.invalid.Add(1)
+		return fmt.Errorf("%w: %q", ErrUnknownType, typeName)
+	}
+
+	if err := e.validateFn(payload); err != nil {
+		e.counters.invalid.Add(1)
+		return fmt.Errorf("invalid %q payload: %w", typeName, err)
+	}
+	e.counters.valid.Add(1)
+
+	return nil
+}
+
+// Stats returns the validation counts per registered type name.
+func (r *Registry) Stats() map[string]Stats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := make(map[string]Stats, len(r.entries))
+	for typeName, e := range r.entries {
+		stats[typeName] = Stats{
+			Valid:   e.counters.valid.Load(),
+			Invalid: e.counters.invalid.Load(),
+		}
+	}
+	return stats
+}
+
+// UnknownStats returns the counts of payloads with an unregistered type, which
+// are counted as valid or invalid according to the unknown type policy.
+func (r *Registry) UnknownStats() Stats {
+	return Stats{
+		Valid:   r.unknown.valid.Load(),
+		Invalid: r.unknown.invalid.Load(),
+	}
+}
+
+// Types returns the registered type names in sorted order.
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.entries))
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorevent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+type orderPlaced struct {
+	OrderID string `json:"order_id"`
+}
+
+var orderPlacedSchema = valtor.FieldOf(valtor.Object[orderPlaced](), "order_id",
+	func(e orderPlaced) string { return e.OrderID },
+	valtor.String().Required(),
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	Register(r, "OrderPlaced", orderPlacedSchema)
+
+	if err := r.ValidateEnvelope("OrderPlaced", []byte(`{"order_id":"123"}`)); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := r.ValidateEnvelope("OrderPlaced", []byte(`{}`)); !errors.Is(err, valtor.ErrValueRequired) {
+		t.Errorf("expected error %q, got %v", valtor.ErrValueRequired, err)
+	}
+	var decodeErr *valtor.DecodeError
+	if err := r.ValidateEnvelope("OrderPlaced", []byte(`{`)); !errors.As(err, &decodeErr) {
+		t.Errorf("expected decode error for malformed payload, got %v", err)
+	}
+	if err := r.ValidateEnvelope("OrderShipped", []byte(`{}`)); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("expected error %q, got %v", ErrUnknownType, err)
+	}
+
+	if got, want := r.Stats()["OrderPlaced"], (Stats{Valid: 1, Invalid: 2}); got != want {
+		t.Errorf("expected stats %+v, got %+v", want, got)
+	}
+	if got, want := r.UnknownStats(), (Stats{Invalid: 1}); got != want {
+		t.Errorf("expected unknown stats %+v, got %+v", want, got)
+	}
+	if got, want := r.Types(), []string{"OrderPlaced"}; !slices.Equal(got, want) {
+		t.Errorf("expected types %v, got %v", want, got)
+	}
+}
+
+func TestRegistryLargeNumber(t *testing.T) {
+	r := NewRegistry()
+	Register(r, "Transfer", valtor.New[map[string]any]().Custom(func(v map[string]any) error {
+		if n, _ := v["amount"].(json.Number); n != "9007199254740993" {
+			return fmt.Errorf("expected amount 9007199254740993, got %v", v["amount"])
+		}
+		return nil
+	}))
+
+	if err := r.ValidateEnvelope("Transfer", []byte(`{"amount":9007199254740993}`)); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+}
+
+func TestRegistryAllowUnknown(t *testing.T) {
+	r := NewRegistry().UnknownTypePolicy(AllowUnknown)
+
+	if err := r.ValidateEnvelope("OrderShipped", []byte(`{}`)); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if got, want := r.UnknownStats(), (Stats{Valid: 1}); got != want {
+		t.Errorf("expected unknown stats %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,498 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorexpr implements a small expression language for validation
+// rules, e.g. `max >= min && currency in ["EUR", "USD"]`, so that rules can be
+// defined in configuration that is loaded at runtime, without dependencies.
+// For a more complete language, see package valtorcel.
+//
+// Expressions consist of:
+//
+//   - literals: numbers, e.g. `42` and `0.5`, strings in single or double
+//     quotes, `true`, `false`, `null` and lists, e.g. `[1, 2]`.
+//   - field references, e.g. `address.zip`, which look up keys of maps and
+//     fields of structs by their JSON names. Missing fields are null. `self`
+//     refers to the validated value itself.
+//   - comparisons: `==`, `!=`, `<`, `<=`, `>`, `>=` and `in`, which checks if
+//     a value is an item of a list, a key of a map or a substring of a string.
+//     Numbers, strings and times are ordered.
+//   - boolean operators: `&&`, `||` and `!`.
+//   - arithmetic: `+`, `-`, `*`, `/` and `%` on numbers, and `+` on strings.
+//   - functions: `len(x)` of strings, lists and maps, and `contains(s, sub)`,
+//     `startsWith(s, prefix)` and `endsWith(s, suffix)` of strings.
+//
+// Numbers are exact (*big.Rat), so that e.g. `0.1 + 0.2 == 0.3` holds.
+// Floating-point values are converted by their shortest decimal
+// representation.
+package valtorexpr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrSyntax         = errors.New("syntax error")
+	ErrType           = errors.New("type error")
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+// Expr is a parsed expression. It is safe for concurrent use.
+type Expr struct {
+	src  string
+	root node
+}
+
+// Parse parses an expression. It fails with ErrSyntax if the expression is
+// invalid.
+func Parse(src string) (*Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// MustParse is like Parse, but panics if the expression is invalid.
+func MustParse(src string) *Expr {
+	e, err := Parse(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression against a value, which fields are referenced
+// by. The result is nil, a bool, a *big.Rat, a string, a time.Time, a []any,
+// or a map or struct of the value. It fails with ErrType if an operator or
+// function is applied to values of the wrong type.
+func (e *Expr) Eval(value any) (any, error) {
+	return e.root.eval(value)
+}
+
+// Test evaluates the expression against a value and reports whether it holds.
+// It fails with ErrType if the expression doesn't evaluate to a bool.
+func (e *Expr) Test(value any) (bool, error) {
+	result, err := e.Eval(value)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: expression evaluates to %s instead of bool", ErrType, typeName(result))
+	}
+	return b, nil
+}
+
+func (n literal) eval(any) (any, error) {
+	return n.value, nil
+}
+
+func (n ref) eval(root any) (any, error) {
+	value := root
+	for _, name := range n.path {
+		v, err := field(value, name)
+		if err != nil {
+			return nil, err
+		}
+		value = v
+	}
+	return normalize(value), nil
+}
+
+func (n list) eval(root any) (any, error) {
+	items := make([]any, len(n.items))
+	for i, item := range n.items {
+		v, err := item.eval(root)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = v
+	}
+	return items, nil
+}
+
+func (n unary) eval(root any) (any, error) {
+	v, err := n.operand.eval(root)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "!":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, operandError(n.op, v)
+		}
+		return !b, nil
+	default:
+		r, ok := v.(*big.Rat)
+		if !ok {
+			return nil, operandError(n.op, v)
+		}
+		return new(big.Rat).Neg(r), nil
+	}
+}
+
+func (n binary) eval(root any) (any, error) {
+	left, err := n.left.eval(root)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, operandError(n.op, left)
+		}
+		if l == (n.op == "||") {
+			return l, nil
+		}
+		right, err := n.right.eval(root)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, operandError(n.op, right)
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(root)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		c, err := compare(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	case "in":
+		return in(left, right)
+	default:
+		return arithmetic(n.op, left, right)
+	}
+}
+
+func (n call) eval(root any) (any, error) {
+	args := make([]any, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(root)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return functions[n.name].fn(args)
+}
+
+// function is a built-in function.
+type function struct {
+	arity int
+	fn    func(args []any) (any, error)
+}
+
+var functions = map[string]function{
+	"len": {arity: 1, fn: func(args []any) (any, error) {
+		switch v := args[0].(type) {
+		case string:
+			return big.NewRat(int64(utf8.RuneCountInString(v)), 1), nil
+		case []any:
+			return big.NewRat(int64(len(v)), 1), nil
+		}
+		if rv := reflect.ValueOf(args[0]); rv.Kind() == reflect.Map {
+			return big.NewRat(int64(rv.Len()), 1), nil
+		}
+		return nil, fmt.Errorf("%w: len of %s", ErrType, typeName(args[0]))
+	}},
+	"contains":   stringFunction("contains", strings.Contains),
+	"startsWith": stringFunction("startsWith", strings.HasPrefix),
+	"endsWith":   stringFunction("endsWith", strings.HasSuffix),
+}
+
+func stringFunction(name string, fn func(s, substr string) bool) function {
+	return function{arity: 2, fn: func(args []any) (any, error) {
+		s, ok1 := args[0].(string)
+		substr, ok2 := args[1].(string)
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("%w: %s of %s and %s", ErrType, name, typeName(args[0]), typeName(args[1]))
+		}
+		return fn(s, substr), nil
+	}}
+}
+
+// field returns the field of a map or struct by name. Fields of null values
+// and missing fields are null.
+func field(value any, name string) (any, error) {
+	if m, ok := value.(map[string]any); ok {
+		return m[name], nil
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		v := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+		if !v.IsValid() {
+			return nil, nil
+		}
+		return v.Interface(), nil
+	case reflect.Struct:
+		t := rv.Type()
+		for i := range t.NumField() {
+			if f := t.Field(i); f.IsExported() && fieldName(f) == name {
+				return rv.Field(i).Interface(), nil
+			}
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: field %q of %s", ErrType, name, typeName(normalize(value)))
+}
+
+// fieldName returns the JSON name of a struct field, or an empty string if it
+// is ignored by package json.
+func fieldName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return f.Name
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// normalize converts a Go value to a value of the language: numbers to
+// *big.Rat, slices and arrays to []any, and pointers to their values. Maps and
+// structs are kept as is.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case nil, bool, string, time.Time, []any:
+		return v
+	case *big.Rat:
+		return v
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		return new(big.Rat).SetInt(v)
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(v.String()); ok {
+			return r
+		}
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewRat(rv.Int(), 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		// Use the shortest decimal representation, so that e.g. 0.1 is 1/10.
+		bitSize := 64
+		if rv.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		if r, ok := new(big.Rat).SetString(strconv.FormatFloat(rv.Float(), 'g', -1, bitSize)); ok {
+			return r
+		}
+		return value // NaN or infinity.
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = normalize(rv.Index(i).Interface())
+		}
+		return items
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		if elem := rv.Elem(); elem.Kind() != reflect.Struct || elem.Type() == timeType {
+			return normalize(elem.Interface())
+		}
+	}
+	return value
+}
+
+// typeName returns the name of the type of a value of the language.
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case *big.Rat:
+		return "number"
+	case string:
+		return "string"
+	case time.Time:
+		return "time"
+	case []any:
+		return "list"
+	default:
+		return "object"
+	}
+}
+
+func operandError(op string, value any) error {
+	return fmt.Errorf("%w: operator %s on %s", ErrType, op, typeName(value))
+}
+
+// equal reports whether two values are equal. Values of different types are
+// not equal.
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case *big.Rat:
+		b, ok := b.(*big.Rat)
+		return ok && a.Cmp(b) == 0
+	case time.Time:
+		b, ok := b.(time.Time)
+		return ok && a.Equal(b)
+	case []any:
+		b, ok := b.([]any)
+		return ok && slices.EqualFunc(a, b, func(x, y any) bool {
+			return equal(normalize(x), normalize(y))
+		})
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare compares two numbers, strings or times.
+func compare(a, b any) (int, error) {
+	switch a := a.(type) {
+	case *big.Rat:
+		if b, ok := b.(*big.Rat); ok {
+			return a.Cmp(b), nil
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), nil
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: cannot compare %s and %s", ErrType, typeName(a), typeName(b))
+}
+
+// in reports whether a value is an item of a list, a key of a map or a
+// substring of a string.
+func in(value, container any) (bool, error) {
+	switch c := container.(type) {
+	case []any:
+		return slices.ContainsFunc(c, func(item any) bool {
+			return equal(value, normalize(item))
+		}), nil
+	case string:
+		if s, ok := value.(string); ok {
+			return strings.Contains(c, s), nil
+		}
+	case nil:
+		return false, nil
+	default:
+		if rv := reflect.ValueOf(c); rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+			if s, ok := value.(string); ok {
+				return rv.MapIndex(reflect.ValueOf(s).Convert(rv.Type().Key())).IsValid(), nil
+			}
+		}
+	}
+	return false, fmt.Errorf("%w: %s in %s", ErrType, typeName(value), typeName(container))
+}
+
+func arithmetic(op string, a, b any) (any, error) {
+	if s, ok := a.(string); ok && op == "+" {
+		if t, ok := b.(string); ok {
+			return s + t, nil
+		}
+	}
+	x, ok1 := a.(*big.Rat)
+	y, ok2 := b.(*big.Rat)
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("%w: %s %s %s", ErrType, typeName(a), op, typeName(b))
+	}
+	switch op {
+	case "+":
+		return new(big.Rat).Add(x, y), nil
+	case "-":
+		return new(big.Rat).Sub(x, y), nil
+	case "*":
+		return new(big.Rat).Mul(x, y), nil
+	}
+	if y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	if op == "/" {
+		return new(big.Rat).Quo(x, y), nil
+	}
+	if !x.IsInt() || !y.IsInt() {
+		return nil, fmt.Errorf("%w: %% of non-integers", ErrType)
+	}
+	return new(big.Rat).SetInt(new(big.Int).Rem(x.Num(), y.Num())), nil
+}
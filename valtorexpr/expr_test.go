@@ -0,0 +1,275 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorexpr
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/valtor"
+)
+
+type address struct {
+	Zip     string `json:"zip"`
+	Country string `json:"country,omitempty"`
+}
+
+type order struct {
+	Min      int               `json:"min"`
+	Max      int               `json:"max"`
+	Price    float64           `json:"price"`
+	Currency string            `json:"currency"`
+	Tags     []string          `json:"tags"`
+	Address  *address          `json:"address"`
+	Labels   map[string]string `json:"labels"`
+	Created  time.Time         `json:"created"`
+	Expires  *time.Time        `json:"expires"`
+	Internal string            `json:"-"`
+	Note     string
+}
+
+func TestExprTest(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := created.Add(time.Hour)
+	value := order{
+		Min:      1,
+		Max:      10,
+		Price:    9.95,
+		Currency: "EUR",
+		Tags:     []string{"new", "sale"},
+		Address:  &address{Zip: "1234AB"},
+		Labels:   map[string]string{"team": "core"},
+		Created:  created,
+		Expires:  &expires,
+		Internal: "secret",
+		Note:     "fragile",
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "max >= min", want: true},
+		{expr: "max < min", want: false},
+		{expr: "self.max == 10", want: true},
+		{expr: "max != 10", want: false},
+		{expr: "price <= 9.95 && price > 9.949", want: true},
+		{expr: "price * 2 == 19.9", want: true},
+		{expr: "0.1 + 0.2 == 0.3", want: true},
+		{expr: "max - min * 2 == 8", want: true},
+		{expr: "(max - min) * 2 == 18", want: true},
+		{expr: "max / 4 == 2.5", want: true},
+		{expr: "max % 3 == 1", want: true},
+		{expr: "-min == -1", want: true},
+		{expr: "currency in ['EUR', 'USD']", want: true},
+		{expr: `currency in ["GBP"]`, want: false},
+		{expr: "'sale' in tags", want: true},
+		{expr: "'team' in labels", want: true},
+		{expr: "'owner' in labels", want: false},
+		{expr: "'UR' in currency", want: true},
+		{expr: "len(tags) == 2 && len(currency) == 3 && len(labels) == 1", want: true},
+		{expr: "startsWith(address.zip, '1234') && endsWith(address.zip, 'AB')", want: true},
+		{expr: "contains(Note, 'agil')", want: true},
+		{expr: "address.country == ''", want: true},
+		{expr: "missing == null && missing.nested == null", want: true},
+		{expr: "Internal == null", want: true},
+		{expr: "expires > created", want: true},
+		{expr: "currency + '-' + address.zip == 'EUR-1234AB'", want: true},
+		{expr: "tags == ['new', 'sale']", want: true},
+		{expr: "!(max < min) || max / 0 == 1", want: true},
+		{expr: "max < min && max / 0 == 1", want: false},
+		{expr: "!true == false", want: true},
+		{expr: "currency < 'USD'", want: true},
+		{expr: "1e3 == 1000", want: true},
+		{expr: `"it's" == 'it\'s'`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+			got, err := expr.Test(value)
+			if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExprEval(t *testing.T) {
+	value := map[string]any{
+		"n":    json.Number("12.5"),
+		"big":  big.NewInt(3),
+		"list": []int{1, 2},
+	}
+
+	tests := []struct {
+		expr string
+		want any
+	}{
+		{expr: "n * 2", want: big.NewRat(25, 1)},
+		{expr: "big + 1", want: big.NewRat(4, 1)},
+		{expr: "list", want: []any{big.NewRat(1, 1), big.NewRat(2, 1)}},
+		{expr: "'a' + 'b'", want: "ab"},
+		{expr: "null", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := MustParse(tt.expr).Eval(value)
+			if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+			if !equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr error
+	}{
+		{expr: "", wantErr: ErrSyntax},
+		{expr: "max >=", wantErr: ErrSyntax},
+		{expr: "max >= min)", wantErr: ErrSyntax},
+		{expr: "(max >= min", wantErr: ErrSyntax},
+		{expr: "max # min", wantErr: ErrSyntax},
+		{expr: "'unterminated", wantErr: ErrSyntax},
+		{expr: "1.2.3 == 1", wantErr: ErrSyntax},
+		{expr: "len(a, b)", wantErr: ErrSyntax},
+		{expr: "a. == 1", wantErr: ErrSyntax},
+		{expr: "[1, 2", wantErr: ErrSyntax},
+		{expr: "max", wantErr: ErrType},
+		{expr: "max > 'a'", wantErr: ErrType},
+		{expr: "max && true", wantErr: ErrType},
+		{expr: "true && max", wantErr: ErrType},
+		{expr: "!max", wantErr: ErrType},
+		{expr: "-currency == 1", wantErr: ErrType},
+		{expr: "currency * 2 == 1", wantErr: ErrType},
+		{expr: "max % 0.5 == 0", wantErr: ErrType},
+		{expr: "len(max) == 1", wantErr: ErrType},
+		{expr: "contains(max, 'a')", wantErr: ErrType},
+		{expr: "max in currency", wantErr: ErrType},
+		{expr: "currency.code == 1", wantErr: ErrType},
+		{expr: "max / 0 == 1", wantErr: ErrDivisionByZero},
+	}
+
+	value := order{Max: 10, Currency: "EUR"}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err == nil {
+				_, err = expr.Test(value)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	schema := valtor.Object[order]()
+	schema.Rule(MustCompile[order](Rule{Expr: "max >= min"}))
+	schema.Rule(MustCompile[order](Rule{Expr: "currency in ['EUR', 'USD']", Message: "unsupported currency"}))
+
+	tests := []struct {
+		name    string
+		value   order
+		wantErr string
+	}{
+		{name: "valid", value: order{Min: 1, Max: 2, Currency: "EUR"}},
+		{name: "default message", value: order{Min: 2, Max: 1, Currency: "EUR"}, wantErr: "value must satisfy max >= min"},
+		{name: "message", value: order{Currency: "GBP"}, wantErr: "unsupported currency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %q", err)
+				}
+				return
+			}
+			var constraintErr *valtor.ConstraintError
+			if !errors.As(err, &constraintErr) || constraintErr.Code != valtor.CodeExpression {
+				t.Fatalf("expected *valtor.ConstraintError with code %q, got %v", valtor.CodeExpression, err)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %q", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := Compile[order](Rule{Expr: "max >="}); !errors.Is(err, ErrSyntax) {
+		t.Errorf("expected error %q, got %v", ErrSyntax, err)
+	}
+}
+
+func TestPack(t *testing.T) {
+	registry := valtor.NewExtensionRegistry()
+	if err := registry.Use(Pack{}); err != nil {
+		t.Fatalf("failed to register pack: %v", err)
+	}
+	ext, ok := registry.Lookup("x-expr")
+	if !ok {
+		t.Fatal("expected extension x-expr to be registered")
+	}
+
+	tests := []struct {
+		name     string
+		params   string
+		value    any
+		wantErr  string
+		parseErr bool
+	}{
+		{name: "expression", params: `"max >= min"`, value: map[string]any{"min": json.Number("1"), "max": json.Number("2.5")}},
+		{name: "expression invalid", params: `"max >= min"`, value: map[string]any{"min": json.Number("2"), "max": json.Number("1")}, wantErr: "value must satisfy max >= min"},
+		{name: "rule", params: `{"rule": "len(self) <= 2", "message": "too many tags"}`, value: []any{"a", "b", "c"}, wantErr: "too many tags"},
+		{name: "invalid expression", params: `"self >"`, parseErr: true},
+		{name: "invalid params", params: `42`, parseErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateFn, err := ext.Parse(json.RawMessage(tt.params))
+			if (err != nil) != tt.parseErr {
+				t.Fatalf("expected parse error: %v, got %v", tt.parseErr, err)
+			}
+			if err != nil {
+				return
+			}
+			err = validateFn(tt.value)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorexpr
+
+import (
+	"encoding/json"
+
+	"github.com/dstotijn/valtor"
+)
+
+// Pack adds the `x-expr` extension, e.g. for a keyword of a JSON Schema or a
+// rule of a valtorconfig file, once registered with valtor.Use. Its parameters
+// are a Rule, or just its expression, e.g. `"max >= min"`. As expressions
+// can't loop, their evaluation needs no cost limit.
+type Pack struct{}
+
+func (Pack) Extensions() []valtor.Extension {
+	return []valtor.Extension{{
+		Name: "x-expr",
+		Parse: func(params json.RawMessage) (func(any) error, error) {
+			var rule Rule
+			if err := json.Unmarshal(params, &rule); err != nil {
+				return nil, err
+			}
+			r, err := Compile[any](rule)
+			if err != nil {
+				return nil, err
+			}
+			return r.Validate, nil
+		},
+	}}
+}
@@ -0,0 +1,359 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorexpr
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// node is a node of the syntax tree of an expression.
+type node interface {
+	eval(root any) (any, error)
+}
+
+type (
+	literal struct{ value any }
+	ref     struct{ path []string } // Empty for `self`.
+	list    struct{ items []node }
+	call    struct {
+		name string
+		args []node
+	}
+	unary struct {
+		op      string
+		operand node
+	}
+	binary struct {
+		op          string
+		left, right node
+	}
+)
+
+// token is a lexical token of an expression.
+type token struct {
+	kind  tokenKind
+	text  string
+	value any // Value of number and string literals.
+	pos   int // Byte offset in the expression.
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+)
+
+// operators are the operators and punctuation, longest first.
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", "."}
+
+// lex splits an expression into tokens.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(src); {
+		r, size := utf8.DecodeRuneInString(src[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case r == '_' || unicode.IsLetter(r):
+			end := pos
+			for end < len(src) {
+				r, size := utf8.DecodeRuneInString(src[end:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[pos:end], pos: pos})
+			pos = end
+		case r >= '0' && r <= '9':
+			end := pos
+			for end < len(src) && (src[end] >= '0' && src[end] <= '9' || src[end] == '.' || src[end] == 'e' || src[end] == 'E' ||
+				(src[end] == '+' || src[end] == '-') && (src[end-1] == 'e' || src[end-1] == 'E')) {
+				end++
+			}
+			n, ok := new(big.Rat).SetString(src[pos:end])
+			if !ok {
+				return nil, syntaxError(pos, "invalid number %q", src[pos:end])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[pos:end], value: n, pos: pos})
+			pos = end
+		case r == '"' || r == '\'':
+			end := pos + 1
+			for end < len(src) && src[end] != byte(r) {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, syntaxError(pos, "unterminated string")
+			}
+			text := src[pos : end+1]
+			quoted := text
+			if r == '\'' {
+				quoted = `"` + strings.ReplaceAll(strings.ReplaceAll(text[1:len(text)-1], `\'`, `'`), `"`, `\"`) + `"`
+			}
+			s, err := strconv.Unquote(quoted)
+			if err != nil {
+				return nil, syntaxError(pos, "invalid string %s", text)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, value: s, pos: pos})
+			pos = end + 1
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(src[pos:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, syntaxError(pos, "unexpected character %q", r)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: pos})
+			pos += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+func syntaxError(pos int, format string, args ...any) error {
+	return fmt.Errorf("%w at offset %d: %s", ErrSyntax, pos, fmt.Sprintf(format, args...))
+}
+
+// parser is a recursive descent parser of expressions. In order of increasing
+// precedence, it parses `||`, `&&`, `!`, comparisons (including `in`), `+` and
+// `-`, `*`, `/` and `%`, unary `-`, and operands.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the operators or keywords.
+func (p *parser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOp && t.kind != tokenIdent {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.next()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return syntaxError(t.pos, "unexpected end of expression")
+	}
+	return syntaxError(t.pos, "unexpected %q", t.text)
+}
+
+func (p *parser) parseBinary(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseNot, "&&")
+}
+
+func (p *parser) parseNot() (node, error) {
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: "!", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=", "in")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return binary{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	return p.parseBinary(p.parseProduct, "+", "-")
+}
+
+func (p *parser) parseProduct() (node, error) {
+	return p.parseBinary(p.parseNegation, "*", "/", "%")
+}
+
+func (p *parser) parseNegation() (node, error) {
+	if _, ok := p.accept("-"); ok {
+		operand, err := p.parseNegation()
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: "-", operand: operand}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenNumber, tokenString:
+		p.next()
+		return literal{value: t.value}, nil
+	case tokenIdent:
+		p.next()
+		switch t.text {
+		case "true":
+			return literal{value: true}, nil
+		case "false":
+			return literal{value: false}, nil
+		case "null":
+			return literal{value: nil}, nil
+		}
+		if _, ok := functions[t.text]; ok {
+			if _, ok := p.accept("("); ok {
+				return p.parseCall(t.text)
+			}
+		}
+		return p.parseRef(t)
+	case tokenOp:
+		switch t.text {
+		case "(":
+			p.next()
+			n, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		case "[":
+			p.next()
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return list{items: items}, nil
+		}
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) parseRef(first token) (node, error) {
+	var path []string
+	if first.text != "self" {
+		path = append(path, first.text)
+	}
+	for {
+		if _, ok := p.accept("."); !ok {
+			return ref{path: path}, nil
+		}
+		t := p.peek()
+		if t.kind != tokenIdent {
+			return nil, p.unexpected()
+		}
+		p.next()
+		path = append(path, t.text)
+	}
+}
+
+func (p *parser) parseCall(name string) (node, error) {
+	args, err := p.parseList(")")
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != functions[name].arity {
+		return nil, syntaxError(p.tokens[p.pos-1].pos, "%s expects %d argument(s), got %d", name, functions[name].arity, len(args))
+	}
+	return call{name: name, args: args}, nil
+}
+
+// parseList parses comma separated expressions up to and including the
+// closing token.
+func (p *parser) parseList(closing string) ([]node, error) {
+	var items []node
+	if _, ok := p.accept(closing); ok {
+		return items, nil
+	}
+	for {
+		item, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if _, ok := p.accept(closing); ok {
+			return items, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorexpr
+
+import (
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+// Rule is an expression of this package with the message of its error, e.g.
+// `{"rule": "max >= min", "message": "max must not be less than min"}`.
+type Rule = valtor.ExpressionRule
+
+// Compile parses the expression of a rule into a valtor rule for values of
+// type T, so that fields of a struct or map can be checked against each other,
+// e.g. `max >= min` with ObjectSchema.Rule. A syntax error fails with
+// ErrSyntax. A value for which the expression is false fails with
+// rule.Failure(). A value for which it can't be evaluated, e.g. because a
+// number is compared to a string, fails with the evaluation error.
+func Compile[T any](rule Rule) (*valtor.Rule[T], error) {
+	expr, err := Parse(rule.Expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", rule.Expr, err)
+	}
+
+	return valtor.NewRule(func(value T) error {
+		ok, err := expr.Test(value)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate %q: %w", rule.Expr, err)
+		}
+		if !ok {
+			return rule.Failure()
+		}
+		return nil
+	}), nil
+}
+
+// MustCompile is like Compile, but panics on a syntax error.
+func MustCompile[T any](rule Rule) *valtor.Rule[T] {
+	r, err := Compile[T](rule)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
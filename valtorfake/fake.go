@@ -0,0 +1,523 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorfake generates random values that are valid (or deliberately
+// invalid) according to a JSON Schema, for property-based testing and
+// fixtures. Generated values have the types produced by encoding/json when
+// decoding into an `any` value, except that integers are int64. Invalid
+// numbers that a float64 can't represent exactly, e.g. just beyond a bound
+// above 2^53, are json.Number.
+package valtorfake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dstotijn/valtor/formats"
+	"github.com/dstotijn/valtor/valtorjsonschema"
+	"github.com/invopop/jsonschema"
+)
+
+var ErrUnsupported = errors.New("unsupported schema")
+
+const (
+	// maxAttempts is the number of values generated to find one that satisfies
+	// constraints that are not generated for directly, e.g. `maxLength` of a
+	// string generated from a `pattern`.
+	maxAttempts = 100
+	// extraLength and extraItems bound the length of strings and arrays without
+	// a maximum, beyond their minimum.
+	extraLength = 16
+	extraItems  = 5
+	// numberRange bounds numbers without a minimum or maximum.
+	numberRange = 1000
+)
+
+// Generator generates values for JSON Schemas.
+type Generator struct {
+	rand *rand.Rand
+}
+
+// New creates a new generator that uses r as its source of randomness. Use a
+// seeded source for reproducible values.
+func New(r *rand.Rand) *Generator {
+	return &Generator{rand: r}
+}
+
+// Valid generates a random value that is valid according to the schema.
+// Optional object properties are included at random. It fails with
+// ErrUnsupported for schemas without a type or with constraints that cannot be
+// satisfied, such as a format without a generator.
+func (g *Generator) Valid(schema jsonschema.Schema) (any, error) {
+	if len(schema.Enum) > 0 {
+		return schema.Enum[g.rand.IntN(len(schema.Enum))], nil
+	}
+
+	switch schema.Type {
+	case "null":
+		return nil, nil
+	case "boolean":
+		return g.rand.IntN(2) == 1, nil
+	case "integer":
+		min, max, err := intBounds(schema)
+		if err != nil {
+			return nil, err
+		}
+		// The difference is computed as uint64, as it can exceed the int64
+		// range, e.g. for a schema without bounds below and above zero.
+		span := uint64(max) - uint64(min)
+		if span == math.MaxUint64 {
+			return int64(g.rand.Uint64()), nil
+		}
+		return min + int64(g.rand.Uint64N(span+1)), nil
+	case "number":
+		min, max, err := numberBounds(schema)
+		if err != nil {
+			return nil, err
+		}
+		return min + g.rand.Float64()*(max-min), nil
+	case "string":
+		return g.validString(schema)
+	case "array":
+		return g.validArray(schema)
+	case "object":
+		return g.validObject(schema)
+	default:
+		return nil, fmt.Errorf("%w: type %q", ErrUnsupported, schema.Type)
+	}
+}
+
+func (g *Generator) validString(schema jsonschema.Schema) (string, error) {
+	minLength, maxLength := 0, -1
+	if schema.MinLength != nil {
+		minLength = int(*schema.MinLength)
+	}
+	if schema.MaxLength != nil {
+		maxLength = int(*schema.MaxLength)
+	}
+
+	// Patterns are ECMA-262 regular expressions, so they are translated to
+	// Go's regexp syntax first.
+	var pattern string
+	var re *regexp.Regexp
+	if schema.Pattern != "" {
+		var err error
+		pattern, err = valtorjsonschema.TranslatePattern(schema.Pattern)
+		if errors.Is(err, valtorjsonschema.ErrUnsupportedPattern) {
+			return "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+		}
+		if err == nil {
+			re, err = regexp.Compile(pattern)
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
+		}
+	}
+
+	var generateFn func() (string, error)
+	switch {
+	case schema.Format != "" && formatGenerators[schema.Format] != nil:
+		genFn := formatGenerators[schema.Format]
+		generateFn = func() (string, error) { return genFn(g.rand), nil }
+	case schema.Format != "" && isRegisteredFormat(schema.Format):
+		return "", fmt.Errorf("%w: format %q", ErrUnsupported, schema.Format)
+	case schema.Pattern != "":
+		generateFn = func() (string, error) { return g.pattern(pattern) }
+	default:
+		if maxLength < 0 {
+			maxLength = minLength + extraLength
+		}
+		if minLength > maxLength {
+			return "", fmt.Errorf("%w: `minLength` is greater than `maxLength`", ErrUnsupported)
+		}
+		return g.alphanumeric(minLength + g.rand.IntN(maxLength-minLength+1)), nil
+	}
+
+	for range maxAttempts {
+		s, err := generateFn()
+		if err != nil {
+			return "", err
+		}
+		n := len([]rune(s))
+		if n >= minLength && (maxLength < 0 || n <= maxLength) && (re == nil || re.MatchString(s)) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no string found that satisfies all constraints", ErrUnsupported)
+}
+
+func (g *Generator) validArray(schema jsonschema.Schema) ([]any, error) {
+	minItems := 0
+	if schema.MinItems != nil {
+		minItems = int(*schema.MinItems)
+	}
+	maxItems := minItems + extraItems
+	if schema.MaxItems != nil {
+		maxItems = int(*schema.MaxItems)
+	}
+	if minItems > maxItems {
+		return nil, fmt.Errorf("%w: `minItems` is greater than `maxItems`", ErrUnsupported)
+	}
+	n := minItems + g.rand.IntN(maxItems-minItems+1)
+
+	items := make([]any, 0, n)
+	seen := make(map[string]bool)
+	for attempts := 0; len(items) < n; attempts++ {
+		if attempts == maxAttempts*n {
+			return nil, fmt.Errorf("%w: no unique items found", ErrUnsupported)
+		}
+		// Arrays without an item schema can contain any value.
+		var item any = g.alphanumeric(8)
+		if schema.Items != nil {
+			var err error
+			if item, err = g.Valid(*schema.Items); err != nil {
+				return nil, fmt.Errorf("failed to generate item: %w", err)
+			}
+		}
+		if schema.UniqueItems {
+			key := encode(item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (g *Generator) validObject(schema jsonschema.Schema) (map[string]any, error) {
+	obj := make(map[string]any)
+	if schema.Properties == nil {
+		return obj, nil
+	}
+
+	minProperties := 0
+	if schema.MinProperties != nil {
+		minProperties = int(*schema.MinProperties)
+	}
+	maxProperties := -1
+	if schema.MaxProperties != nil {
+		maxProperties = int(*schema.MaxProperties)
+	}
+
+	var optional []string
+	for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value == nil {
+			continue
+		}
+		if !slices.Contains(schema.Required, pair.Key) {
+			optional = append(optional, pair.Key)
+			continue
+		}
+		value, err := g.Valid(*pair.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate property %q: %w", pair.Key, err)
+		}
+		obj[pair.Key] = value
+	}
+
+	g.rand.Shuffle(len(optional), func(i, j int) {
+		optional[i], optional[j] = optional[j], optional[i]
+	})
+	for _, key := range optional {
+		if maxProperties >= 0 && len(obj) >= maxProperties {
+			break
+		}
+		if len(obj) >= minProperties && g.rand.IntN(2) == 0 {
+			continue
+		}
+		propSchema, _ := schema.Properties.Get(key)
+		value, err := g.Valid(*propSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate property %q: %w", key, err)
+		}
+		obj[key] = value
+	}
+
+	if len(obj) < minProperties || (maxProperties >= 0 && len(obj) > maxProperties) {
+		return nil, fmt.Errorf("%w: number of properties cannot be satisfied", ErrUnsupported)
+	}
+	return obj, nil
+}
+
+// Invalid generates a random value that is invalid according to the schema,
+// by violating one of its constraints, chosen at random. A value of another
+// type is always a candidate. Use it to test that invalid input is rejected.
+func (g *Generator) Invalid(schema jsonschema.Schema) (any, error) {
+	candidates := []func() (any, error){
+		func() (any, error) { return g.wrongType(schema.Type), nil },
+	}
+
+	switch schema.Type {
+	case "integer", "number":
+		if min := schema.Minimum; min != "" {
+			candidates = append(candidates, func() (any, error) {
+				return beyond(schema.Type, min, -1)
+			})
+		}
+		if max := schema.Maximum; max != "" {
+			candidates = append(candidates, func() (any, error) {
+				return beyond(schema.Type, max, 1)
+			})
+		}
+		if schema.Type == "integer" && len(schema.Enum) == 0 {
+			candidates = append(candidates, func() (any, error) {
+				_, max, err := numberBounds(schema)
+				if err != nil {
+					return nil, err
+				}
+				v, err := g.Valid(schema)
+				if err != nil {
+					return nil, err
+				}
+				// A fraction, within the bounds if possible.
+				frac := new(big.Rat).SetFrac64(2*v.(int64)+1, 2)
+				if frac.Cmp(new(big.Rat).SetFloat64(max)) > 0 {
+					frac.Sub(frac, big.NewRat(1, 1))
+				}
+				return exact(frac), nil
+			})
+		}
+	case "string":
+		if schema.MinLength != nil && *schema.MinLength > 0 {
+			candidates = append(candidates, func() (any, error) {
+				return g.alphanumeric(int(*schema.MinLength) - 1), nil
+			})
+		}
+		if schema.MaxLength != nil {
+			candidates = append(candidates, func() (any, error) {
+				return g.alphanumeric(int(*schema.MaxLength) + 1), nil
+			})
+		}
+	case "array":
+		if schema.MinItems != nil && *schema.MinItems > 0 {
+			candidates = append(candidates, func() (any, error) {
+				return g.resize(schema, int(*schema.MinItems)-1)
+			})
+		}
+		if schema.MaxItems != nil {
+			candidates = append(candidates, func() (any, error) {
+				return g.resize(schema, int(*schema.MaxItems)+1)
+			})
+		}
+		if schema.Items != nil && (schema.MaxItems == nil || *schema.MaxItems > 0) {
+			candidates = append(candidates, func() (any, error) {
+				items, err := g.resize(schema, max(1, minItems(schema)))
+				if err != nil {
+					return nil, err
+				}
+				if items[0], err = g.Invalid(*schema.Items); err != nil {
+					return nil, err
+				}
+				return items, nil
+			})
+		}
+	case "object":
+		for _, key := range schema.Required {
+			candidates = append(candidates, func() (any, error) {
+				obj, err := g.validObject(schema)
+				if err != nil {
+					return nil, err
+				}
+				delete(obj, key)
+				return obj, nil
+			})
+		}
+		if schema.Properties != nil {
+			for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+				if pair.Value == nil {
+					continue
+				}
+				candidates = append(candidates, func() (any, error) {
+					obj, err := g.validObject(schema)
+					if err != nil {
+						return nil, err
+					}
+					if obj[pair.Key], err = g.Invalid(*pair.Value); err != nil {
+						return nil, err
+					}
+					return obj, nil
+				})
+			}
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		candidates = append(candidates, func() (any, error) {
+			return g.notInEnum(schema), nil
+		})
+	}
+
+	return candidates[g.rand.IntN(len(candidates))]()
+}
+
+// resize generates a valid array with n items.
+func (g *Generator) resize(schema jsonschema.Schema, n int) ([]any, error) {
+	itemsSchema := schema
+	count := uint64(n)
+	itemsSchema.MinItems, itemsSchema.MaxItems = &count, &count
+	// Items are allowed to repeat, so that arrays longer than the number of
+	// distinct items can be generated.
+	itemsSchema.UniqueItems = false
+	return g.validArray(itemsSchema)
+}
+
+func (g *Generator) wrongType(typ string) any {
+	switch typ {
+	case "string":
+		return int64(g.rand.IntN(numberRange))
+	default:
+		return g.alphanumeric(8)
+	}
+}
+
+func (g *Generator) notInEnum(schema jsonschema.Schema) any {
+	allowed := make(map[string]bool, len(schema.Enum))
+	for _, v := range schema.Enum {
+		allowed[encode(v)] = true
+	}
+	for {
+		var v any = g.alphanumeric(8)
+		if schema.Type == "integer" || schema.Type == "number" {
+			v = int64(g.rand.IntN(numberRange))
+		}
+		if !allowed[encode(v)] {
+			return v
+		}
+	}
+}
+
+func (g *Generator) alphanumeric(n int) string {
+	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	var sb strings.Builder
+	for range n {
+		sb.WriteByte(chars[g.rand.IntN(len(chars))])
+	}
+	return sb.String()
+}
+
+func intBounds(schema jsonschema.Schema) (int64, int64, error) {
+	min, max, err := numberBounds(schema)
+	if err != nil {
+		return 0, 0, err
+	}
+	lo, hi := math.Ceil(min), math.Floor(max)
+	if lo > hi {
+		return 0, 0, fmt.Errorf("%w: no integer between `minimum` and `maximum`", ErrUnsupported)
+	}
+	// Bounds outside the int64 range are clamped to it. As -2^63 is the lowest
+	// int64 and 2^63 is beyond the highest, a range that doesn't overlap it
+	// can't be generated.
+	if lo >= maxInt64 || hi < math.MinInt64 {
+		return 0, 0, fmt.Errorf("%w: no int64 between `minimum` and `maximum`", ErrUnsupported)
+	}
+	minInt, maxInt := int64(math.MinInt64), int64(math.MaxInt64)
+	if lo > math.MinInt64 {
+		minInt = int64(lo)
+	}
+	if hi < maxInt64 {
+		maxInt = int64(hi)
+	}
+	return minInt, maxInt, nil
+}
+
+// maxInt64 is 2^63, the lowest float64 above the int64 range.
+const maxInt64 = 1 << 63
+
+func numberBounds(schema jsonschema.Schema) (float64, float64, error) {
+	min, max := -float64(numberRange), float64(numberRange)
+	if schema.Minimum != "" {
+		f, err := schema.Minimum.Float64()
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid `minimum` value %q", schema.Minimum)
+		}
+		min = f
+		if schema.Maximum == "" {
+			max = f + numberRange
+		}
+	}
+	if schema.Maximum != "" {
+		f, err := schema.Maximum.Float64()
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid `maximum` value %q", schema.Maximum)
+		}
+		max = f
+		if schema.Minimum == "" {
+			min = f - numberRange
+		}
+	}
+	if min > max {
+		return 0, 0, fmt.Errorf("%w: `minimum` is greater than `maximum`", ErrUnsupported)
+	}
+	return min, max, nil
+}
+
+func minItems(schema jsonschema.Schema) int {
+	if schema.MinItems == nil {
+		return 0
+	}
+	return int(*schema.MinItems)
+}
+
+// beyond returns a number of the type just outside bound, below it for a step
+// of -1 and above it for a step of 1.
+func beyond(typ string, bound json.Number, step int64) (any, error) {
+	r, ok := new(big.Rat).SetString(bound.String())
+	if !ok {
+		return nil, fmt.Errorf("invalid bound %q", bound)
+	}
+	// The integer at or beyond the bound. As the denominator is positive,
+	// Euclidean division rounds down.
+	n := new(big.Int)
+	if step < 0 {
+		n.Div(r.Num(), r.Denom())
+	} else {
+		n.Neg(n.Div(new(big.Int).Neg(r.Num()), r.Denom()))
+	}
+	n.Add(n, big.NewInt(step))
+	if typ == "integer" && n.IsInt64() {
+		return n.Int64(), nil
+	}
+	return exact(new(big.Rat).SetInt(n)), nil
+}
+
+// exact returns r as float64 if it can be represented exactly, or else as
+// json.Number, e.g. for integers beyond 2^53 and fractions of them.
+func exact(r *big.Rat) any {
+	if f, exact := r.Float64(); exact {
+		return f
+	}
+	if r.IsInt() {
+		return json.Number(r.Num().String())
+	}
+	return json.Number(r.FloatString(1))
+}
+
+func encode(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func isRegisteredFormat(name string) bool {
+	_, ok := formats.Lookup(name)
+	return ok
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorfake
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/dstotijn/valtor/formats"
+	"github.com/dstotijn/valtor/valtorjsonschema"
+	"github.com/invopop/jsonschema"
+)
+
+func TestGenerator(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaJSON string
+	}{
+		{
+			name:       "string",
+			schemaJSON: `{"type": "string", "minLength": 2, "maxLength": 5}`,
+		},
+		{
+			name:       "string with pattern",
+			schemaJSON: `{"type": "string", "pattern": "^[A-Z]{2}-\\d{3,5}(-[a-z]+)?$", "maxLength": 12}`,
+		},
+		{
+			name:       "string with format",
+			schemaJSON: `{"type": "string", "format": "email"}`,
+		},
+		{
+			name:       "string enum",
+			schemaJSON: `{"type": "string", "enum": ["draft", "published"]}`,
+		},
+		{
+			name:       "integer",
+			schemaJSON: `{"type": "integer", "minimum": 1.5, "maximum": 3}`,
+		},
+		{
+			name:       "integer beyond int64",
+			schemaJSON: `{"type": "integer", "minimum": 0, "maximum": 18446744073709551615}`,
+		},
+		{
+			name:       "integer without bounds",
+			schemaJSON: `{"type": "integer", "minimum": -1e30, "maximum": 1e30}`,
+		},
+		{
+			name:       "number",
+			schemaJSON: `{"type": "number", "minimum": -1, "maximum": 1}`,
+		},
+		{
+			name:       "boolean",
+			schemaJSON: `{"type": "boolean"}`,
+		},
+		{
+			name:       "array",
+			schemaJSON: `{"type": "array", "items": {"type": "integer", "minimum": 0, "maximum": 9}, "minItems": 1, "maxItems": 4, "uniqueItems": true}`,
+		},
+		{
+			name: "object",
+			schemaJSON: `{
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "minLength": 1, "maxLength": 20},
+					"age": {"type": "integer", "minimum": 18},
+					"tags": {"type": "array", "items": {"type": "string", "format": "slug"}, "maxItems": 3}
+				},
+				"required": ["name", "age"]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsonSchema jsonschema.Schema
+			if err := json.Unmarshal([]byte(tt.schemaJSON), &jsonSchema); err != nil {
+				t.Fatalf("failed to unmarshal schema: %v", err)
+			}
+			valtorSchema, err := valtorjsonschema.ParseJSONSchema[any](jsonSchema)
+			if err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			g := New(rand.New(rand.NewPCG(1, 2)))
+			for range 200 {
+				value, err := g.Valid(jsonSchema)
+				if err != nil {
+					t.Fatalf("failed to generate valid value: %v", err)
+				}
+				if err := valtorSchema.Validate(value); err != nil {
+					t.Errorf("expected generated value %#v to be valid, got error: %v", value, err)
+				}
+
+				value, err = g.Invalid(jsonSchema)
+				if err != nil {
+					t.Fatalf("failed to generate invalid value: %v", err)
+				}
+				if err := valtorSchema.Validate(value); err == nil {
+					t.Errorf("expected generated value %#v to be invalid, got no error", value)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratorFormats(t *testing.T) {
+	g := New(rand.New(rand.NewPCG(1, 2)))
+	for name, genFn := range formatGenerators {
+		validateFn, ok := formats.Lookup(name)
+		if !ok && (name == "language-tag" || name == "locale") {
+			// Registered by valtorlocale, which is a separate module.
+			continue
+		}
+		if !ok {
+			t.Errorf("expected format %q to exist", name)
+			continue
+		}
+		for range 50 {
+			if s := genFn(g.rand); validateFn(s) != nil {
+				t.Errorf("expected generated %s %q to be valid, got error: %v", name, s, validateFn(s))
+			}
+		}
+	}
+}
+
+func TestGeneratorUnsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema jsonschema.Schema
+	}{
+		{
+			name:   "no type",
+			schema: jsonschema.Schema{},
+		},
+		{
+			name:   "impossible bounds",
+			schema: jsonschema.Schema{Type: "integer", Minimum: "1.2", Maximum: "1.8"},
+		},
+		{
+			name:   "minimum above int64",
+			schema: jsonschema.Schema{Type: "integer", Minimum: "1e30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(rand.New(rand.NewPCG(1, 2))).Valid(tt.schema)
+			if !errors.Is(err, ErrUnsupported) {
+				t.Errorf("expected error %q, got %v", ErrUnsupported, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorfake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"strings"
+)
+
+// formatGenerators generate values for the built-in formats of package
+// formats.
+var formatGenerators = map[string]func(r *rand.Rand) string{
+	"hostname": func(r *rand.Rand) string {
+		return word(r, 8) + "." + word(r, 3)
+	},
+	"e164": func(r *rand.Rand) string {
+		return "+" + string(rune('1'+r.IntN(9))) + digits(r, 4+r.IntN(10))
+	},
+	"semver": func(r *rand.Rand) string {
+		return fmt.Sprintf("%d.%d.%d", r.IntN(10), r.IntN(20), r.IntN(100))
+	},
+	"slug": func(r *rand.Rand) string {
+		return word(r, 5) + "-" + word(r, 5)
+	},
+	"ulid": func(r *rand.Rand) string {
+		const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+		return "0" + randomString(r, crockford, 25)
+	},
+	"base64": func(r *rand.Rand) string {
+		return base64.StdEncoding.EncodeToString([]byte(word(r, 1+r.IntN(16))))
+	},
+	"base64url": func(r *rand.Rand) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(word(r, 1+r.IntN(16))))
+	},
+	"hex": func(r *rand.Rand) string {
+		return randomString(r, "0123456789abcdef", 2*(1+r.IntN(16)))
+	},
+	"jwt": func(r *rand.Rand) string {
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"` + word(r, 8) + `"}`))
+		return "eyJhbGciOiJub25lIn0." + payload + "."
+	},
+	"uuid": func(r *rand.Rand) string {
+		const hex = "0123456789abcdef"
+		return randomString(r, hex, 8) + "-" + randomString(r, hex, 4) + "-4" + randomString(r, hex, 3) +
+			"-" + string(hex[8+r.IntN(4)]) + randomString(r, hex, 3) + "-" + randomString(r, hex, 12)
+	},
+	"email": func(r *rand.Rand) string {
+		return word(r, 8) + "@" + word(r, 8) + ".com"
+	},
+	"credit-card": func(r *rand.Rand) string {
+		number := "4" + digits(r, 14)
+		return number + luhnCheckDigit(number)
+	},
+	"iban": func(r *rand.Rand) string {
+		// A Dutch IBAN: country code, check digits, bank code and account.
+		bban := "ABNA" + digits(r, 10)
+		return "NL" + ibanCheckDigits("NL", bban) + bban
+	},
+	"bic": func(r *rand.Rand) string {
+		return strings.ToUpper(word(r, 4)) + "NL2A"
+	},
+	"iso3166-alpha2": func(r *rand.Rand) string {
+		codes := []string{"DE", "FR", "GB", "JP", "NL", "US"}
+		return codes[r.IntN(len(codes))]
+	},
+	"iso4217": func(r *rand.Rand) string {
+		codes := []string{"CHF", "EUR", "GBP", "JPY", "USD"}
+		return codes[r.IntN(len(codes))]
+	},
+	"hex-color": func(r *rand.Rand) string {
+		return "#" + randomString(r, "0123456789abcdef", 6)
+	},
+	"rgb-color": func(r *rand.Rand) string {
+		return fmt.Sprintf("rgb(%d, %d, %d)", r.IntN(256), r.IntN(256), r.IntN(256))
+	},
+	"language-tag": func(r *rand.Rand) string {
+		tags := []string{"de", "en-GB", "en-US", "fr-CA", "nl", "zh-Hant-TW"}
+		return tags[r.IntN(len(tags))]
+	},
+	"locale": func(r *rand.Rand) string {
+		locales := []string{"de_DE", "en_GB", "en_US.UTF-8", "fr_CA", "nl_NL"}
+		return locales[r.IntN(len(locales))]
+	},
+	"timezone": func(r *rand.Rand) string {
+		zones := []string{"America/New_York", "Asia/Tokyo", "Europe/Amsterdam", "UTC"}
+		return zones[r.IntN(len(zones))]
+	},
+}
+
+func word(r *rand.Rand, n int) string {
+	return randomString(r, "abcdefghijklmnopqrstuvwxyz", n)
+}
+
+func digits(r *rand.Rand, n int) string {
+	return randomString(r, "0123456789", n)
+}
+
+func randomString(r *rand.Rand, chars string, n int) string {
+	var sb strings.Builder
+	for range n {
+		sb.WriteByte(chars[r.IntN(len(chars))])
+	}
+	return sb.String()
+}
+
+// luhnCheckDigit returns the check digit to append to number.
+func luhnCheckDigit(number string) string {
+	var sum int
+	for i := range len(number) {
+		digit := int(number[len(number)-1-i] - '0')
+		// Double every second digit, starting with the last one, as the check
+		// digit will be appended.
+		if i%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return string(rune('0' + (10-sum%10)%10))
+}
+
+// ibanCheckDigits returns the check digits of an IBAN, as defined by ISO 13616.
+func ibanCheckDigits(country, bban string) string {
+	var numeric strings.Builder
+	for _, c := range bban + country + "00" {
+		if c >= 'A' && c <= 'Z' {
+			fmt.Fprintf(&numeric, "%d", c-'A'+10)
+		} else {
+			numeric.WriteRune(c)
+		}
+	}
+	n, _ := new(big.Int).SetString(numeric.String(), 10)
+	remainder := new(big.Int).Mod(n, big.NewInt(97)).Int64()
+	return fmt.Sprintf("%02d", 98-remainder)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorfake
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// maxRepeat bounds unbounded repetitions (e.g. `*` and `+`) in patterns.
+const maxRepeat = 3
+
+// pattern generates a random string that matches the regular expression.
+func (g *Generator) pattern(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	var sb strings.Builder
+	g.writeRegexp(&sb, re.Simplify())
+	return sb.String(), nil
+}
+
+func (g *Generator) writeRegexp(sb *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && g.rand.IntN(2) == 0 {
+				r = unicode.SimpleFold(r)
+			}
+			sb.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		sb.WriteRune(g.charClass(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		sb.WriteByte(byte(' ' + g.rand.IntN('~'-' '+1)))
+	case syntax.OpCapture:
+		g.writeRegexp(sb, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.writeRegexp(sb, sub)
+		}
+	case syntax.OpAlternate:
+		g.writeRegexp(sb, re.Sub[g.rand.IntN(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, maxRepeat
+		case syntax.OpPlus:
+			min, max = 1, maxRepeat
+		case syntax.OpQuest:
+			min, max = 0, 1
+		}
+		if max < 0 {
+			max = min + maxRepeat
+		}
+		for range min + g.rand.IntN(max-min+1) {
+			g.writeRegexp(sb, re.Sub[0])
+		}
+	}
+	// Empty matches, such as anchors and word boundaries, generate nothing.
+}
+
+// charClass returns a random rune from the ranges of a character class,
+// preferring printable ASCII characters.
+func (g *Generator) charClass(ranges []rune) rune {
+	var ascii []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := max(ranges[i], ' '); r <= min(ranges[i+1], '~'); r++ {
+			ascii = append(ascii, r)
+		}
+	}
+	if len(ascii) > 0 {
+		return ascii[g.rand.IntN(len(ascii))]
+	}
+
+	i := 2 * g.rand.IntN(len(ranges)/2)
+	return ranges[i] + g.rand.Int32N(ranges[i+1]-ranges[i]+1)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorfile validates file paths and file contents, e.g. of uploads
+// or of paths passed to CLI tools. Its validators can be added to schemas with
+// Custom, e.g. `valtor.String().Custom(valtorfile.ValidPath)`.
+package valtorfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidPath    = errors.New("invalid path")
+	ErrFileTooLarge   = errors.New("file is too large")
+	ErrExtension      = errors.New("file extension is not allowed")
+	ErrContentType    = errors.New("content type is not allowed")
+	ErrNotRegularFile = errors.New("not a regular file")
+)
+
+// sniffLen is the number of bytes that http.DetectContentType considers.
+const sniffLen = 512
+
+// ValidPath validates that s is a path as accepted by fs.FS implementations:
+// slash-separated, unrooted, and without `.`, `..` or empty elements (see
+// fs.ValidPath). It rejects paths that could escape the directory they are
+// resolved in, such as `../etc/passwd`, and paths with backslashes or NUL
+// characters, which some operating systems treat specially.
+func ValidPath(s string) error {
+	if !fs.ValidPath(s) || s == "." {
+		return fmt.Errorf("%w: %q", ErrInvalidPath, s)
+	}
+	if strings.ContainsAny(s, "\\\x00") {
+		return fmt.Errorf("%w: %q must not contain backslashes or NUL characters", ErrInvalidPath, s)
+	}
+	return nil
+}
+
+// Exists returns a validator that checks if the path names an existing file
+// or directory in fsys. The path must be valid (see ValidPath). Errors wrap
+// fs.ErrNotExist for missing files.
+func Exists(fsys fs.FS) func(string) error {
+	return func(name string) error {
+		if err := ValidPath(name); err != nil {
+			return err
+		}
+		if _, err := fs.Stat(fsys, name); err != nil {
+			return fmt.Errorf("file must exist: %w", err)
+		}
+		return nil
+	}
+}
+
+// MaxFileSize returns a validator that checks if the path names a regular file
+// in fsys of at most max bytes. Errors wrap ErrFileTooLarge for larger files,
+// and fs.ErrNotExist for missing files.
+func MaxFileSize(fsys fs.FS, max int64) func(string) error {
+	return func(name string) error {
+		if err := ValidPath(name); err != nil {
+			return err
+		}
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			return fmt.Errorf("file must exist: %w", err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%w: %q", ErrNotRegularFile, name)
+		}
+		if info.Size() > max {
+			return fmt.Errorf("%w: size must be at most %d bytes, got %d", ErrFileTooLarge, max, info.Size())
+		}
+		return nil
+	}
+}
+
+// AllowedExtensions returns a validator that checks if the path or file name
+// has one of the extensions, e.g. `.jpg` (the leading dot is optional).
+// Extensions are compared case-insensitively. Errors wrap ErrExtension.
+func AllowedExtensions(extensions ...string) func(string) error {
+	allowed := make([]string, len(extensions))
+	for i, ext := range extensions {
+		allowed[i] = "." + strings.ToLower(strings.TrimPrefix(ext, "."))
+	}
+	return func(name string) error {
+		ext := strings.ToLower(path.Ext(name))
+		if ext == "" || !slices.Contains(allowed, ext) {
+			return fmt.Errorf("%w: extension must be one of %s, got %q", ErrExtension, strings.Join(allowed, ", "), ext)
+		}
+		return nil
+	}
+}
+
+// ContentTypeSniff returns a validator that detects the content type of the
+// data read from r with http.DetectContentType, and checks if it is one of the
+// allowed media types, e.g. `image/png`, or of a wildcard type, e.g.
+// `image/*`. Unlike a client-supplied Content-Type header, the detected type
+// depends on the content. Errors wrap ErrContentType.
+//
+// Up to 512 bytes are read. If r implements io.Seeker, e.g. a
+// multipart.File, it is rewound afterwards, so that it can be read again.
+func ContentTypeSniff(allowed ...string) func(io.Reader) error {
+	return func(r io.Reader) error {
+		buf := make([]byte, sniffLen)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+		if seeker, ok := r.(io.Seeker); ok {
+			if _, err := seeker.Seek(int64(-n), io.SeekCurrent); err != nil {
+				return fmt.Errorf("failed to rewind content: %w", err)
+			}
+		}
+
+		contentType := http.DetectContentType(buf[:n])
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		for _, a := range allowed {
+			if a == mediaType {
+				return nil
+			}
+			if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: content type must be one of %s, got %q", ErrContentType, strings.Join(allowed, ", "), mediaType)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorfile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "uploads/avatar.png"},
+		{path: "README"},
+		{path: "", wantErr: true},
+		{path: ".", wantErr: true},
+		{path: "/etc/passwd", wantErr: true},
+		{path: "../secret", wantErr: true},
+		{path: "a/../../b", wantErr: true},
+		{path: "a//b", wantErr: true},
+		{path: "a/", wantErr: true},
+		{path: `a\..\b`, wantErr: true},
+		{path: "a\x00.png", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := ValidPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPath) {
+				t.Errorf("expected error %q, got %v", ErrInvalidPath, err)
+			}
+		})
+	}
+}
+
+func TestFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"small.txt":     {Data: []byte("hello")},
+		"large.bin":     {Data: make([]byte, 2048)},
+		"dir/photo.jpg": {Data: []byte("jpg")},
+	}
+
+	tests := []struct {
+		name      string
+		validator func(string) error
+		path      string
+		wantErr   error
+	}{
+		{name: "exists", validator: Exists(fsys), path: "small.txt"},
+		{name: "exists directory", validator: Exists(fsys), path: "dir"},
+		{name: "not exists", validator: Exists(fsys), path: "missing.txt", wantErr: fs.ErrNotExist},
+		{name: "exists invalid path", validator: Exists(fsys), path: "../small.txt", wantErr: ErrInvalidPath},
+		{name: "max size", validator: MaxFileSize(fsys, 1024), path: "small.txt"},
+		{name: "max size exceeded", validator: MaxFileSize(fsys, 1024), path: "large.bin", wantErr: ErrFileTooLarge},
+		{name: "max size directory", validator: MaxFileSize(fsys, 1024), path: "dir", wantErr: ErrNotRegularFile},
+		{name: "max size missing", validator: MaxFileSize(fsys, 1024), path: "missing.txt", wantErr: fs.ErrNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator(tt.path)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAllowedExtensions(t *testing.T) {
+	validate := AllowedExtensions(".jpg", "PNG")
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "photo.jpg"},
+		{name: "photos/PHOTO.JPG"},
+		{name: "logo.png"},
+		{name: "archive.tar.gz", wantErr: true},
+		{name: "jpg", wantErr: true},
+		{name: "photo.jpg.exe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrExtension) {
+				t.Errorf("expected error %q, got %v", ErrExtension, err)
+			}
+		})
+	}
+}
+
+func TestContentTypeSniff(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 600)...)
+
+	tests := []struct {
+		name    string
+		allowed []string
+		content io.Reader
+		wantErr bool
+	}{
+		{name: "exact", allowed: []string{"image/png"}, content: bytes.NewReader(png)},
+		{name: "wildcard", allowed: []string{"image/*"}, content: bytes.NewReader(png)},
+		{name: "text with parameters", allowed: []string{"text/plain"}, content: strings.NewReader("hello")},
+		{name: "not allowed", allowed: []string{"image/*"}, content: strings.NewReader("<html><body>hi</body></html>"), wantErr: true},
+		{name: "empty", allowed: []string{"image/png"}, content: strings.NewReader(""), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ContentTypeSniff(tt.allowed...)(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrContentType) {
+				t.Errorf("expected error %q, got %v", ErrContentType, err)
+			}
+		})
+	}
+}
+
+func TestContentTypeSniffRewinds(t *testing.T) {
+	r := bytes.NewReader([]byte("hello"))
+	if err := ContentTypeSniff("text/plain")(r); err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+	b, _ := io.ReadAll(r)
+	if string(b) != "hello" {
+		t.Errorf("expected reader to be rewound, got %q", b)
+	}
+}
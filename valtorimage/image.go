@@ -0,0 +1,163 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorimage validates image payloads, e.g. uploaded avatars, by
+// format, pixel dimensions, byte size and aspect ratio. Only the image header
+// is decoded (see image.DecodeConfig), so validation is cheap even for large
+// images.
+//
+// GIF, JPEG and PNG are supported. Other formats are supported once their
+// decoder is registered with image.RegisterFormat, e.g. by importing
+// golang.org/x/image/webp.
+package valtorimage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register the GIF format.
+	_ "image/jpeg" // Register the JPEG format.
+	_ "image/png"  // Register the PNG format.
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown image format")
+	ErrFormat        = errors.New("image format is not allowed")
+	ErrDimensions    = errors.New("image dimensions are too large")
+	ErrTooLarge      = errors.New("image is too large")
+	ErrAspectRatio   = errors.New("image aspect ratio is not allowed")
+)
+
+// ImageSchema represents a validation schema for encoded images. It implements
+// valtor.Validator for []byte.
+type ImageSchema struct {
+	formats         []string
+	maxWidth        int
+	maxHeight       int
+	maxBytes        int64
+	aspectWidth     int
+	aspectHeight    int
+	aspectTolerance float64
+}
+
+// Image creates a new validation schema for encoded images.
+func Image() *ImageSchema {
+	return &ImageSchema{}
+}
+
+// Formats sets the allowed formats, by the names they are registered with,
+// e.g. `png` and `jpeg`, and returns the schema for chaining. By default, all
+// registered formats are allowed.
+func (s *ImageSchema) Formats(names ...string) *ImageSchema {
+	s.formats = names
+	return s
+}
+
+// MaxDimensions sets the maximum width and height in pixels and returns the
+// schema for chaining. A maximum of 0 means no limit.
+func (s *ImageSchema) MaxDimensions(width, height int) *ImageSchema {
+	s.maxWidth = width
+	s.maxHeight = height
+	return s
+}
+
+// MaxBytes sets the maximum size of the encoded image in bytes and returns the
+// schema for chaining.
+func (s *ImageSchema) MaxBytes(n int64) *ImageSchema {
+	s.maxBytes = n
+	return s
+}
+
+// AspectRatio sets the required ratio of width to height, e.g. 16 and 9, and
+// returns the schema for chaining. The tolerance is the allowed relative
+// deviation, e.g. 0.01 for 1%, so that images that were scaled with rounding
+// are accepted.
+func (s *ImageSchema) AspectRatio(width, height int, tolerance float64) *ImageSchema {
+	s.aspectWidth = width
+	s.aspectHeight = height
+	s.aspectTolerance = tolerance
+	return s
+}
+
+// Validate validates the encoded image against the schema.
+func (s *ImageSchema) Validate(data []byte) error {
+	if s.maxBytes > 0 && int64(len(data)) > s.maxBytes {
+		return fmt.Errorf("%w: size must be at most %d bytes, got %d", ErrTooLarge, s.maxBytes, len(data))
+	}
+	return s.validateConfig(bytes.NewReader(data))
+}
+
+// ValidateReader validates the encoded image read from r against the schema.
+// Only the header is read, unless a maximum size is set, in which case up to
+// that many bytes (plus one) are read to measure the size.
+func (s *ImageSchema) ValidateReader(r io.Reader) error {
+	cr := &countingReader{r: r}
+	if err := s.validateConfig(cr); err != nil {
+		return err
+	}
+	if s.maxBytes <= 0 {
+		return nil
+	}
+	if cr.n <= s.maxBytes {
+		if _, err := io.Copy(io.Discard, io.LimitReader(cr, s.maxBytes+1-cr.n)); err != nil {
+			return fmt.Errorf("failed to read image: %w", err)
+		}
+	}
+	if cr.n > s.maxBytes {
+		return fmt.Errorf("%w: size must be at most %d bytes", ErrTooLarge, s.maxBytes)
+	}
+	return nil
+}
+
+func (s *ImageSchema) validateConfig(r io.Reader) error {
+	cfg, format, err := image.DecodeConfig(r)
+	if errors.Is(err, image.ErrFormat) {
+		return ErrUnknownFormat
+	}
+	if err != nil {
+		return fmt.Errorf("invalid image: %w", err)
+	}
+
+	if len(s.formats) > 0 && !slices.Contains(s.formats, format) {
+		return fmt.Errorf("%w: format must be one of %s, got %q", ErrFormat, strings.Join(s.formats, ", "), format)
+	}
+	if (s.maxWidth > 0 && cfg.Width > s.maxWidth) || (s.maxHeight > 0 && cfg.Height > s.maxHeight) {
+		return fmt.Errorf("%w: dimensions must be at most %dx%d, got %dx%d", ErrDimensions, s.maxWidth, s.maxHeight, cfg.Width, cfg.Height)
+	}
+	if s.aspectWidth > 0 && s.aspectHeight > 0 {
+		want := float64(s.aspectWidth) / float64(s.aspectHeight)
+		got := float64(cfg.Width) / float64(cfg.Height)
+		if cfg.Height == 0 || math.Abs(got-want)/want > s.aspectTolerance {
+			return fmt.Errorf("%w: aspect ratio must be %d:%d, got %dx%d", ErrAspectRatio, s.aspectWidth, s.aspectHeight, cfg.Width, cfg.Height)
+		}
+	}
+	return nil
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
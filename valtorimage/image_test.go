@@ -0,0 +1,191 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorimage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestImageSchema(t *testing.T) {
+	png := encodePNG(t, 160, 90)
+	jpeg := encodeJPEG(t, 100, 100)
+
+	tests := []struct {
+		name    string
+		schema  *ImageSchema
+		data    []byte
+		wantErr error
+	}{
+		{
+			name:   "any format",
+			schema: Image(),
+			data:   jpeg,
+		},
+		{
+			name:   "allowed format",
+			schema: Image().Formats("png", "gif"),
+			data:   png,
+		},
+		{
+			name:    "format not allowed",
+			schema:  Image().Formats("png"),
+			data:    jpeg,
+			wantErr: ErrFormat,
+		},
+		{
+			name:    "unknown format",
+			schema:  Image(),
+			data:    []byte("not an image"),
+			wantErr: ErrUnknownFormat,
+		},
+		{
+			name:   "within dimensions",
+			schema: Image().MaxDimensions(160, 90),
+			data:   png,
+		},
+		{
+			name:    "too wide",
+			schema:  Image().MaxDimensions(100, 0),
+			data:    png,
+			wantErr: ErrDimensions,
+		},
+		{
+			name:    "too high",
+			schema:  Image().MaxDimensions(0, 80),
+			data:    png,
+			wantErr: ErrDimensions,
+		},
+		{
+			name:   "within max bytes",
+			schema: Image().MaxBytes(int64(len(png))),
+			data:   png,
+		},
+		{
+			name:    "too many bytes",
+			schema:  Image().MaxBytes(int64(len(png) - 1)),
+			data:    png,
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:   "aspect ratio",
+			schema: Image().AspectRatio(16, 9, 0),
+			data:   png,
+		},
+		{
+			name:   "aspect ratio within tolerance",
+			schema: Image().AspectRatio(16, 9, 0.01),
+			data:   encodePNG(t, 161, 90),
+		},
+		{
+			name:    "aspect ratio not allowed",
+			schema:  Image().AspectRatio(16, 9, 0.01),
+			data:    jpeg,
+			wantErr: ErrAspectRatio,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, validate := range []func([]byte) error{
+				tt.schema.Validate,
+				func(data []byte) error { return tt.schema.ValidateReader(bytes.NewReader(data)) },
+			} {
+				err := validate(tt.data)
+				if tt.wantErr == nil && err != nil {
+					t.Errorf("expected no error, got %q", err)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %q, got %v", tt.wantErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPack(t *testing.T) {
+	registry := valtor.NewExtensionRegistry()
+	if err := registry.Use(Pack{}); err != nil {
+		t.Fatalf("failed to register pack: %v", err)
+	}
+	ext, ok := registry.Lookup("x-image")
+	if !ok {
+		t.Fatal("expected extension x-image to be registered")
+	}
+
+	png := encodePNG(t, 160, 90)
+
+	tests := []struct {
+		name     string
+		params   string
+		value    any
+		wantErr  error
+		parseErr bool
+	}{
+		{name: "bytes", params: `{"formats": ["png"], "maxWidth": 160, "maxHeight": 90}`, value: png},
+		{name: "base64", params: `{"aspectRatio": [16, 9]}`, value: base64.StdEncoding.EncodeToString(png)},
+		{name: "too large", params: `{"maxWidth": 100}`, value: png, wantErr: ErrDimensions},
+		{name: "aspect ratio", params: `{"aspectRatio": [1, 1, 0.1]}`, value: png, wantErr: ErrAspectRatio},
+		{name: "not base64", params: `{}`, value: "not base64!", wantErr: base64.CorruptInputError(3)},
+		{name: "other type", params: `{}`, value: 42},
+		{name: "invalid aspect ratio", params: `{"aspectRatio": [16]}`, parseErr: true},
+		{name: "invalid params", params: `true`, parseErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateFn, err := ext.Parse(json.RawMessage(tt.params))
+			if (err != nil) != tt.parseErr {
+				t.Fatalf("expected parse error: %v, got %v", tt.parseErr, err)
+			}
+			if err != nil {
+				return
+			}
+			err = validateFn(tt.value)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorimage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/valtor"
+)
+
+// Pack publishes the rules of this package as valtor extensions, so that they
+// can be used by name, e.g. as JSON Schema extension keywords, once registered
+// with valtor.Use. The `x-image` rule validates []byte values, and string
+// values that are base64-encoded as in JSON, with an ImageSchema. Its
+// parameters are an object with the optional members `formats`, `maxWidth`,
+// `maxHeight`, `maxBytes` and `aspectRatio` (e.g. `[16, 9, 0.01]`).
+type Pack struct{}
+
+// packParams are the parameters of the `x-image` rule.
+type packParams struct {
+	Formats     []string  `json:"formats"`
+	MaxWidth    int       `json:"maxWidth"`
+	MaxHeight   int       `json:"maxHeight"`
+	MaxBytes    int64     `json:"maxBytes"`
+	AspectRatio []float64 `json:"aspectRatio"`
+}
+
+func (Pack) Extensions() []valtor.Extension {
+	return []valtor.Extension{{
+		Name: "x-image",
+		Parse: func(params json.RawMessage) (func(any) error, error) {
+			var p packParams
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, fmt.Errorf("expected object: %w", err)
+			}
+			schema := Image().MaxDimensions(p.MaxWidth, p.MaxHeight).MaxBytes(p.MaxBytes)
+			if len(p.Formats) > 0 {
+				schema.Formats(p.Formats...)
+			}
+			switch len(p.AspectRatio) {
+			case 0:
+			case 2, 3:
+				tolerance := 0.0
+				if len(p.AspectRatio) == 3 {
+					tolerance = p.AspectRatio[2]
+				}
+				schema.AspectRatio(int(p.AspectRatio[0]), int(p.AspectRatio[1]), tolerance)
+			default:
+				return nil, fmt.Errorf("expected `aspectRatio` of width, height and optional tolerance, got %v", p.AspectRatio)
+			}
+
+			return func(value any) error {
+				switch v := value.(type) {
+				case []byte:
+					return schema.Validate(v)
+				case string:
+					data, err := base64.StdEncoding.DecodeString(v)
+					if err != nil {
+						return fmt.Errorf("failed to decode base64 image: %w", err)
+					}
+					return schema.Validate(data)
+				default:
+					return nil
+				}
+			}, nil
+		},
+	}}
+}
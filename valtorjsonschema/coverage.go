@@ -0,0 +1,134 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedKeyword is returned in strict mode (see WithStrict) for
+// keywords that are not enforced by the parsed schema.
+var ErrUnsupportedKeyword = errors.New("unsupported keyword")
+
+// UnsupportedKeyword is a keyword of a JSON Schema that is not enforced by the
+// parsed schema.
+type UnsupportedKeyword struct {
+	// Path is the JSON Pointer of the (sub)schema that contains the keyword,
+	// e.g. `/properties/tags/items`. It is empty for the root schema. For
+	// schemas in other documents (see WithLoader), it is prefixed with the URI
+	// of the document and `#`.
+	Path    string
+	Keyword string
+}
+
+func (k UnsupportedKeyword) String() string {
+	return fmt.Sprintf("%s#%s", k.Keyword, k.Path)
+}
+
+// Report lists the keywords that were encountered while parsing a JSON Schema,
+// but are not enforced by the parsed schema (see WithReport).
+type Report struct {
+	Unsupported []UnsupportedKeyword
+}
+
+// Complete reports whether all keywords of the schema are enforced.
+func (r *Report) Complete() bool {
+	return len(r.Unsupported) == 0
+}
+
+// WithReport fills report with the keywords that are not enforced by the
+// parsed schema, instead of silently ignoring them. These are keywords such as
+// `$dynamicRef`, `allOf` and `const`, formats that are not in the format registry,
+// extension keywords without a registered extension and patterns that are
+// ignored with PatternLenient. Annotations, such as `title` and `examples`,
+// are not reported.
+//
+// Keywords that the parser doesn't know, such as `unevaluatedProperties`, are
+// reported as extension keywords.
+func WithReport(report *Report) Option {
+	return func(cfg *config) {
+		cfg.report = report
+	}
+}
+
+// WithStrict makes parsing fail with ErrUnsupportedKeyword on the first
+// keyword that would not be enforced by the parsed schema (see WithReport).
+func WithStrict() Option {
+	return func(cfg *config) {
+		cfg.strict = true
+	}
+}
+
+// unsupported records keyword of the schema at path as unsupported. It returns
+// an error in strict mode.
+func (cfg *config) unsupported(path, keyword string) error {
+	if cfg.report != nil {
+		cfg.report.Unsupported = append(cfg.report.Unsupported, UnsupportedKeyword{
+			Path:    path,
+			Keyword: keyword,
+		})
+	}
+	if cfg.strict {
+		return fmt.Errorf("%w `%s` at %q", ErrUnsupportedKeyword, keyword, path)
+	}
+	return nil
+}
+
+// checkKeywords records the keywords of the schema at path that are never
+// enforced, regardless of its type. Keywords that depend on the type, such as
+// `format`, are checked by parseType.
+func (cfg *config) checkKeywords(schema schemaNode, path string) error {
+	keywords := []struct {
+		name    string
+		present bool
+	}{
+		{"$dynamicRef", schema.DynamicRef != ""},
+		{"allOf", len(schema.AllOf) > 0},
+		{"anyOf", len(schema.AnyOf) > 0},
+		{"oneOf", len(schema.OneOf) > 0},
+		{"not", schema.Not != nil},
+		{"if", schema.If != nil},
+		{"then", schema.Then != nil},
+		{"else", schema.Else != nil},
+		{"dependentSchemas", len(schema.DependentSchemas) > 0},
+		{"prefixItems", len(schema.PrefixItems) > 0},
+		{"contains", schema.Contains != nil},
+		{"additionalProperties", schema.AdditionalProperties != nil &&
+			!isFalseSchema(schema.AdditionalProperties) && !isTrueSchema(schema.AdditionalProperties)},
+		{"const", len(schema.Const) > 0},
+		{"multipleOf", schema.MultipleOf != ""},
+		{"exclusiveMaximum", schema.ExclusiveMaximum != ""},
+		{"exclusiveMinimum", schema.ExclusiveMinimum != ""},
+		{"maxContains", schema.MaxContains != nil},
+		{"minContains", schema.MinContains != nil},
+		{"dependentRequired", len(schema.DependentRequired) > 0},
+	}
+	for _, kw := range keywords {
+		if !kw.present {
+			continue
+		}
+		if err := cfg.unsupported(path, kw.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapePointer escapes a reference token of a JSON Pointer (RFC 6901).
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
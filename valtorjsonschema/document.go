@@ -0,0 +1,247 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+	"github.com/dstotijn/valtor/formats"
+	"github.com/invopop/jsonschema"
+)
+
+// ParseJSONSchemaBytes parses a JSON Schema document into a validation schema
+// for type T. It is like ParseJSONSchema, but without the need to construct a
+// jsonschema.Schema: the document is decoded into a minimal internal model,
+// which keeps the order of `properties`, accepts type arrays such as
+// `"type": ["string", "null"]` and keeps the keywords it doesn't know, so that
+// extensions are applied to them and WithReport lists them when they are not
+// supported.
+func ParseJSONSchemaBytes[T any](data []byte, opts ...Option) (*valtor.Schema[T], error) {
+	schema, err := decodeSchema(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config{
+		ctx:        context.Background(),
+		formats:    formats.Default,
+		extensions: valtor.DefaultExtensions,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	cfg.refs = newRefs(data, schema.ID)
+	return parseJSONSchema[T](schema, cfg, "")
+}
+
+// UnmarshalJSONSchema decodes a JSON Schema document. Unlike decoding into a
+// jsonschema.Schema directly, it keeps the keywords that jsonschema.Schema has
+// no field for, such as `unevaluatedProperties` and extension keywords, in the
+// `Extras` of the (sub)schema, so that extensions are applied to them and
+// WithReport lists them when they are not supported.
+//
+// It also accepts type arrays such as `"type": ["string", "null"]`, which
+// jsonschema.Schema can't represent. A type array is decoded as `anyOf` with a
+// schema per type that only has a `type`, which ParseJSONSchema parses as a
+// union of the types.
+func UnmarshalJSONSchema(data []byte) (jsonschema.Schema, error) {
+	var schema jsonschema.Schema
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return schema, err
+	}
+	if err := rewriteTypeArrays(doc); err != nil {
+		return schema, err
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return schema, err
+	}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		return schema, err
+	}
+	setExtras(doc, &schema)
+
+	return schema, nil
+}
+
+// knownKeywords are the keywords that jsonschema.Schema has a field for.
+var knownKeywords = func() map[string]bool {
+	keywords := make(map[string]bool)
+	t := reflect.TypeFor[jsonschema.Schema]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keywords[name] = true
+		}
+	}
+	return keywords
+}()
+
+// ignoredKeywords are keywords that jsonschema.Schema has no field for, but
+// that don't affect validation, so they are not kept in `Extras`.
+var ignoredKeywords = map[string]bool{
+	"$vocabulary":      true,
+	"$dynamicAnchor":   true,
+	"$recursiveAnchor": true,
+	"definitions":      true,
+}
+
+// Keywords of which the value is a subschema, a map of subschemas or an array
+// of subschemas.
+var (
+	subschemaKeywords = []string{
+		"items", "additionalItems", "additionalProperties", "propertyNames", "not", "if", "then", "else",
+		"contains", "contentSchema", "unevaluatedItems", "unevaluatedProperties",
+	}
+	subschemaMapKeywords   = []string{"properties", "patternProperties", "$defs", "definitions", "dependentSchemas"}
+	subschemaArrayKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+)
+
+// rewriteTypeArrays replaces the type arrays of a decoded schema and its
+// subschemas with `anyOf` (see UnmarshalJSONSchema).
+func rewriteTypeArrays(doc any) error {
+	schema, ok := doc.(map[string]any)
+	if !ok {
+		// Boolean schema.
+		return nil
+	}
+
+	if types, ok := schema["type"].([]any); ok {
+		if len(types) == 1 {
+			schema["type"] = types[0]
+		} else {
+			if _, ok := schema["anyOf"]; ok {
+				return fmt.Errorf("%w: `type` array with `anyOf` is not supported", ErrInvalidType)
+			}
+			branches := make([]any, len(types))
+			for i, t := range types {
+				branches[i] = map[string]any{"type": t}
+			}
+			delete(schema, "type")
+			schema["anyOf"] = branches
+		}
+	}
+
+	for _, keyword := range subschemaKeywords {
+		// Before draft 2020-12, `items` could also be an array of subschemas.
+		if subschemas, ok := schema[keyword].([]any); ok {
+			for _, subschema := range subschemas {
+				if err := rewriteTypeArrays(subschema); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if subschema, ok := schema[keyword]; ok {
+			if err := rewriteTypeArrays(subschema); err != nil {
+				return err
+			}
+		}
+	}
+	for _, keyword := range subschemaMapKeywords {
+		subschemas, _ := schema[keyword].(map[string]any)
+		for _, subschema := range subschemas {
+			if err := rewriteTypeArrays(subschema); err != nil {
+				return err
+			}
+		}
+	}
+	for _, keyword := range subschemaArrayKeywords {
+		subschemas, _ := schema[keyword].([]any)
+		for _, subschema := range subschemas {
+			if err := rewriteTypeArrays(subschema); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setExtras sets the `Extras` of a schema and its subschemas to the keywords
+// of the decoded schema that jsonschema.Schema has no field for.
+func setExtras(doc any, schema *jsonschema.Schema) {
+	m, ok := doc.(map[string]any)
+	if !ok || schema == nil {
+		// Boolean schema.
+		return
+	}
+
+	for keyword, value := range m {
+		if knownKeywords[keyword] || ignoredKeywords[keyword] {
+			continue
+		}
+		if schema.Extras == nil {
+			schema.Extras = make(map[string]any)
+		}
+		schema.Extras[keyword] = value
+	}
+
+	subschemas := map[string]*jsonschema.Schema{
+		"items":                schema.Items,
+		"additionalProperties": schema.AdditionalProperties,
+		"propertyNames":        schema.PropertyNames,
+		"not":                  schema.Not,
+		"if":                   schema.If,
+		"then":                 schema.Then,
+		"else":                 schema.Else,
+		"contains":             schema.Contains,
+		"contentSchema":        schema.ContentSchema,
+	}
+	for keyword, subschema := range subschemas {
+		setExtras(m[keyword], subschema)
+	}
+
+	arrays := map[string][]*jsonschema.Schema{
+		"allOf":       schema.AllOf,
+		"anyOf":       schema.AnyOf,
+		"oneOf":       schema.OneOf,
+		"prefixItems": schema.PrefixItems,
+	}
+	for keyword, subschemas := range arrays {
+		docs, _ := m[keyword].([]any)
+		for i := range min(len(docs), len(subschemas)) {
+			setExtras(docs[i], subschemas[i])
+		}
+	}
+
+	named := map[string]map[string]*jsonschema.Schema{
+		"patternProperties": schema.PatternProperties,
+		"$defs":             schema.Definitions,
+		"dependentSchemas":  schema.DependentSchemas,
+	}
+	for keyword, subschemas := range named {
+		docs, _ := m[keyword].(map[string]any)
+		for key, subschema := range subschemas {
+			setExtras(docs[key], subschema)
+		}
+	}
+	if schema.Properties != nil {
+		docs, _ := m["properties"].(map[string]any)
+		for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			setExtras(docs[pair.Key], pair.Value)
+		}
+	}
+}
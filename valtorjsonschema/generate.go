@@ -0,0 +1,410 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"iter"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dstotijn/valtor/formats"
+	"github.com/invopop/jsonschema"
+)
+
+// commonInitialisms are name parts that are uppercased in generated Go names,
+// e.g. `user_id` becomes `UserID`.
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+	"JSON": true, "SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+type generator struct {
+	cfg     *config
+	decls   []string // Type and constructor declarations, per object.
+	names   map[string]bool
+	regexps bool
+}
+
+// Generate writes Go source code for package pkg to w, with a struct type named
+// typeName for an object schema and a `New<typeName>Schema` function that
+// constructs the equivalent valtor schema. Nested object schemas become struct
+// types named after their parent type and property. Properties are pointer
+// fields, so that a missing property is told apart from a zero value, and
+// required properties are validated with valtor.FieldOf and a required
+// valtor.Ptr schema.
+//
+// Supported are the types `string`, `integer` (as int64), `number` (as
+// float64), `boolean`, `array` (with `items`) and `object` (with
+// `properties`), and the validation keywords that ParseJSONSchema supports for
+// those types, except `uniqueItems`, `additionalProperties` and
+// `patternProperties`. Formats that are not in the registry are ignored, and
+// formats that are only in a registry set with WithFormats fail, as the
+// generated code looks formats up in formats.Default.
+//
+// An `enum` of strings or integers becomes a named type with a constant per
+// value, e.g. `UserStatusActive` for the value `active` of property `status`,
+// which the schema allows with OneOf. Fields keep the underlying type, so that
+// they are validated by valtor.String and valtor.Number.
+func Generate(w io.Writer, pkg, typeName string, schema jsonschema.Schema, opts ...Option) error {
+	cfg := &config{
+		formats: formats.Default,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if schema.Type != "object" {
+		return fmt.Errorf("%w: expected `object` schema, got %q", ErrInvalidType, schema.Type)
+	}
+
+	g := &generator{
+		cfg:   cfg,
+		names: make(map[string]bool),
+	}
+	if err := g.object(typeName, schema); err != nil {
+		return err
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by jsonschemagen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	src.WriteString("import (\n")
+	if g.regexps {
+		src.WriteString("\t\"regexp\"\n\n")
+	}
+	src.WriteString("\t\"github.com/dstotijn/valtor\"\n)\n\n")
+	for _, decl := range g.decls {
+		src.WriteString(decl)
+	}
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+func (g *generator) object(typeName string, schema jsonschema.Schema) error {
+	if g.names[typeName] {
+		return fmt.Errorf("duplicate type name %q", typeName)
+	}
+	g.names[typeName] = true
+
+	// Reserve a slot, so that the declarations of nested objects follow the
+	// declaration of their parent.
+	i := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	var (
+		fields     bytes.Buffer
+		validators bytes.Buffer
+	)
+	for key, propSchema := range properties(schema) {
+		fieldName := goName(key)
+		if fieldName == "" {
+			return fmt.Errorf("invalid property name %q", key)
+		}
+
+		goType, schemaExpr, err := g.value(typeName+fieldName, *propSchema)
+		if err != nil {
+			return fmt.Errorf("invalid schema for property %q: %w", key, err)
+		}
+
+		writeComment(&fields, "\t", propSchema.Description)
+		fmt.Fprintf(&fields, "\t%s *%s `json:%q`\n", fieldName, goType, key+",omitempty")
+
+		ptrExpr := "valtor.Ptr(" + schemaExpr + ")"
+		if slices.Contains(schema.Required, key) {
+			ptrExpr += ".Required()"
+		}
+		fmt.Fprintf(&validators, "\tvaltor.FieldOf(schema, %q, func(v %s) *%s { return v.%s }, %s)\n",
+			key, typeName, goType, fieldName, ptrExpr)
+	}
+
+	var decl bytes.Buffer
+
+	comment := schema.Description
+	if comment == "" {
+		comment = typeName + " is generated from a JSON Schema."
+	}
+	writeComment(&decl, "", comment)
+	fmt.Fprintf(&decl, "type %s struct {\n%s}\n\n", typeName, fields.String())
+
+	fmt.Fprintf(&decl, "// New%sSchema creates a validation schema for %s.\n", typeName, typeName)
+	fmt.Fprintf(&decl, "func New%sSchema() *valtor.ObjectSchema[%s] {\n", typeName, typeName)
+	if validators.Len() == 0 {
+		fmt.Fprintf(&decl, "\treturn valtor.Object[%s]()\n}\n\n", typeName)
+	} else {
+		fmt.Fprintf(&decl, "\tschema := valtor.Object[%s]()\n%s\treturn schema\n}\n\n", typeName, validators.String())
+	}
+
+	g.decls[i] = decl.String()
+	return nil
+}
+
+// value returns the Go type and the expression of the valtor schema for a
+// value schema. Nested object types are named typeName.
+func (g *generator) value(typeName string, schema jsonschema.Schema) (string, string, error) {
+	var expr strings.Builder
+
+	switch schema.Type {
+	case "string":
+		// JSON Schema string lengths are measured in Unicode code points.
+		expr.WriteString("valtor.String().LengthMode(valtor.LengthRunes)")
+		if schema.MinLength != nil {
+			fmt.Fprintf(&expr, ".Min(%d)", *schema.MinLength)
+		}
+		if schema.MaxLength != nil {
+			fmt.Fprintf(&expr, ".Max(%d)", *schema.MaxLength)
+		}
+		if schema.Pattern != "" {
+			re, err := g.cfg.compilePattern(schema.Pattern)
+			if err != nil {
+				return "", "", fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
+			}
+			if re != nil {
+				g.regexps = true
+				fmt.Fprintf(&expr, ".Regexp(regexp.MustCompile(%s))", strconv.Quote(re.String()))
+			}
+		}
+		if schema.Format != "" {
+			// The generated code looks formats up in formats.Default.
+			if _, ok := formats.Default.Lookup(schema.Format); ok {
+				fmt.Fprintf(&expr, ".Format(%q)", schema.Format)
+			} else if _, ok := g.cfg.formats.Lookup(schema.Format); ok {
+				return "", "", fmt.Errorf("format %q is not in formats.Default", schema.Format)
+			}
+		}
+		if len(schema.Enum) > 0 {
+			values, err := g.enum(typeName, "string", schema.Enum)
+			if err != nil {
+				return "", "", err
+			}
+			fmt.Fprintf(&expr, ".OneOf(%s)", strings.Join(values, ", "))
+		}
+		return "string", expr.String(), nil
+	case "integer", "number":
+		goType := "float64"
+		if schema.Type == "integer" {
+			goType = "int64"
+		}
+		fmt.Fprintf(&expr, "valtor.Number[%s]()", goType)
+		if min := schema.Minimum; min != "" {
+			literal, err := numberLiteral(goType, min, true)
+			if err != nil {
+				return "", "", fmt.Errorf("invalid `minimum` value %q: %w", min, err)
+			}
+			fmt.Fprintf(&expr, ".Min(%s)", literal)
+		}
+		if max := schema.Maximum; max != "" {
+			literal, err := numberLiteral(goType, max, false)
+			if err != nil {
+				return "", "", fmt.Errorf("invalid `maximum` value %q: %w", max, err)
+			}
+			fmt.Fprintf(&expr, ".Max(%s)", literal)
+		}
+		if len(schema.Enum) > 0 && goType == "int64" {
+			values, err := g.enum(typeName, goType, schema.Enum)
+			if err != nil {
+				return "", "", err
+			}
+			fmt.Fprintf(&expr, ".OneOf(%s)", strings.Join(values, ", "))
+		} else if len(schema.Enum) > 0 {
+			values := make([]string, 0, len(schema.Enum))
+			for _, v := range schema.Enum {
+				f, ok := toFloat64(v)
+				if !ok {
+					return "", "", fmt.Errorf("unsupported `enum` value %v for %s", v, schema.Type)
+				}
+				values = append(values, strconv.FormatFloat(f, 'g', -1, 64))
+			}
+			fmt.Fprintf(&expr, ".OneOf(%s)", strings.Join(values, ", "))
+		}
+		return goType, expr.String(), nil
+	case "boolean":
+		return "bool", "valtor.Bool()", nil
+	case "array":
+		if schema.Items == nil {
+			return "", "", fmt.Errorf("%w: `array` without `items` is not supported", ErrInvalidType)
+		}
+		itemType, itemExpr, err := g.value(typeName+"Item", *schema.Items)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid item schema: %w", err)
+		}
+		fmt.Fprintf(&expr, "valtor.Array[%s]().ItemsOf(%s)", itemType, itemExpr)
+		if schema.MinItems != nil {
+			fmt.Fprintf(&expr, ".Min(%d)", *schema.MinItems)
+		}
+		if schema.MaxItems != nil {
+			fmt.Fprintf(&expr, ".Max(%d)", *schema.MaxItems)
+		}
+		return "[]" + itemType, expr.String(), nil
+	case "object":
+		if err := g.object(typeName, schema); err != nil {
+			return "", "", err
+		}
+		return typeName, "New" + typeName + "Schema()", nil
+	default:
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidType, schema.Type)
+	}
+}
+
+// numberLiteral returns the Go literal of a bound of goType. Integer bounds are
+// rounded up for a minimum and down for a maximum, exactly, and must fit in an
+// int64. Float bounds keep their JSON text, which is a valid Go literal.
+func numberLiteral(goType string, n json.Number, min bool) (string, error) {
+	if goType != "int64" {
+		if _, err := strconv.ParseFloat(n.String(), 64); err != nil {
+			return "", err
+		}
+		return n.String(), nil
+	}
+
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok {
+		return "", errors.New("not a number")
+	}
+	// As the denominator is positive, Euclidean division rounds down.
+	i := new(big.Int)
+	if min {
+		i.Neg(i.Div(new(big.Int).Neg(r.Num()), r.Denom()))
+	} else {
+		i.Div(r.Num(), r.Denom())
+	}
+	if !i.IsInt64() {
+		return "", errors.New("out of range for int64")
+	}
+	return i.String(), nil
+}
+
+// enum declares a named type typeName with a constant per value of an enum of
+// strings or integers, e.g. `UserStatusActive` for `active`, and returns the
+// constants converted to goType, the type of the field, to be passed to OneOf.
+// A value without a valid name gets a constant with its position, e.g.
+// `UserStatusValue2`.
+func (g *generator) enum(typeName, goType string, values []any) ([]string, error) {
+	if g.names[typeName] {
+		return nil, fmt.Errorf("duplicate type name %q", typeName)
+	}
+	g.names[typeName] = true
+
+	var (
+		consts bytes.Buffer
+		args   = make([]string, 0, len(values))
+	)
+	for i, v := range values {
+		var literal, suffix string
+		if goType == "string" {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported `enum` value %v for string", v)
+			}
+			// The name of the value follows the type name, so it may start
+			// with a digit: the name of `x_<value>` without its first part.
+			literal, suffix = strconv.Quote(s), strings.TrimPrefix(goName("x_"+s), "X")
+		} else {
+			f, ok := toFloat64(v)
+			if !ok || f != math.Trunc(f) {
+				return nil, fmt.Errorf("unsupported `enum` value %v for integer", v)
+			}
+			literal = strconv.FormatFloat(f, 'f', -1, 64)
+			suffix = strings.Replace(literal, "-", "Minus", 1)
+		}
+
+		name := typeName + suffix
+		if suffix == "" || g.names[name] {
+			name = fmt.Sprintf("%sValue%d", typeName, i+1)
+		}
+		if g.names[name] {
+			return nil, fmt.Errorf("duplicate constant name %q", name)
+		}
+		g.names[name] = true
+
+		fmt.Fprintf(&consts, "\t%s %s = %s\n", name, typeName, literal)
+		args = append(args, fmt.Sprintf("%s(%s)", goType, name))
+	}
+
+	var decl bytes.Buffer
+	fmt.Fprintf(&decl, "// %s is an enum generated from a JSON Schema.\n", typeName)
+	fmt.Fprintf(&decl, "type %s %s\n\n", typeName, goType)
+	fmt.Fprintf(&decl, "// Values of %s.\nconst (\n%s)\n\n", typeName, consts.String())
+	g.decls = append(g.decls, decl.String())
+	return args, nil
+}
+
+// properties returns the non-nil properties of an object schema, in order.
+func properties(schema jsonschema.Schema) iter.Seq2[string, *jsonschema.Schema] {
+	return func(yield func(string, *jsonschema.Schema) bool) {
+		if schema.Properties == nil {
+			return
+		}
+		for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			if pair.Value != nil && !yield(pair.Key, pair.Value) {
+				return
+			}
+		}
+	}
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// goName converts a property name to an exported Go identifier, e.g.
+// `first_name` becomes `FirstName`.
+func goName(name string) string {
+	var sb strings.Builder
+	for part := range strings.FieldsFuncSeq(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if upper := strings.ToUpper(part); commonInitialisms[upper] {
+			sb.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	s := sb.String()
+	if s != "" && !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+func writeComment(w *bytes.Buffer, indent, text string) {
+	for line := range strings.Lines(strings.TrimSpace(text)) {
+		fmt.Fprintf(w, "%s// %s\n", indent, strings.TrimRight(line, "\n"))
+	}
+}
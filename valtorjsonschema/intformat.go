@@ -0,0 +1,167 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/dstotijn/valtor"
+)
+
+// intFormats are the ranges of the `format` values of integer schemas, as
+// used by OpenAPI, e.g. `int32`.
+var intFormats = map[string][2]*big.Int{
+	"int8":   {big.NewInt(math.MinInt8), big.NewInt(math.MaxInt8)},
+	"int16":  {big.NewInt(math.MinInt16), big.NewInt(math.MaxInt16)},
+	"int32":  {big.NewInt(math.MinInt32), big.NewInt(math.MaxInt32)},
+	"int64":  {big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)},
+	"uint8":  {big.NewInt(0), big.NewInt(math.MaxUint8)},
+	"uint16": {big.NewInt(0), big.NewInt(math.MaxUint16)},
+	"uint32": {big.NewInt(0), big.NewInt(math.MaxUint32)},
+	"uint64": {big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64)},
+	"uint":   {big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64)},
+}
+
+// intFormatValidator creates a validator that checks if an integer is in the
+// range of the format, e.g. `int32`, so that values that would overflow the
+// type are rejected. It returns false for unknown formats.
+func intFormatValidator(format string) (func(any) error, bool) {
+	bounds, ok := intFormats[format]
+	if !ok {
+		return nil, false
+	}
+	min, max := new(big.Rat).SetInt(bounds[0]), new(big.Rat).SetInt(bounds[1])
+
+	return func(value any) error {
+		r, ok := ratOf(value)
+		if !ok || (r.Cmp(min) >= 0 && r.Cmp(max) <= 0) {
+			// Values that are not numbers fail the type check.
+			return nil
+		}
+		actual := r.RatString()
+		return &valtor.ConstraintError{
+			Code:    valtor.CodeBetween,
+			Params:  map[string]any{"min": bounds[0], "max": bounds[1], "actual": actual},
+			Message: format + " value must be between " + bounds[0].String() + " and " + bounds[1].String() + ", got " + actual,
+			Err:     &valtor.RangeError{Min: bounds[0], Max: bounds[1], Actual: actual},
+		}
+	}, true
+}
+
+// WithIntegerCoercion sets how values of integer schemas that are fractional,
+// e.g. 1.5, or out of range are handled. With valtor.CoerceStrict, the
+// default, they are invalid, unless the profile in the context passed to
+// ValidateContext or valtor.Run coerces them (see valtor.Profile). With valtor.CoerceTruncate, the fractional part
+// is dropped before the value is validated. With valtor.CoerceClamp, values
+// out of the range of int64 or of the `format` of the schema (e.g. `int32`)
+// are also saturated to that range. Numbers decoded as json.Number are not
+// limited to the range of int64. Validate checks the coerced values; use
+// valtor.Run to obtain them, also for nested properties and items, as int64
+// values, or as json.Number values for numbers decoded as json.Number.
+func WithIntegerCoercion(mode valtor.CoerceMode) Option {
+	return func(cfg *config) {
+		cfg.intCoercion = mode
+	}
+}
+
+// coerceInteger applies the integer coercion mode to a value of an integer
+// schema with the format, if any, and returns the value to validate. Values
+// that can't be coerced are returned as is, to fail validation.
+func (cfg *config) coerceInteger(ctx context.Context, value any, format string) any {
+	mode := cfg.coercion(ctx)
+	if mode == valtor.CoerceStrict || value == nil {
+		return value
+	}
+
+	var r *big.Rat
+	if n, ok := value.(json.Number); ok {
+		r, ok = new(big.Rat).SetString(n.String())
+		if !ok {
+			return value
+		}
+		r.SetInt(new(big.Int).Quo(r.Num(), r.Denom()))
+	} else {
+		n, err := valtor.CoerceNumberMode[int64](value, mode)
+		if err != nil {
+			return value
+		}
+		r = new(big.Rat).SetInt64(n)
+	}
+
+	if bounds, ok := intFormats[format]; ok && mode == valtor.CoerceClamp {
+		if r.Cmp(new(big.Rat).SetInt(bounds[0])) < 0 {
+			r.SetInt(bounds[0])
+		} else if r.Cmp(new(big.Rat).SetInt(bounds[1])) > 0 {
+			r.SetInt(bounds[1])
+		}
+	}
+
+	if _, ok := value.(json.Number); ok {
+		return json.Number(r.Num().String())
+	}
+	return r.Num().Int64()
+}
+
+// coercion returns the integer coercion mode, which is the more lenient of
+// the one set with WithIntegerCoercion and the one of the profile in ctx.
+func (cfg *config) coercion(ctx context.Context) valtor.CoerceMode {
+	return max(cfg.intCoercion, valtor.ProfileFromContext(ctx).Coerce)
+}
+
+// coerces reports whether integers are coerced, so that the schemas of objects,
+// arrays, unions and references apply the transformations of the nested
+// schemas (see valtor.Run).
+func (cfg *config) coerces(ctx context.Context) bool {
+	return cfg.coercion(ctx) != valtor.CoerceStrict
+}
+
+// transformAs applies fn to value, if it is of type V, and returns the result
+// as T, or else value as is.
+func transformAs[T, V any](value T, fn func(V) V) T {
+	v, ok := any(value).(V)
+	if !ok {
+		return value
+	}
+	if result, ok := any(fn(v)).(T); ok {
+		return result
+	}
+	return value
+}
+
+// ratOf returns a numeric value as a rational number.
+func ratOf(value any) (*big.Rat, bool) {
+	if n, ok := value.(json.Number); ok {
+		return new(big.Rat).SetString(n.String())
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(f), true
+	}
+	return nil, false
+}
@@ -15,24 +15,209 @@
 package valtorjsonschema
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"maps"
 	"math"
 	"regexp"
 	"slices"
+	"strconv"
 
 	"github.com/dstotijn/valtor"
+	"github.com/dstotijn/valtor/formats"
 	"github.com/invopop/jsonschema"
 )
 
 var ErrInvalidType = errors.New("invalid type")
 
-func ParseJSONSchema[T any](schema jsonschema.Schema) (*valtor.Schema[T], error) {
-	return parseJSONSchema[T](schema, false)
+// Option configures how a JSON Schema is parsed.
+type Option func(*config)
+
+type config struct {
+	formats     *formats.Registry
+	extensions  *valtor.ExtensionRegistry
+	patternMode PatternMode
+	report      *Report
+	strict      bool
+	ctx         context.Context
+	loader      Loader
+	allowed     []string
+	refs        *refs
+	intCoercion valtor.CoerceMode
 }
 
-func parseJSONSchema[T any](schema jsonschema.Schema, required bool) (*valtor.Schema[T], error) {
+// WithFormats sets the registry used to look up validators for the `format`
+// keyword. Defaults to formats.Default. Formats that are not in the registry
+// are ignored, as the keyword is an annotation by default (see WithReport).
+func WithFormats(registry *formats.Registry) Option {
+	return func(cfg *config) {
+		cfg.formats = registry
+	}
+}
+
+// WithExtensions sets the registry used to look up extensions for extension
+// keywords, i.e. keywords in the `Extras` of a schema (see the
+// `jsonschema_extras` struct tag). Defaults to valtor.DefaultExtensions.
+// Keywords without a registered extension are ignored (see WithReport).
+func WithExtensions(registry *valtor.ExtensionRegistry) Option {
+	return func(cfg *config) {
+		cfg.extensions = registry
+	}
+}
+
+// ParseJSONSchema parses a JSON Schema into a validation schema for type T.
+// Numbers decoded as json.Number (see json.Decoder.UseNumber) are validated
+// with exact precision, also beyond the range of float64 and int64. A schema
+// with `anyOf` or `oneOf` that only lists types, e.g. a nullable field of a
+// reflected schema, is parsed as a union of the types. The `format` of an
+// integer schema, e.g. `int32` or `uint64`, limits values to the range of the
+// type.
+//
+// The schema is parsed from its JSON encoding, as with ParseJSONSchemaBytes.
+func ParseJSONSchema[T any](schema jsonschema.Schema, opts ...Option) (*valtor.Schema[T], error) {
+	data, err := json.Marshal(&schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return ParseJSONSchemaBytes[T](data, opts...)
+}
+
+// parseJSONSchema parses the schema at path, a JSON Pointer that is empty for
+// the root schema.
+func parseJSONSchema[T any](schema schemaNode, cfg *config, path string) (*valtor.Schema[T], error) {
+	types, union := typeUnion(schema)
+	if union && len(schema.Types) == 0 {
+		// The subschemas of `anyOf` or `oneOf` only list the types.
+		schema.AnyOf, schema.OneOf = nil, nil
+	}
+
+	if err := cfg.checkKeywords(schema, path); err != nil {
+		return nil, err
+	}
+
+	var ref *refSchema
+	if schema.Ref != "" {
+		var err error
+		ref, err = cfg.parseRef(schema.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `$ref` %q: %w", schema.Ref, err)
+		}
+	}
+
+	var (
+		valtorSchema *valtor.Schema[T]
+		err          error
+	)
+	switch {
+	case union:
+		valtorSchema, err = parseUnion[T](schema, types, cfg, path)
+	case ref != nil && schema.Type == "":
+		// The referenced schema applies, along with the keywords next to
+		// `$ref` that don't depend on the type, such as `enum`.
+		valtorSchema = valtor.New[T]()
+	default:
+		valtorSchema, err = parseType[T](schema, cfg, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ref != nil {
+		valtorSchema.Rule(contextFunc[T](func(ctx context.Context, value T) error {
+			return ref.ValidateContext(ctx, value)
+		}))
+		valtorSchema.TransformContext(func(ctx context.Context, value T) T {
+			if !cfg.coerces(ctx) {
+				return value
+			}
+			return transformAs(value, func(v any) any {
+				return ref.schema.TransformValueContext(ctx, v)
+			})
+		})
+	}
+
+	if len(schema.Enum) > 0 {
+		enumFn, err := enumValidator(schema.Enum)
+		if err != nil {
+			return nil, err
+		}
+		valtorSchema.Custom(func(value T) error {
+			return enumFn(value)
+		})
+	}
+
+	if schema.Deprecated {
+		valtorSchema.Deprecated("")
+	}
+
+	if schema.Title != "" || schema.Description != "" {
+		valtorSchema.Annotate(schema.Title, schema.Description)
+	}
+
+	for _, keyword := range slices.Sorted(maps.Keys(schema.Extras)) {
+		ext, ok := cfg.extensions.Lookup(keyword)
+		if !ok {
+			if err := cfg.unsupported(path, keyword); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		params, err := json.Marshal(schema.Extras[keyword])
+		if err != nil {
+			return nil, fmt.Errorf("invalid `%s` value: %w", keyword, err)
+		}
+		extFn, err := ext.Parse(params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `%s` value: %w", keyword, err)
+		}
+		valtorSchema.Custom(func(value T) error {
+			return extFn(value)
+		})
+	}
+
+	return valtorSchema, nil
+}
+
+// enumValidator creates a validator for the `enum` keyword. Values are
+// compared by their JSON encoding, so that e.g. `1` matches both an int and a
+// float64 value. A nil value (an absent value) is not validated. The error
+// lists the allowed values and has them as `allowed` parameter.
+func enumValidator(enum []any) (func(any) error, error) {
+	allowed := make(map[string]struct{}, len(enum))
+	encoded := make([]string, 0, len(enum))
+	for _, v := range enum {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `enum` value %v: %w", v, err)
+		}
+		allowed[string(b)] = struct{}{}
+		encoded = append(encoded, string(b))
+	}
+	message := "value must be one of " + valtor.ListValues(encoded)
+
+	return func(value any) error {
+		if value == nil {
+			return nil
+		}
+		b, err := json.Marshal(value)
+		if err == nil {
+			if _, ok := allowed[string(b)]; ok {
+				return nil
+			}
+		}
+		return &valtor.ConstraintError{
+			Code:    valtor.CodeOneOf,
+			Params:  map[string]any{"allowed": enum, "actual": value},
+			Message: message,
+		}
+	}, nil
+}
+
+func parseType[T any](schema schemaNode, cfg *config, path string) (*valtor.Schema[T], error) {
 	switch schema.Type {
 	case "null":
 		nullSchema := valtor.Null()
@@ -41,20 +226,10 @@ func parseJSONSchema[T any](schema jsonschema.Schema, required bool) (*valtor.Sc
 			return nullSchema.Validate(value)
 		}), nil
 	case "boolean":
-		boolSchema := valtor.Bool()
+		anySchema := valtor.WhenType(valtor.Any().Expected("boolean"), valtor.Bool())
 
 		return valtor.New[T]().Custom(func(value T) error {
-			switch v := any(value).(type) {
-			case bool:
-				return boolSchema.Validate(v)
-			case nil:
-				if required {
-					return valtor.ErrValueRequired
-				}
-				return nil
-			default:
-				return fmt.Errorf("expected boolean value, got %T", v)
-			}
+			return anySchema.Validate(value)
 		}), nil
 	case "array":
 		if schema.Items == nil {
@@ -72,27 +247,19 @@ func parseJSONSchema[T any](schema jsonschema.Schema, required bool) (*valtor.Sc
 				arrSchema.UniqueItems()
 			}
 
+			anySchema := valtor.WhenType(valtor.Any().Expected("array"), arrSchema)
+
 			return valtor.New[T]().Custom(func(value T) error {
-				switch v := any(value).(type) {
-				case []any:
-					return arrSchema.Validate(v)
-				case nil:
-					if required && schema.MinItems != nil && *schema.MinItems > 0 {
-						return valtor.ErrValueRequired
-					}
-					return nil
-				default:
-					return fmt.Errorf("expected array value, got %T", v)
-				}
+				return anySchema.Validate(value)
 			}), nil
 		}
 
-		itemSchema, err := parseJSONSchema[any](*schema.Items, false)
+		itemSchema, err := parseJSONSchema[any](*schema.Items, cfg, path+"/items")
 		if err != nil {
 			return nil, fmt.Errorf("invalid item schema: %w", err)
 		}
 
-		arrSchema := valtor.Array[any]().Items(itemSchema.Validate)
+		arrSchema := valtor.Array[any]().ItemsOf(itemSchema)
 
 		if schema.MinItems != nil {
 			arrSchema.Min(int(*schema.MinItems))
@@ -106,21 +273,23 @@ func parseJSONSchema[T any](schema jsonschema.Schema, required bool) (*valtor.Sc
 			arrSchema.UniqueItems()
 		}
 
-		return valtor.New[T]().Custom(func(value T) error {
-			switch v := any(value).(type) {
-			case []any:
-				return arrSchema.Validate(v)
-			case nil:
-				if required && schema.MinItems != nil && *schema.MinItems > 0 {
-					return valtor.ErrValueRequired
-				}
-				return nil
-			default:
-				return fmt.Errorf("expected array value, got %T", v)
+		anySchema := valtor.WhenType(valtor.Any().Expected("array"), arrSchema)
+
+		arraySchema := valtor.New[T]().Rule(contextFunc[T](func(ctx context.Context, value T) error {
+			return anySchema.ValidateContext(ctx, value)
+		}))
+		arraySchema.TransformContext(func(ctx context.Context, value T) T {
+			if !cfg.coerces(ctx) {
+				return value
 			}
-		}), nil
+			return transformAs(value, func(arr []any) []any {
+				return arrSchema.TransformValueContext(ctx, arr)
+			})
+		})
+		return arraySchema, nil
 	case "string":
-		strSchema := valtor.String()
+		// JSON Schema string lengths are measured in Unicode code points.
+		strSchema := valtor.String().LengthMode(valtor.LengthRunes)
 
 		if schema.MinLength != nil {
 			strSchema.Min(int(*schema.MinLength))
@@ -129,97 +298,102 @@ func parseJSONSchema[T any](schema jsonschema.Schema, required bool) (*valtor.Sc
 			strSchema.Max(int(*schema.MaxLength))
 		}
 		if schema.Pattern != "" {
-			re, err := regexp.Compile(schema.Pattern)
+			re, err := cfg.compilePattern(schema.Pattern)
 			if err != nil {
 				return nil, fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
 			}
-			strSchema.Regexp(re)
+			if re != nil {
+				strSchema.Regexp(re)
+			} else if err := cfg.unsupported(path, "pattern"); err != nil {
+				return nil, err
+			}
 		}
-
-		if required {
-			strSchema = strSchema.Required()
+		if schema.Format != "" {
+			if fn, ok := cfg.formats.Lookup(schema.Format); ok {
+				strSchema.Custom(fn)
+			} else if err := cfg.unsupported(path, "format"); err != nil {
+				return nil, err
+			}
 		}
 
-		return valtor.New[T]().Custom(func(value T) error {
-			switch typedValue := any(value).(type) {
-			case string:
-				return strSchema.Validate(typedValue)
-			case nil:
+		anySchema := valtor.WhenType(valtor.Any().Expected("string"), strSchema).
+			WhenNil(func() error {
 				return strSchema.Validate("")
-			default:
-				return fmt.Errorf("expected string value, got %T", value)
-			}
+			})
+
+		return valtor.New[T]().Custom(func(value T) error {
+			return anySchema.Validate(value)
 		}), nil
 	case "integer":
 		numSchema := valtor.Number[int64]()
+		decSchema := valtor.Decimal().Scale(0)
+		// Bounds outside the int64 range are checked as decimals.
+		var wideBounds bool
 
 		if min := schema.Minimum; min != "" {
 			minFloat, err := min.Float64()
 			if err != nil {
 				return nil, fmt.Errorf("invalid `minimum` value %q", min)
 			}
-			minInt := int64(math.Ceil(minFloat))
-			numSchema.Min(minInt)
+			if inInt64Range(minFloat) {
+				numSchema.Min(int64(math.Ceil(minFloat)))
+			} else {
+				wideBounds = true
+			}
+			decSchema.Min(min.String())
 		}
 		if max := schema.Maximum; max != "" {
 			maxFloat, err := max.Float64()
 			if err != nil {
 				return nil, fmt.Errorf("invalid `maximum` value %q", max)
 			}
-			maxInt := int64(math.Floor(maxFloat))
-			numSchema.Max(maxInt)
-		}
-
-		if required {
-			numSchema = numSchema.Required()
+			if inInt64Range(maxFloat) {
+				numSchema.Max(int64(math.Floor(maxFloat)))
+			} else {
+				wideBounds = true
+			}
+			decSchema.Max(max.String())
 		}
 
-		return valtor.New[T]().Custom(func(value T) error {
-			switch typedValue := any(value).(type) {
-			case int64:
-				return numSchema.Validate(typedValue)
-			case int32:
-				return numSchema.Validate(int64(typedValue))
-			case int16:
-				return numSchema.Validate(int64(typedValue))
-			case int8:
-				return numSchema.Validate(int64(typedValue))
-			case int:
-				return numSchema.Validate(int64(typedValue))
-			case uint64:
-				if typedValue > math.MaxInt64 {
-					return fmt.Errorf("uint64 value %d exceeds maximum int64", typedValue)
-				}
-				return numSchema.Validate(int64(typedValue))
-			case uint32:
-				return numSchema.Validate(int64(typedValue))
-			case uint16:
-				return numSchema.Validate(int64(typedValue))
-			case uint8:
-				return numSchema.Validate(int64(typedValue))
-			case uint:
-				if uint64(typedValue) > math.MaxInt64 {
-					return fmt.Errorf("uint value %d exceeds maximum int64", typedValue)
-				}
-				return numSchema.Validate(int64(typedValue))
-			case float64:
-				if typedValue != math.Trunc(typedValue) {
-					return fmt.Errorf("expected integer value, got float with fractional part: %v", typedValue)
-				}
-				if typedValue > math.MaxInt64 || typedValue < math.MinInt64 {
-					return fmt.Errorf("float value %v exceeds int64 range", typedValue)
-				}
-				return numSchema.Validate(int64(typedValue))
+		intSchema := valtor.New[T]().Rule(contextFunc[T](func(ctx context.Context, value T) error {
+			switch typedValue := cfg.coerceInteger(ctx, value, schema.Format).(type) {
+			case json.Number:
+				return decSchema.Validate(typedValue.String())
 			case nil:
 				return numSchema.Validate(0)
 			default:
-				log.Printf("expected integer value, got %T", typedValue)
-				return fmt.Errorf("expected integer value, got %T", typedValue)
+				n, err := valtor.CoerceNumber[int64](typedValue)
+				if err != nil {
+					return err
+				}
+				if wideBounds {
+					return decSchema.Validate(strconv.FormatInt(n, 10))
+				}
+				return numSchema.Validate(n)
 			}
-		}), nil
+		}))
+
+		if schema.Format != "" {
+			if fn, ok := intFormatValidator(schema.Format); ok {
+				intSchema.Rule(contextFunc[T](func(ctx context.Context, value T) error {
+					return fn(cfg.coerceInteger(ctx, value, schema.Format))
+				}))
+			} else if err := cfg.unsupported(path, "format"); err != nil {
+				return nil, err
+			}
+		}
+
+		intSchema.TransformContext(func(ctx context.Context, value T) T {
+			return transformAs(value, func(v any) any {
+				return cfg.coerceInteger(ctx, v, schema.Format)
+			})
+		})
+
+		return intSchema, nil
 
 	case "number":
 		numSchema := valtor.Number[float64]()
+		decSchema := valtor.Decimal()
 
 		if min := schema.Minimum; min != "" {
 			minFloat, err := min.Float64()
@@ -227,6 +401,7 @@ func parseJSONSchema[T any](schema jsonschema.Schema, required bool) (*valtor.Sc
 				return nil, fmt.Errorf("invalid `minimum` %q: %w", min, err)
 			}
 			numSchema.Min(minFloat)
+			decSchema.Min(min.String())
 		}
 		if max := schema.Maximum; max != "" {
 			maxFloat, err := max.Float64()
@@ -234,69 +409,180 @@ func parseJSONSchema[T any](schema jsonschema.Schema, required bool) (*valtor.Sc
 				return nil, fmt.Errorf("invalid `maximum` %q: %w", max, err)
 			}
 			numSchema.Max(maxFloat)
-		}
-
-		if required {
-			numSchema = numSchema.Required()
+			decSchema.Max(max.String())
 		}
 
 		return valtor.New[T]().Custom(func(value T) error {
 			switch typedValue := any(value).(type) {
-			case float64:
-				return numSchema.Validate(typedValue)
-			case int64:
-				return numSchema.Validate(float64(typedValue))
-			case int32:
-				return numSchema.Validate(float64(typedValue))
-			case int16:
-				return numSchema.Validate(float64(typedValue))
-			case int8:
-				return numSchema.Validate(float64(typedValue))
-			case int:
-				return numSchema.Validate(float64(typedValue))
-			case uint64:
-				return numSchema.Validate(float64(typedValue))
-			case uint32:
-				return numSchema.Validate(float64(typedValue))
-			case uint16:
-				return numSchema.Validate(float64(typedValue))
-			case uint8:
-				return numSchema.Validate(float64(typedValue))
-			case uint:
-				return numSchema.Validate(float64(typedValue))
+			case json.Number:
+				return decSchema.Validate(typedValue.String())
 			case nil:
 				return numSchema.Validate(0)
 			default:
-				return fmt.Errorf("expected numeric value, got %T", typedValue)
+				n, err := valtor.CoerceNumber[float64](typedValue)
+				if err != nil {
+					return err
+				}
+				return numSchema.Validate(n)
 			}
 		}), nil
 	case "object":
 		objSchema := valtor.Object[any]()
+		propSchemas := make(map[string]*valtor.Schema[any])
+
+		for _, prop := range schema.Properties {
+			if prop.schema == nil {
+				continue
+			}
+
+			// A deprecated property is reported by the object schema, along
+			// with its name.
+			propSchema := *prop.schema
+			propSchema.Deprecated = false
+			fieldSchema, err := parseJSONSchema[any](propSchema, cfg, path+"/properties/"+escapePointer(prop.name))
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema for property %q: %w", prop.name, err)
+			}
+			propSchemas[prop.name] = fieldSchema
+
+			if prop.schema.Deprecated {
+				objSchema.DeprecatedField(prop.name, "")
+			}
+			if prop.schema.ReadOnly {
+				objSchema.ReadOnlyFields(prop.name)
+			}
+			if prop.schema.WriteOnly {
+				objSchema.WriteOnlyFields(prop.name)
+			}
+			// The context is passed on, so that the mode and the profile apply
+			// to nested objects as well, and their deprecated properties are
+			// reported.
+			objSchema.FieldPresenceContext(prop.name, func(ctx context.Context, value any, present bool) error {
+				// Properties that are absent are only validated by `required`.
+				if !present {
+					return nil
+				}
+				return fieldSchema.ValidateContext(ctx, value)
+			})
+		}
+
+		objSchema.RequiredFields(schema.Required...)
 
-		for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
-			if pair.Value == nil {
+		var (
+			patterns       []*regexp.Regexp
+			patternSchemas []*valtor.Schema[any]
+		)
+		for _, pattern := range slices.Sorted(maps.Keys(schema.PatternProperties)) {
+			propSchema := schema.PatternProperties[pattern]
+			if propSchema == nil {
 				continue
 			}
 
-			fieldRequired := false
-			if slices.Contains(schema.Required, pair.Key) {
-				fieldRequired = true
+			re, err := cfg.compilePattern(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern property %q: %w", pattern, err)
+			}
+			if re == nil {
+				if err := cfg.unsupported(path+"/patternProperties/"+escapePointer(pattern), "pattern"); err != nil {
+					return nil, err
+				}
+				continue
 			}
+			patterns = append(patterns, re)
 
-			fieldSchema, err := parseJSONSchema[any](*pair.Value, fieldRequired)
+			fieldSchema, err := parseJSONSchema[any](*propSchema, cfg, path+"/patternProperties/"+escapePointer(pattern))
 			if err != nil {
-				return nil, fmt.Errorf("invalid schema for property %q: %w", pair.Key, err)
+				return nil, fmt.Errorf("invalid schema for pattern property %q: %w", pattern, err)
 			}
 
-			objSchema.Field(pair.Key, fieldSchema.Validate)
+			objSchema.PatternFieldContext(re, fieldSchema.ValidateContext)
+			patternSchemas = append(patternSchemas, fieldSchema)
 		}
 
-		return valtor.New[T]().Custom(func(value T) error {
-			return objSchema.Validate(value)
-		}), nil
+		if isFalseSchema(schema.AdditionalProperties) {
+			objSchema.PropertyNames(valtor.New[string]().Custom(func(key string) error {
+				if _, ok := schema.Properties.get(key); ok {
+					return nil
+				}
+				for _, re := range patterns {
+					if re.MatchString(key) {
+						return nil
+					}
+				}
+				return errors.New("additional properties are not allowed")
+			}))
+		}
+
+		if schema.MinProperties != nil {
+			objSchema.MinProperties(int(*schema.MinProperties))
+		}
+		if schema.MaxProperties != nil {
+			objSchema.MaxProperties(int(*schema.MaxProperties))
+		}
+
+		if schema.PropertyNames != nil {
+			nameSchema, err := parseJSONSchema[string](*schema.PropertyNames, cfg, path+"/propertyNames")
+			if err != nil {
+				return nil, fmt.Errorf("invalid `propertyNames` schema: %w", err)
+			}
+			objSchema.PropertyNames(nameSchema)
+		}
+
+		// The context is passed on, so that the profile applies and deprecated
+		// properties are reported (see valtor.ValidateResult).
+		objectSchema := valtor.New[T]().Rule(contextFunc[T](func(ctx context.Context, value T) error {
+			return objSchema.ValidateContext(ctx, value)
+		}))
+		objectSchema.TransformContext(func(ctx context.Context, value T) T {
+			if !cfg.coerces(ctx) {
+				return value
+			}
+			return transformAs(value, func(m map[string]any) map[string]any {
+				transformed := make(map[string]any, len(m))
+				for key, v := range m {
+					if propSchema, ok := propSchemas[key]; ok {
+						v = propSchema.TransformValueContext(ctx, v)
+					}
+					for i, re := range patterns {
+						if re.MatchString(key) {
+							v = patternSchemas[i].TransformValueContext(ctx, v)
+						}
+					}
+					transformed[key] = v
+				}
+				return transformed
+			})
+		})
+		return objectSchema, nil
 	case "":
 		fallthrough
 	default:
 		return nil, ErrInvalidType
 	}
 }
+
+// contextFunc adapts a function to valtor.ContextValidator.
+type contextFunc[T any] func(ctx context.Context, value T) error
+
+func (fn contextFunc[T]) ValidateContext(ctx context.Context, value T) error {
+	return fn(ctx, value)
+}
+
+// Fingerprint returns a fingerprint of the schema definition, e.g.
+// `sha256:9f86...`, to identify the version of a contract in a validation
+// report (see valtor.NewReport). It is the SHA-256 of the JSON encoding of the
+// schema, so it changes with any change to the schema, including annotations
+// such as `description`.
+func Fingerprint(schema jsonschema.Schema) (string, error) {
+	b, err := json.Marshal(&schema)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode schema: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// inInt64Range reports whether f, rounded to an integer, is an int64.
+func inInt64Range(f float64) bool {
+	return f >= math.MinInt64 && f < 1<<63
+}
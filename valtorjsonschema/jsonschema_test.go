@@ -15,10 +15,19 @@
 package valtorjsonschema
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"math"
 	"os"
+	"reflect"
+	"regexp"
 	"testing"
 
+	"github.com/dstotijn/valtor"
+	"github.com/dstotijn/valtor/formats"
 	"github.com/invopop/jsonschema"
 )
 
@@ -34,7 +43,7 @@ func TestParseJSONSchema(t *testing.T) {
 	}
 	invalidData := map[string]any{
 		"name":      "John123", // contains numbers
-		"age":       int64(0),  // not greater than 0
+		"age":       int64(-1), // less than minimum of 0
 		"height":    4.0,       // too tall
 		"email":     "invalid-email",
 		"tags":      []any{"a", "a"}, // Duplicate items, violates uniqueItems
@@ -107,6 +116,36 @@ func TestParseJSONSchema(t *testing.T) {
 		t.Error("expected invalid array item data to fail validation, got no error")
 	}
 
+	// Test zero values for required fields.
+	zeroRequiredData := map[string]any{
+		"name": "John Doe",
+		"age":  int64(0), // present, so satisfies `required`
+	}
+	err = valtorSchema.Validate(zeroRequiredData)
+	if err != nil {
+		t.Errorf("expected zero value for required field to pass validation, got error: %v", err)
+	}
+
+	// Test missing required field.
+	missingAgeData := map[string]any{
+		"name": "John Doe",
+	}
+	err = valtorSchema.Validate(missingAgeData)
+	if !errors.Is(err, valtor.ErrValueRequired) {
+		t.Errorf("expected error %q for missing required field, got %v", valtor.ErrValueRequired, err)
+	}
+
+	// Test string length measured in code points.
+	multiByteData := map[string]any{
+		"name": "Zoe",
+		"age":  int64(30),
+		"tags": []any{"ééééééééééééééééééé"}, // 19 code points, 38 bytes
+	}
+	err = valtorSchema.Validate(multiByteData)
+	if err != nil {
+		t.Errorf("expected multi-byte string within maxLength to pass validation, got error: %v", err)
+	}
+
 	// Test specific invalid types
 	invalidBooleanData := map[string]any{
 		"name":      "John Doe",
@@ -149,22 +188,6 @@ func TestParseJSONSchemaErrors(t *testing.T) {
 			},
 			expectedError: ErrInvalidType.Error(),
 		},
-		{
-			name: "invalid exclusive minimum for integer",
-			schema: jsonschema.Schema{
-				Type:    "integer",
-				Minimum: json.Number("invalid"),
-			},
-			expectedError: "invalid `minimum` value \"invalid\"",
-		},
-		{
-			name: "invalid exclusive maximum for integer",
-			schema: jsonschema.Schema{
-				Type:    "integer",
-				Maximum: json.Number("invalid"),
-			},
-			expectedError: "invalid `maximum` value \"invalid\"",
-		},
 	}
 
 	for _, tt := range tests {
@@ -182,3 +205,1211 @@ func TestParseJSONSchemaErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestParseJSONSchemaInvalidNumber(t *testing.T) {
+	// Schemas are parsed from their JSON encoding, so invalid numbers fail to
+	// encode.
+	tests := []jsonschema.Schema{
+		{Type: "integer", Minimum: json.Number("invalid")},
+		{Type: "integer", Maximum: json.Number("invalid")},
+	}
+
+	for _, schema := range tests {
+		_, err := ParseJSONSchema[any](schema)
+		var marshalerErr *json.MarshalerError
+		if !errors.As(err, &marshalerErr) {
+			t.Errorf("expected json.MarshalerError, got %v", err)
+		}
+	}
+}
+
+func TestParseJSONSchemaObjectKeywords(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"patternProperties": {
+			"^x-": {"type": "string", "maxLength": 5}
+		},
+		"minProperties": 1,
+		"maxProperties": 2,
+		"propertyNames": {"type": "string", "pattern": "^[a-z-]+$"}
+	}`
+
+	var jsonSchema jsonschema.Schema
+	if err := json.Unmarshal([]byte(schemaJSON), &jsonSchema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	valtorSchema, err := ParseJSONSchema[any](jsonSchema)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: map[string]any{"x-foo": "bar", "name": int64(1)},
+		},
+		{
+			name:    "invalid pattern property value",
+			data:    map[string]any{"x-foo": "foobar"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern property type",
+			data:    map[string]any{"x-foo": true},
+			wantErr: true,
+		},
+		{
+			name:    "too few properties",
+			data:    map[string]any{},
+			wantErr: true,
+		},
+		{
+			name:    "too many properties",
+			data:    map[string]any{"a": 1, "b": 2, "c": 3},
+			wantErr: true,
+		},
+		{
+			name:    "invalid property name",
+			data:    map[string]any{"Name": "John"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := valtorSchema.Validate(tt.data)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got no error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaFormat(t *testing.T) {
+	registry := formats.NewRegistry()
+	registry.Register("even-length", func(s string) error {
+		if len(s)%2 != 0 {
+			return errors.New("length must be even")
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		format  string
+		value   any
+		wantErr bool
+	}{
+		{name: "built-in format valid", format: "slug", value: "hello-world"},
+		{name: "built-in format invalid", format: "slug", value: "Hello World", wantErr: true},
+		{name: "custom format valid", format: "even-length", value: "ab"},
+		{name: "custom format invalid", format: "even-length", value: "abc", wantErr: true},
+		{name: "unknown format ignored", format: "foobar", value: "anything"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valtorSchema, err := ParseJSONSchema[any](jsonschema.Schema{
+				Type:   "string",
+				Format: tt.format,
+			}, WithFormats(registry))
+			if err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			err = valtorSchema.Validate(tt.value)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got no error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaJSONNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  jsonschema.Schema
+		value   json.Number
+		wantErr bool
+	}{
+		{
+			name:   "number beyond float64 precision within maximum",
+			schema: jsonschema.Schema{Type: "number", Maximum: "0.30000000000000001"},
+			value:  "0.30000000000000001",
+		},
+		{
+			name:    "number beyond float64 precision exceeding maximum",
+			schema:  jsonschema.Schema{Type: "number", Maximum: "0.30000000000000001"},
+			value:   "0.30000000000000002",
+			wantErr: true,
+		},
+		{
+			name:   "integer beyond int64 range",
+			schema: jsonschema.Schema{Type: "integer", Minimum: "0"},
+			value:  "123456789012345678901234567890",
+		},
+		{
+			name:    "integer with fractional part",
+			schema:  jsonschema.Schema{Type: "integer"},
+			value:   "1.5",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valtorSchema, err := ParseJSONSchema[any](tt.schema)
+			if err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			err = valtorSchema.Validate(tt.value)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got no error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  jsonschema.Schema
+		value   any
+		wantErr string
+	}{
+		{
+			name:   "string in enum",
+			schema: jsonschema.Schema{Type: "string", Enum: []any{"draft", "published"}},
+			value:  "draft",
+		},
+		{
+			name:    "string not in enum",
+			schema:  jsonschema.Schema{Type: "string", Enum: []any{"draft", "published"}},
+			value:   "deleted",
+			wantErr: `value must be one of "draft", "published"`,
+		},
+		{
+			name:   "integer in enum as float64",
+			schema: jsonschema.Schema{Type: "integer", Enum: []any{1, 2, 3}},
+			value:  float64(2),
+		},
+		{
+			name:    "integer not in enum",
+			schema:  jsonschema.Schema{Type: "integer", Enum: []any{1, 2, 3}},
+			value:   4,
+			wantErr: "value must be one of 1, 2, 3",
+		},
+		{
+			name:    "truncated list",
+			schema:  jsonschema.Schema{Type: "integer", Enum: []any{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			value:   13,
+			wantErr: "value must be one of 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, ... (and 2 more)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valtorSchema, err := ParseJSONSchema[any](tt.schema)
+			if err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			err = valtorSchema.Validate(tt.value)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+
+			var constraintErr *valtor.ConstraintError
+			if tt.wantErr != "" && (!errors.As(err, &constraintErr) || constraintErr.Code != valtor.CodeOneOf) {
+				t.Errorf("expected constraint error with code %q, got %v", valtor.CodeOneOf, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaAdditionalProperties(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"}
+		},
+		"patternProperties": {
+			"^x-": {"type": "string"}
+		},
+		"additionalProperties": false
+	}`
+
+	var jsonSchema jsonschema.Schema
+	if err := json.Unmarshal([]byte(schemaJSON), &jsonSchema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	valtorSchema, err := ParseJSONSchema[any](jsonSchema)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	if err := valtorSchema.Validate(map[string]any{"name": "John", "x-foo": "bar"}); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+
+	wantErr := `invalid property name "age": additional properties are not allowed`
+	err = valtorSchema.Validate(map[string]any{"name": "John", "age": 30})
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("expected error %q, got %v", wantErr, err)
+	}
+}
+
+func TestMaxSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		schemaJSON  string
+		wantSize    int64
+		wantBounded bool
+	}{
+		{
+			name:        "null",
+			schemaJSON:  `{"type": "null"}`,
+			wantSize:    4,
+			wantBounded: true,
+		},
+		{
+			name:        "boolean",
+			schemaJSON:  `{"type": "boolean"}`,
+			wantSize:    5,
+			wantBounded: true,
+		},
+		{
+			name:        "integer",
+			schemaJSON:  `{"type": "integer"}`,
+			wantSize:    20,
+			wantBounded: true,
+		},
+		{
+			name:        "string with maxLength",
+			schemaJSON:  `{"type": "string", "maxLength": 10}`,
+			wantSize:    122,
+			wantBounded: true,
+		},
+		{
+			name:       "string without maxLength",
+			schemaJSON: `{"type": "string"}`,
+		},
+		{
+			name:        "enum",
+			schemaJSON:  `{"type": "string", "enum": ["draft", "published"]}`,
+			wantSize:    11,
+			wantBounded: true,
+		},
+		{
+			name:        "array with maxItems",
+			schemaJSON:  `{"type": "array", "items": {"type": "boolean"}, "maxItems": 3}`,
+			wantSize:    19,
+			wantBounded: true,
+		},
+		{
+			name:        "empty array",
+			schemaJSON:  `{"type": "array", "items": {"type": "boolean"}, "maxItems": 0}`,
+			wantSize:    2,
+			wantBounded: true,
+		},
+		{
+			name:       "array without maxItems",
+			schemaJSON: `{"type": "array", "items": {"type": "boolean"}}`,
+		},
+		{
+			name: "closed object",
+			schemaJSON: `{
+				"type": "object",
+				"properties": {
+					"active": {"type": "boolean"},
+					"status": {"enum": ["on", "off"]}
+				},
+				"additionalProperties": false
+			}`,
+			// {"active":false,"status":"off"}
+			wantSize:    31,
+			wantBounded: true,
+		},
+		{
+			name:       "open object",
+			schemaJSON: `{"type": "object", "properties": {"active": {"type": "boolean"}}}`,
+		},
+		{
+			name:       "object with unbounded property",
+			schemaJSON: `{"type": "object", "properties": {"name": {"type": "string"}}, "additionalProperties": false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsonSchema jsonschema.Schema
+			if err := json.Unmarshal([]byte(tt.schemaJSON), &jsonSchema); err != nil {
+				t.Fatalf("failed to unmarshal schema: %v", err)
+			}
+
+			size, bounded := MaxSize(jsonSchema)
+			if bounded != tt.wantBounded {
+				t.Errorf("expected bounded %v, got %v", tt.wantBounded, bounded)
+			}
+			if size != tt.wantSize {
+				t.Errorf("expected size %d, got %d", tt.wantSize, size)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := jsonschema.Schema{Type: "string", Pattern: "^[a-z]+$"}
+	b := jsonschema.Schema{Type: "string", Pattern: "^[a-z0-9]+$"}
+
+	fpA, err := Fingerprint(a)
+	if err != nil {
+		t.Fatalf("failed to fingerprint schema: %v", err)
+	}
+	fpA2, _ := Fingerprint(a)
+	fpB, _ := Fingerprint(b)
+
+	if fpA != fpA2 {
+		t.Errorf("expected equal fingerprints for equal schemas, got %q and %q", fpA, fpA2)
+	}
+	if fpA == fpB {
+		t.Errorf("expected different fingerprints for different schemas, got %q", fpA)
+	}
+}
+
+type testPack struct{}
+
+func (testPack) Extensions() []valtor.Extension {
+	return []valtor.Extension{{
+		Name: "x-even",
+		Parse: func(params json.RawMessage) (func(any) error, error) {
+			var enabled bool
+			if err := json.Unmarshal(params, &enabled); err != nil {
+				return nil, err
+			}
+			return func(value any) error {
+				if n, ok := value.(int); ok && enabled && n%2 != 0 {
+					return errors.New("value must be even")
+				}
+				return nil
+			}, nil
+		},
+	}}
+}
+
+func TestParseJSONSchemaExtensions(t *testing.T) {
+	registry := valtor.NewExtensionRegistry()
+	if err := registry.Use(testPack{}); err != nil {
+		t.Fatalf("failed to use pack: %v", err)
+	}
+
+	schema := jsonschema.Schema{
+		Type:   "integer",
+		Extras: map[string]any{"x-even": true, "x-unknown": "ignored"},
+	}
+
+	valtorSchema, err := ParseJSONSchema[any](schema, WithExtensions(registry))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if err := valtorSchema.Validate(2); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := valtorSchema.Validate(3); err == nil || err.Error() != "value must be even" {
+		t.Errorf("expected error %q, got %v", "value must be even", err)
+	}
+
+	schema.Extras["x-even"] = "yes"
+	if _, err := ParseJSONSchema[any](schema, WithExtensions(registry)); err == nil {
+		t.Error("expected error for invalid extension params, got no error")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	schemaBytes, err := os.ReadFile("testdata/generate.json")
+	if err != nil {
+		t.Fatalf("failed to read schema: %v", err)
+	}
+	want, err := os.ReadFile("testdata/generate.golden")
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	var jsonSchema jsonschema.Schema
+	if err := json.Unmarshal(schemaBytes, &jsonSchema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, "models", "User", jsonSchema); err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if got := buf.String(); got != string(want) {
+		t.Errorf("generated code does not match testdata/generate.golden, got:\n%s", got)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	registry := formats.NewRegistry()
+	registry.Register("sku", func(string) error { return nil })
+
+	tests := []struct {
+		name       string
+		schemaJSON string
+		opts       []Option
+	}{
+		{
+			name:       "not an object",
+			schemaJSON: `{"type": "string"}`,
+		},
+		{
+			name:       "array without items",
+			schemaJSON: `{"type": "object", "properties": {"tags": {"type": "array"}}}`,
+		},
+		{
+			name:       "mixed enum",
+			schemaJSON: `{"type": "object", "properties": {"status": {"type": "string", "enum": ["a", 1]}}}`,
+		},
+		{
+			name:       "duplicate type name",
+			schemaJSON: `{"type": "object", "properties": {"a": {"type": "object", "properties": {"b": {"type": "object"}}}, "a_b": {"type": "object"}}}`,
+		},
+		{
+			name:       "custom format",
+			schemaJSON: `{"type": "object", "properties": {"sku": {"type": "string", "format": "sku"}}}`,
+			opts:       []Option{WithFormats(registry)},
+		},
+		{
+			name:       "minimum out of range",
+			schemaJSON: `{"type": "object", "properties": {"n": {"type": "integer", "minimum": 1e19}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsonSchema jsonschema.Schema
+			if err := json.Unmarshal([]byte(tt.schemaJSON), &jsonSchema); err != nil {
+				t.Fatalf("failed to unmarshal schema: %v", err)
+			}
+			if err := Generate(io.Discard, "models", "User", jsonSchema, tt.opts...); err == nil {
+				t.Error("expected error, got no error")
+			}
+		})
+	}
+}
+
+func TestTranslatePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+		wantErr error
+	}{
+		{pattern: `^\d{3}-\w+$`, match: []string{"123-abc"}, noMatch: []string{"12-abc"}},
+		{pattern: `^a.c$`, match: []string{"abc", "a\u00e9c"}, noMatch: []string{"a\nc", "a\u2028c"}},
+		{pattern: `^\s$`, match: []string{" ", "\u00a0", "\u3000"}, noMatch: []string{"a"}},
+		{pattern: `^\S+$`, match: []string{"abc"}, noMatch: []string{"a\u00a0c"}},
+		{pattern: `^[\s-]+$`, match: []string{"-\u2003"}, noMatch: []string{"a"}},
+		{pattern: `^\u00e9\u{1F600}\uD83D\uDE00$`, match: []string{"é\U0001F600\U0001F600"}},
+		{pattern: `^\x41\cJ\0$`, match: []string{"A\n\x00"}},
+		{pattern: `^[^]$`, match: []string{"a", "\n"}},
+		{pattern: `[]`, noMatch: []string{"", "a"}},
+		{pattern: `^[[\]]+$`, match: []string{"[]"}, noMatch: []string{"a"}},
+		{pattern: `^\p{Script=Greek}+$`, match: []string{"αβγ"}, noMatch: []string{"abc"}},
+		{pattern: `^\/\-$`, match: []string{"/-"}},
+		{pattern: `^(?<year>\d{4})$`, match: []string{"2025"}},
+		{pattern: `^(?=a)`, wantErr: ErrUnsupportedPattern},
+		{pattern: `(?<!a)b`, wantErr: ErrUnsupportedPattern},
+		{pattern: `(a)\1`, wantErr: ErrUnsupportedPattern},
+		{pattern: `(?<x>a)\k<x>`, wantErr: ErrUnsupportedPattern},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			translated, err := TranslatePattern(tt.pattern)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to translate pattern: %v", err)
+			}
+
+			re, err := regexp.Compile(translated)
+			if err != nil {
+				t.Fatalf("failed to compile translated pattern %q: %v", translated, err)
+			}
+			for _, s := range tt.match {
+				if !re.MatchString(s) {
+					t.Errorf("expected %q to match %q (translated to %q)", s, tt.pattern, translated)
+				}
+			}
+			for _, s := range tt.noMatch {
+				if re.MatchString(s) {
+					t.Errorf("expected %q not to match %q (translated to %q)", s, tt.pattern, translated)
+				}
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaPatternMode(t *testing.T) {
+	lookahead := jsonschema.Schema{Type: "string", Pattern: `^(?!admin$)[a-z]+$`}
+
+	if _, err := ParseJSONSchema[any](lookahead); !errors.Is(err, ErrUnsupportedPattern) {
+		t.Errorf("expected error %v, got %v", ErrUnsupportedPattern, err)
+	}
+
+	valtorSchema, err := ParseJSONSchema[any](lookahead, WithPatternMode(PatternLenient))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if err := valtorSchema.Validate("admin"); err != nil {
+		t.Errorf("expected ignored pattern, got %q", err)
+	}
+
+	// In RE2 mode, `\s` only matches ASCII white space.
+	space := jsonschema.Schema{Type: "string", Pattern: `^\s$`}
+	for mode, wantValid := range map[PatternMode]bool{PatternECMA: true, PatternRE2: false} {
+		valtorSchema, err := ParseJSONSchema[any](space, WithPatternMode(mode))
+		if err != nil {
+			t.Fatalf("failed to parse schema: %v", err)
+		}
+		if err := valtorSchema.Validate("\u00a0"); (err == nil) != wantValid {
+			t.Errorf("mode %v: expected valid %v, got error %v", mode, wantValid, err)
+		}
+	}
+}
+
+func TestParseJSONSchemaDeprecated(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"username": {"type": "string", "deprecated": true},
+			"profile": {
+				"type": "object",
+				"properties": {"nickname": {"type": "string", "deprecated": true}}
+			}
+		}
+	}`
+
+	var jsonSchema jsonschema.Schema
+	if err := json.Unmarshal([]byte(schemaJSON), &jsonSchema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	valtorSchema, err := ParseJSONSchema[any](jsonSchema)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	result := valtor.ValidateResult(context.Background(), valtorSchema, any(map[string]any{"name": "John"}))
+	if !result.Valid() || len(result.Warnings) != 0 {
+		t.Errorf("expected valid result without warnings, got %+v", result)
+	}
+
+	result = valtor.ValidateResult(context.Background(), valtorSchema, any(map[string]any{"username": "john"}))
+	if !result.Valid() {
+		t.Errorf("expected valid result, got error %q", result.Err)
+	}
+	if len(result.Warnings) != 1 || !errors.Is(result.Warnings[0], valtor.ErrDeprecated) {
+		t.Errorf("expected deprecation warning, got %v", result.Warnings)
+	}
+
+	result = valtor.ValidateResult(context.Background(), valtorSchema, any(map[string]any{"profile": map[string]any{"nickname": "john"}}))
+	if !result.Valid() {
+		t.Errorf("expected valid result, got error %q", result.Err)
+	}
+	var fieldErr *valtor.FieldError
+	if len(result.Warnings) != 1 || !errors.As(result.Warnings[0], &fieldErr) || fieldErr.Field != "nickname" {
+		t.Errorf("expected deprecation warning for nested property, got %v", result.Warnings)
+	}
+}
+
+func TestParseJSONSchemaReadOnlyWriteOnly(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "readOnly": true},
+			"password": {"type": "string", "writeOnly": true},
+			"owner": {
+				"type": "object",
+				"properties": {"id": {"type": "string", "readOnly": true}}
+			},
+			"members": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"password": {"type": "string", "writeOnly": true}}
+				}
+			}
+		},
+		"patternProperties": {
+			"^x-": {
+				"type": "object",
+				"properties": {"token": {"type": "string", "writeOnly": true}}
+			}
+		},
+		"required": ["id"]
+	}`
+
+	var jsonSchema jsonschema.Schema
+	if err := json.Unmarshal([]byte(schemaJSON), &jsonSchema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	valtorSchema, err := ParseJSONSchema[any](jsonSchema)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mode    valtor.Mode
+		value   map[string]any
+		wantErr error
+	}{
+		{name: "any", mode: valtor.ModeAny, value: map[string]any{"id": 1, "password": "secret"}},
+		{name: "request", mode: valtor.ModeRequest, value: map[string]any{"password": "secret"}},
+		{name: "request with read-only", mode: valtor.ModeRequest, value: map[string]any{"id": 1}, wantErr: valtor.ErrReadOnly},
+		{name: "response", mode: valtor.ModeResponse, value: map[string]any{"id": 1}},
+		{name: "response without required", mode: valtor.ModeResponse, value: map[string]any{}, wantErr: valtor.ErrValueRequired},
+		{name: "response with write-only", mode: valtor.ModeResponse, value: map[string]any{"id": 1, "password": "secret"}, wantErr: valtor.ErrWriteOnly},
+		{name: "request with nested read-only", mode: valtor.ModeRequest, value: map[string]any{"owner": map[string]any{"id": "x"}}, wantErr: valtor.ErrReadOnly},
+		{name: "response with nested read-only", mode: valtor.ModeResponse, value: map[string]any{"id": 1, "owner": map[string]any{"id": "x"}}},
+		{name: "response with write-only in item", mode: valtor.ModeResponse, value: map[string]any{"id": 1, "members": []any{map[string]any{"password": "secret"}}}, wantErr: valtor.ErrWriteOnly},
+		{name: "response with write-only in pattern property", mode: valtor.ModeResponse, value: map[string]any{"id": 1, "x-auth": map[string]any{"token": "secret"}}, wantErr: valtor.ErrWriteOnly},
+		{name: "request with write-only in pattern property", mode: valtor.ModeRequest, value: map[string]any{"x-auth": map[string]any{"token": "secret"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := valtor.WithMode(context.Background(), tt.mode)
+			err := valtorSchema.ValidateContext(ctx, tt.value)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaReport(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "exclusiveMinimum": 0},
+			"code": {"type": "string", "format": "x-unknown", "pattern": "^(?!x)"},
+			"tags": {"type": "array", "items": {"type": "string", "const": "a"}},
+			"a/b": {"type": "string", "$dynamicRef": "#name"}
+		},
+		"additionalProperties": {"type": "string"},
+		"allOf": [{"required": ["id"]}]
+	}`
+
+	var jsonSchema jsonschema.Schema
+	if err := json.Unmarshal([]byte(schemaJSON), &jsonSchema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+	jsonSchema.Extras = map[string]any{"x-unknown": true}
+
+	var report Report
+	if _, err := ParseJSONSchema[any](jsonSchema, WithReport(&report), WithPatternMode(PatternLenient)); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	want := []UnsupportedKeyword{
+		{Path: "", Keyword: "allOf"},
+		{Path: "", Keyword: "additionalProperties"},
+		{Path: "/properties/id", Keyword: "exclusiveMinimum"},
+		{Path: "/properties/code", Keyword: "pattern"},
+		{Path: "/properties/code", Keyword: "format"},
+		{Path: "/properties/tags/items", Keyword: "const"},
+		{Path: "/properties/a~1b", Keyword: "$dynamicRef"},
+		{Path: "", Keyword: "x-unknown"},
+	}
+	if !reflect.DeepEqual(report.Unsupported, want) {
+		t.Errorf("expected unsupported keywords %v, got %v", want, report.Unsupported)
+	}
+	if report.Complete() {
+		t.Error("expected incomplete report")
+	}
+
+	report = Report{}
+	if _, err := ParseJSONSchema[any](jsonschema.Schema{Type: "string", MinLength: new(uint64)}, WithReport(&report)); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if !report.Complete() {
+		t.Errorf("expected complete report, got %v", report.Unsupported)
+	}
+}
+
+func TestParseJSONSchemaStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  jsonschema.Schema
+		wantErr error
+	}{
+		{name: "supported", schema: jsonschema.Schema{Type: "string", Format: "email"}},
+		{name: "unsupported keyword", schema: jsonschema.Schema{Type: "number", MultipleOf: "2"}, wantErr: ErrUnsupportedKeyword},
+		{name: "unknown format", schema: jsonschema.Schema{Type: "string", Format: "x-unknown"}, wantErr: ErrUnsupportedKeyword},
+		{name: "true additional properties", schema: jsonschema.Schema{Type: "object", AdditionalProperties: jsonschema.TrueSchema}},
+		{
+			name: "nested",
+			schema: jsonschema.Schema{
+				Type:  "array",
+				Items: &jsonschema.Schema{Type: "string", Not: &jsonschema.Schema{Type: "string"}},
+			},
+			wantErr: ErrUnsupportedKeyword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJSONSchema[any](tt.schema, WithStrict())
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaTypeArray(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"name": {"type": ["string", "null"], "minLength": 2},
+			"count": {"type": ["integer", "string"], "minimum": 1, "pattern": "^[0-9]+$"},
+			"tags": {"type": "array", "items": {"type": ["string"]}}
+		}
+	}`
+
+	jsonSchema, err := UnmarshalJSONSchema([]byte(schemaJSON))
+	if err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	var report Report
+	valtorSchema, err := ParseJSONSchema[any](jsonSchema, WithReport(&report))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if !report.Complete() {
+		t.Errorf("expected complete report, got %v", report.Unsupported)
+	}
+
+	tests := []struct {
+		name          string
+		value         map[string]any
+		expectedError string
+	}{
+		{name: "string", value: map[string]any{"name": "Alice"}},
+		{name: "null", value: map[string]any{"name": nil}},
+		{name: "too short", value: map[string]any{"name": "A"}, expectedError: `validation failed for field "name": length must be at least 2, got 1`},
+		{name: "wrong type", value: map[string]any{"name": true}, expectedError: `validation failed for field "name": expected string or null value, got bool`},
+		{name: "integer", value: map[string]any{"count": 2.0}},
+		{name: "integer too small", value: map[string]any{"count": 0.0}, expectedError: `validation failed for field "count": value must be at least 1, got 0`},
+		{name: "numeric string", value: map[string]any{"count": "12"}},
+		{name: "non-numeric string", value: map[string]any{"count": "twelve"}, expectedError: `validation failed for field "count": string must match pattern "^[0-9]+$"`},
+		{name: "fraction", value: map[string]any{"count": 1.5}, expectedError: `validation failed for field "count": expected integer or string value, got float64`},
+		{name: "single type", value: map[string]any{"tags": []any{"a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := valtorSchema.Validate(tt.value)
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaNullable(t *testing.T) {
+	// Reflected schemas of nullable fields list the types with `oneOf`.
+	schema := jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{{Type: "integer"}, {Type: "null"}},
+	}
+
+	valtorSchema, err := ParseJSONSchema[any](schema, WithStrict())
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if err := valtorSchema.Validate(nil); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := valtorSchema.Validate("1"); err == nil {
+		t.Error("expected error for string value, got no error")
+	}
+
+	schema.OneOf = []*jsonschema.Schema{{Type: "integer"}, {Type: "number"}}
+	if _, err := ParseJSONSchema[any](schema, WithStrict()); !errors.Is(err, ErrUnsupportedKeyword) {
+		t.Errorf("expected error %v, got %v", ErrUnsupportedKeyword, err)
+	}
+}
+
+func TestParseJSONSchemaBytes(t *testing.T) {
+	registry := valtor.NewExtensionRegistry()
+	if err := registry.Use(testPack{}); err != nil {
+		t.Fatalf("failed to use pack: %v", err)
+	}
+
+	schemaJSON := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"count": {"type": "integer", "x-even": true},
+			"labels": {
+				"type": "object",
+				"unevaluatedProperties": false,
+				"additionalProperties": {"type": ["string", "null"]}
+			}
+		}
+	}`
+
+	var report Report
+	valtorSchema, err := ParseJSONSchemaBytes[any]([]byte(schemaJSON), WithExtensions(registry), WithReport(&report))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	want := []UnsupportedKeyword{
+		{Path: "/properties/labels", Keyword: "additionalProperties"},
+		{Path: "/properties/labels", Keyword: "unevaluatedProperties"},
+	}
+	if !reflect.DeepEqual(report.Unsupported, want) {
+		t.Errorf("expected unsupported keywords %v, got %v", want, report.Unsupported)
+	}
+
+	if err := valtorSchema.Validate(map[string]any{"count": 2}); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := valtorSchema.Validate(map[string]any{"count": 3}); err == nil {
+		t.Error("expected error for odd count, got no error")
+	}
+
+	if _, err := ParseJSONSchemaBytes[any]([]byte(`{"type": `)); err == nil {
+		t.Error("expected error for invalid JSON, got no error")
+	}
+	if _, err := ParseJSONSchemaBytes[any]([]byte(`{"type": "string", "minLength": "1"}`)); err == nil {
+		t.Error("expected error for invalid keyword value, got no error")
+	}
+	if _, err := ParseJSONSchemaBytes[any]([]byte(`[]`)); err == nil {
+		t.Error("expected error for array schema, got no error")
+	}
+}
+
+func TestParseJSONSchemaBytesModel(t *testing.T) {
+	// Properties are parsed in the order of the document, and a type array
+	// can be combined with `anyOf`.
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"zeta": {"type": "integer", "const": 1},
+			"alpha": {"type": ["string", "null"], "anyOf": [{"minLength": 1}]}
+		}
+	}`
+
+	var report Report
+	valtorSchema, err := ParseJSONSchemaBytes[any]([]byte(schemaJSON), WithReport(&report))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	want := []UnsupportedKeyword{
+		{Path: "/properties/zeta", Keyword: "const"},
+		{Path: "/properties/alpha", Keyword: "anyOf"},
+	}
+	if !reflect.DeepEqual(report.Unsupported, want) {
+		t.Errorf("expected unsupported keywords %v, got %v", want, report.Unsupported)
+	}
+
+	if err := valtorSchema.Validate(map[string]any{"alpha": nil}); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := valtorSchema.Validate(map[string]any{"alpha": 1}); err == nil {
+		t.Error("expected error for number value, got no error")
+	}
+}
+
+func TestParseJSONSchemaAnnotations(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"age": {"type": "integer", "minimum": 0, "title": "Age", "description": "user's age in years"},
+			"name": {"type": "string", "minLength": 2, "title": "Name"},
+			"nickname": {"type": "string", "minLength": 2}
+		}
+	}`
+
+	valtorSchema, err := ParseJSONSchemaBytes[any]([]byte(schemaJSON))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		value       map[string]any
+		wantErr     string
+		wantVerbose string
+	}{
+		{
+			name:        "title and description",
+			value:       map[string]any{"age": -1},
+			wantErr:     `validation failed for field "age": value must be at least 0, got -1`,
+			wantVerbose: "Age (user's age in years): value must be at least 0, got -1",
+		},
+		{
+			name:        "title",
+			value:       map[string]any{"name": "A"},
+			wantErr:     `validation failed for field "name": length must be at least 2, got 1`,
+			wantVerbose: "Name: length must be at least 2, got 1",
+		},
+		{
+			name:        "no annotations",
+			value:       map[string]any{"nickname": "A"},
+			wantErr:     `validation failed for field "nickname": length must be at least 2, got 1`,
+			wantVerbose: `validation failed for field "nickname": length must be at least 2, got 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := valtorSchema.Validate(tt.value)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+			if got := valtor.Verbose(err); got != tt.wantVerbose {
+				t.Errorf("expected verbose error %q, got %q", tt.wantVerbose, got)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaIntegerFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		value   any
+		wantErr string
+	}{
+		{name: "int32", format: "int32", value: float64(math.MaxInt32)},
+		{name: "int32 overflow", format: "int32", value: float64(math.MaxInt32 + 1), wantErr: "int32 value must be between -2147483648 and 2147483647, got 2147483648"},
+		{name: "int32 underflow", format: "int32", value: int64(math.MinInt32 - 1), wantErr: "int32 value must be between -2147483648 and 2147483647, got -2147483649"},
+		{name: "int64 json.Number", format: "int64", value: json.Number("9223372036854775807")},
+		{name: "int64 json.Number overflow", format: "int64", value: json.Number("9223372036854775808"), wantErr: "int64 value must be between -9223372036854775808 and 9223372036854775807, got 9223372036854775808"},
+		{name: "uint", format: "uint", value: json.Number("18446744073709551615")},
+		{name: "uint negative", format: "uint", value: -1, wantErr: "uint value must be between 0 and 18446744073709551615, got -1"},
+		{name: "uint8", format: "uint8", value: 256, wantErr: "uint8 value must be between 0 and 255, got 256"},
+		{name: "nil", format: "int32", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valtorSchema, err := ParseJSONSchema[any](jsonschema.Schema{Type: "integer", Format: tt.format})
+			if err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			err = valtorSchema.Validate(tt.value)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+			var rangeErr *valtor.RangeError
+			if tt.wantErr != "" && !errors.As(err, &rangeErr) {
+				t.Errorf("expected range error, got %v", err)
+			}
+		})
+	}
+
+	if _, err := ParseJSONSchema[any](jsonschema.Schema{Type: "integer", Format: "int128"}, WithStrict()); !errors.Is(err, ErrUnsupportedKeyword) {
+		t.Errorf("expected error %v, got %v", ErrUnsupportedKeyword, err)
+	}
+}
+
+func TestParseJSONSchemaIntegerCoercion(t *testing.T) {
+	schema := jsonschema.Schema{Type: "integer", Minimum: "1", Maximum: "100"}
+	int32Schema := jsonschema.Schema{Type: "integer", Format: "int32"}
+
+	tests := []struct {
+		name    string
+		schema  jsonschema.Schema
+		mode    valtor.CoerceMode
+		profile *valtor.Profile
+		value   any
+		wantErr bool
+	}{
+		{name: "strict fraction", schema: schema, mode: valtor.CoerceStrict, value: 1.5, wantErr: true},
+		{name: "strict json.Number fraction", schema: schema, mode: valtor.CoerceStrict, value: json.Number("1.5"), wantErr: true},
+		{name: "truncate fraction", schema: schema, mode: valtor.CoerceTruncate, value: 1.5},
+		{name: "truncate json.Number fraction", schema: schema, mode: valtor.CoerceTruncate, value: json.Number("1.5")},
+		{name: "truncate below minimum", schema: schema, mode: valtor.CoerceTruncate, value: 0.9, wantErr: true},
+		{name: "truncate overflow", schema: schema, mode: valtor.CoerceTruncate, value: 1e20, wantErr: true},
+		{name: "clamp int64 overflow", schema: jsonschema.Schema{Type: "integer"}, mode: valtor.CoerceClamp, value: 1e20},
+		{name: "clamp to maximum is still validated", schema: schema, mode: valtor.CoerceClamp, value: 1e20, wantErr: true},
+		{name: "truncate format overflow", schema: int32Schema, mode: valtor.CoerceTruncate, value: float64(math.MaxInt32) + 1.5, wantErr: true},
+		{name: "clamp format overflow", schema: int32Schema, mode: valtor.CoerceClamp, value: float64(math.MaxInt32) + 1.5},
+		{name: "clamp json.Number format underflow", schema: int32Schema, mode: valtor.CoerceClamp, value: json.Number("-1e30")},
+		{name: "clamp non-number", schema: int32Schema, mode: valtor.CoerceClamp, value: "1", wantErr: true},
+		{name: "strict with dev profile", schema: schema, mode: valtor.CoerceStrict, profile: &valtor.DevProfile, value: 1.5},
+		{name: "strict with prod profile", schema: schema, mode: valtor.CoerceStrict, profile: &valtor.ProdProfile, value: 1.5, wantErr: true},
+		{name: "clamp with prod profile", schema: int32Schema, mode: valtor.CoerceClamp, profile: &valtor.ProdProfile, value: float64(math.MaxInt32) + 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valtorSchema, err := ParseJSONSchema[any](tt.schema, WithIntegerCoercion(tt.mode))
+			if err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.profile != nil {
+				ctx = valtor.WithProfile(ctx, *tt.profile)
+			}
+			err = valtorSchema.ValidateContext(ctx, tt.value)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got no error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaIntegerCoercionRun(t *testing.T) {
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"count": {"type": "integer"},
+			"level": {"type": "integer", "format": "int8"},
+			"sizes": {"type": "array", "items": {"type": "integer"}},
+			"limit": {"type": ["integer", "null"]},
+			"item": {"$ref": "#/$defs/item"}
+		},
+		"patternProperties": {"^x-": {"type": "integer"}},
+		"$defs": {
+			"item": {"type": "object", "properties": {"id": {"type": "integer"}}}
+		}
+	}`
+
+	jsonSchema, err := UnmarshalJSONSchema([]byte(schemaJSON))
+	if err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	value := map[string]any{
+		"count": 1.5,
+		"level": 1000.0,
+		"sizes": []any{2.7, 3.0},
+		"limit": 4.0,
+		"item":  map[string]any{"id": 5.9},
+		"x-n":   6.1,
+		"name":  "a",
+	}
+
+	tests := []struct {
+		name    string
+		mode    valtor.CoerceMode
+		profile *valtor.Profile
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "strict",
+			mode:    valtor.CoerceStrict,
+			want:    value,
+			wantErr: true,
+		},
+		{
+			name:    "strict with dev profile",
+			mode:    valtor.CoerceStrict,
+			profile: &valtor.DevProfile,
+			want: map[string]any{
+				"count": int64(1),
+				"level": int64(1000),
+				"sizes": []any{int64(2), int64(3)},
+				"limit": int64(4),
+				"item":  map[string]any{"id": int64(5)},
+				"x-n":   int64(6),
+				"name":  "a",
+			},
+			wantErr: true,
+		},
+		{
+			name: "clamp",
+			mode: valtor.CoerceClamp,
+			want: map[string]any{
+				"count": int64(1),
+				"level": int64(127),
+				"sizes": []any{int64(2), int64(3)},
+				"limit": int64(4),
+				"item":  map[string]any{"id": int64(5)},
+				"x-n":   int64(6),
+				"name":  "a",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valtorSchema, err := ParseJSONSchema[any](jsonSchema, WithIntegerCoercion(tt.mode))
+			if err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.profile != nil {
+				ctx = valtor.WithProfile(ctx, *tt.profile)
+			}
+			out := valtor.Run(ctx, valtorSchema, any(value))
+			if !reflect.DeepEqual(out.Value, any(tt.want)) {
+				t.Errorf("expected %v, got %v", tt.want, out.Value)
+			}
+			if tt.wantErr && out.Err == nil {
+				t.Error("expected error, got no error")
+			}
+			if !tt.wantErr && out.Err != nil {
+				t.Errorf("expected no error, got %q", out.Err)
+			}
+		})
+	}
+
+	if value["count"] != 1.5 || value["sizes"].([]any)[0] != 2.7 {
+		t.Error("expected input value to be unchanged")
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// schemaNode is the internal model of a JSON Schema that the parser works on.
+// It has a field for each keyword that the parser enforces or reports, and
+// keeps keywords it doesn't know, such as extension keywords, in Extras.
+// Annotations other than the ones valtor supports, such as `examples`, are
+// not kept.
+type schemaNode struct {
+	// Bool is set for the boolean schemas `true` and `false`.
+	Bool *bool `json:"-"`
+
+	ID         string `json:"$id"`
+	Ref        string `json:"$ref"`
+	DynamicRef string `json:"$dynamicRef"`
+
+	// Type is the type of the schema, if it has a single type. Types are the
+	// types of a type array.
+	Type  string   `json:"-"`
+	Types []string `json:"-"`
+
+	AllOf            []*schemaNode          `json:"allOf"`
+	AnyOf            []*schemaNode          `json:"anyOf"`
+	OneOf            []*schemaNode          `json:"oneOf"`
+	Not              *schemaNode            `json:"not"`
+	If               *schemaNode            `json:"if"`
+	Then             *schemaNode            `json:"then"`
+	Else             *schemaNode            `json:"else"`
+	DependentSchemas map[string]*schemaNode `json:"dependentSchemas"`
+
+	PrefixItems []*schemaNode `json:"prefixItems"`
+	Items       *schemaNode   `json:"items"`
+	Contains    *schemaNode   `json:"contains"`
+	MinItems    *uint64       `json:"minItems"`
+	MaxItems    *uint64       `json:"maxItems"`
+	UniqueItems bool          `json:"uniqueItems"`
+	MinContains *uint64       `json:"minContains"`
+	MaxContains *uint64       `json:"maxContains"`
+
+	Properties           propertyList           `json:"properties"`
+	PatternProperties    map[string]*schemaNode `json:"patternProperties"`
+	AdditionalProperties *schemaNode            `json:"additionalProperties"`
+	PropertyNames        *schemaNode            `json:"propertyNames"`
+	Required             []string               `json:"required"`
+	DependentRequired    map[string][]string    `json:"dependentRequired"`
+	MinProperties        *uint64                `json:"minProperties"`
+	MaxProperties        *uint64                `json:"maxProperties"`
+
+	Enum  []any           `json:"-"`
+	Const json.RawMessage `json:"const"`
+
+	MultipleOf       json.Number `json:"multipleOf"`
+	Minimum          json.Number `json:"minimum"`
+	Maximum          json.Number `json:"maximum"`
+	ExclusiveMinimum json.Number `json:"exclusiveMinimum"`
+	ExclusiveMaximum json.Number `json:"exclusiveMaximum"`
+
+	MinLength *uint64 `json:"minLength"`
+	MaxLength *uint64 `json:"maxLength"`
+	Pattern   string  `json:"pattern"`
+	Format    string  `json:"format"`
+
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Deprecated  bool   `json:"deprecated"`
+	ReadOnly    bool   `json:"readOnly"`
+	WriteOnly   bool   `json:"writeOnly"`
+
+	// Extras are the keywords that are not in the model, by name.
+	Extras map[string]any `json:"-"`
+}
+
+// property is a subschema of `properties`.
+type property struct {
+	name   string
+	schema *schemaNode
+}
+
+// propertyList are the subschemas of `properties`, in the order of the document.
+type propertyList []property
+
+// get returns the subschema of the property with the name.
+func (p propertyList) get(name string) (*schemaNode, bool) {
+	for _, prop := range p {
+		if prop.name == name {
+			return prop.schema, true
+		}
+	}
+	return nil, false
+}
+
+// UnmarshalJSON decodes the subschemas of `properties`, keeping their order.
+func (p *propertyList) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return errors.New("`properties` must be an object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var schema *schemaNode
+		if err := dec.Decode(&schema); err != nil {
+			return err
+		}
+		*p = append(*p, property{name: tok.(string), schema: schema})
+	}
+	return nil
+}
+
+// annotationKeywords are keywords that are not in the model, but that don't
+// affect validation, so they are not kept in Extras.
+var annotationKeywords = map[string]bool{
+	"$schema":          true,
+	"$anchor":          true,
+	"$defs":            true,
+	"definitions":      true,
+	"$comment":         true,
+	"$vocabulary":      true,
+	"$dynamicAnchor":   true,
+	"$recursiveAnchor": true,
+	"contentEncoding":  true,
+	"contentMediaType": true,
+	"contentSchema":    true,
+	"default":          true,
+	"examples":         true,
+}
+
+// modelKeywords are the keywords that schemaNode has a field for.
+var modelKeywords = func() map[string]bool {
+	keywords := map[string]bool{"type": true, "enum": true}
+	t := reflect.TypeFor[schemaNode]()
+	for i := range t.NumField() {
+		if name := t.Field(i).Tag.Get("json"); name != "-" {
+			keywords[name] = true
+		}
+	}
+	return keywords
+}()
+
+// UnmarshalJSON decodes a schema, which is either an object or a boolean.
+// Numbers are decoded as json.Number, so that they keep their precision.
+func (n *schemaNode) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("true")), bytes.Equal(data, []byte("false")):
+		b := data[0] == 't'
+		*n = schemaNode{Bool: &b}
+		return nil
+	case len(data) == 0 || data[0] != '{':
+		return errors.New("schema must be an object or a boolean")
+	}
+
+	// The alias has the fields, but not the methods of schemaNode, so that
+	// decoding into it doesn't recurse.
+	type node schemaNode
+	var decoded node
+	if err := decodeNumbers(data, &decoded); err != nil {
+		return err
+	}
+	*n = schemaNode(decoded)
+
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return err
+	}
+	if enum, ok := keywords["enum"]; ok {
+		// Values are compared by their JSON encoding (see enumValidator), so
+		// that e.g. `1.0` matches 1.
+		n.Enum = nil
+		if err := json.Unmarshal(enum, &n.Enum); err != nil {
+			return err
+		}
+	}
+	for keyword, value := range keywords {
+		if modelKeywords[keyword] || annotationKeywords[keyword] {
+			continue
+		}
+		var v any
+		if err := decodeNumbers(value, &v); err != nil {
+			return err
+		}
+		if n.Extras == nil {
+			n.Extras = make(map[string]any)
+		}
+		n.Extras[keyword] = v
+	}
+
+	if typ, ok := keywords["type"]; ok {
+		return n.decodeType(typ)
+	}
+	return nil
+}
+
+// decodeType decodes `type`, which is either a type or an array of types.
+func (n *schemaNode) decodeType(data []byte) error {
+	if err := json.Unmarshal(data, &n.Type); err == nil {
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return fmt.Errorf("%w: `type` must be a string or an array of strings", ErrInvalidType)
+	}
+	if len(types) == 1 {
+		n.Type = types[0]
+	} else {
+		n.Types = types
+	}
+	return nil
+}
+
+// decodeNumbers decodes data into v, with numbers as json.Number.
+func decodeNumbers(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// decodeSchema decodes a JSON Schema document into the internal model.
+func decodeSchema(data []byte) (schemaNode, error) {
+	var schema schemaNode
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return schema, fmt.Errorf("invalid schema: %w", err)
+	}
+	return schema, nil
+}
+
+// isFalseSchema reports whether schema is the boolean schema `false`, which
+// matches no value.
+func isFalseSchema(schema *schemaNode) bool {
+	return schema != nil && schema.Bool != nil && !*schema.Bool
+}
+
+// isTrueSchema reports whether schema is the boolean schema `true`, which
+// matches any value.
+func isTrueSchema(schema *schemaNode) bool {
+	return schema != nil && schema.Bool != nil && *schema.Bool
+}
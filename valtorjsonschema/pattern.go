@@ -0,0 +1,352 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/dstotijn/valtor"
+)
+
+// ErrUnsupportedPattern is returned for ECMA-262 patterns that use constructs
+// which can't be translated to Go's regexp syntax, such as lookarounds.
+var ErrUnsupportedPattern = errors.New("unsupported pattern")
+
+// PatternMode determines how the regular expressions of the `pattern` and
+// `patternProperties` keywords are compiled.
+type PatternMode int
+
+const (
+	// PatternECMA translates patterns from ECMA-262, the regular expression
+	// dialect of JSON Schema, to Go's regexp syntax (see TranslatePattern).
+	// Patterns that can't be translated fail to parse. This is the default.
+	PatternECMA PatternMode = iota
+	// PatternLenient is like PatternECMA, but patterns that can't be
+	// translated are ignored, so that values are not validated against them.
+	PatternLenient
+	// PatternRE2 compiles patterns as is, with Go's regexp syntax.
+	PatternRE2
+)
+
+// WithPatternMode sets how the regular expressions of the `pattern` and
+// `patternProperties` keywords are compiled. Defaults to PatternECMA.
+func WithPatternMode(mode PatternMode) Option {
+	return func(cfg *config) {
+		cfg.patternMode = mode
+	}
+}
+
+// compilePattern compiles a pattern according to the pattern mode. It returns
+// a nil regular expression if the pattern is ignored.
+func (cfg *config) compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cfg.patternMode != PatternRE2 {
+		translated, err := TranslatePattern(pattern)
+		if errors.Is(err, ErrUnsupportedPattern) && cfg.patternMode == PatternLenient {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		pattern = translated
+	}
+	return valtor.CompilePattern(pattern)
+}
+
+// ecmaSpaces are the ranges of white space and line terminator characters,
+// which are matched by `\s` in ECMA-262. In Go's regexp syntax, `\s` only
+// matches ASCII white space.
+var ecmaSpaces = [][2]rune{
+	{'\t', '\r'}, {' ', ' '}, {0xa0, 0xa0}, {0x1680, 0x1680}, {0x2000, 0x200a},
+	{0x2028, 0x2029}, {0x202f, 0x202f}, {0x205f, 0x205f}, {0x3000, 0x3000},
+	{0xfeff, 0xfeff},
+}
+
+// spaceClass returns the contents of a character class (without brackets)
+// that matches the characters of ecmaSpaces, or all other characters if
+// negate is true.
+func spaceClass(negate bool) string {
+	var b strings.Builder
+	writeRange := func(lo, hi rune) {
+		fmt.Fprintf(&b, `\x{%x}`, lo)
+		if hi != lo {
+			fmt.Fprintf(&b, `-\x{%x}`, hi)
+		}
+	}
+	if !negate {
+		for _, r := range ecmaSpaces {
+			writeRange(r[0], r[1])
+		}
+		return b.String()
+	}
+	next := rune(0)
+	for _, r := range ecmaSpaces {
+		if r[0] > next {
+			writeRange(next, r[0]-1)
+		}
+		next = r[1] + 1
+	}
+	writeRange(next, 0x10ffff)
+	return b.String()
+}
+
+// TranslatePattern translates an ECMA-262 regular expression, the dialect of
+// JSON Schema, to Go's regexp syntax. Escapes, character classes and the
+// meaning of `.` and `\s` are translated so that they match the same
+// characters. Lookaheads, lookbehinds and backreferences have no equivalent
+// and result in ErrUnsupportedPattern.
+func TranslatePattern(pattern string) (string, error) {
+	t := &patternTranslator{src: []rune(pattern)}
+	if err := t.translate(); err != nil {
+		return "", err
+	}
+	return t.out.String(), nil
+}
+
+type patternTranslator struct {
+	src []rune
+	pos int
+	out strings.Builder
+}
+
+func (t *patternTranslator) peek(s string) bool {
+	return strings.HasPrefix(string(t.src[t.pos:]), s)
+}
+
+func (t *patternTranslator) translate() error {
+	for t.pos < len(t.src) {
+		switch c := t.src[t.pos]; c {
+		case '\\':
+			if err := t.escape(false); err != nil {
+				return err
+			}
+		case '[':
+			if err := t.class(); err != nil {
+				return err
+			}
+		case '.':
+			// In ECMA-262, `.` doesn't match any line terminator.
+			t.out.WriteString(`[^\n\r\x{2028}\x{2029}]`)
+			t.pos++
+		case '(':
+			switch {
+			case t.peek("(?=") || t.peek("(?!"):
+				return fmt.Errorf("%w: lookaheads are not supported", ErrUnsupportedPattern)
+			case t.peek("(?<=") || t.peek("(?<!"):
+				return fmt.Errorf("%w: lookbehinds are not supported", ErrUnsupportedPattern)
+			}
+			t.out.WriteRune(c)
+			t.pos++
+		default:
+			t.out.WriteRune(c)
+			t.pos++
+		}
+	}
+	return nil
+}
+
+// class translates a character class, starting at its opening bracket.
+func (t *patternTranslator) class() error {
+	t.pos++ // Skip `[`.
+	negate := t.pos < len(t.src) && t.src[t.pos] == '^'
+	if negate {
+		t.pos++
+	}
+
+	// `[]` matches nothing and `[^]` matches anything, whereas Go's regexp
+	// syntax treats a leading `]` as a literal.
+	if t.pos < len(t.src) && t.src[t.pos] == ']' {
+		t.pos++
+		if negate {
+			t.out.WriteString(`[\x00-\x{10ffff}]`)
+		} else {
+			t.out.WriteString(`[^\x00-\x{10ffff}]`)
+		}
+		return nil
+	}
+
+	t.out.WriteByte('[')
+	if negate {
+		t.out.WriteByte('^')
+	}
+	for t.pos < len(t.src) {
+		switch c := t.src[t.pos]; c {
+		case ']':
+			t.out.WriteByte(']')
+			t.pos++
+			return nil
+		case '\\':
+			if err := t.escape(true); err != nil {
+				return err
+			}
+		case '[':
+			// A literal in ECMA-262, but `[:` starts an ASCII class in Go.
+			t.out.WriteString(`\[`)
+			t.pos++
+		default:
+			t.out.WriteRune(c)
+			t.pos++
+		}
+	}
+	return errors.New("missing closing ]")
+}
+
+// escape translates an escape sequence, starting at its backslash.
+func (t *patternTranslator) escape(inClass bool) error {
+	t.pos++ // Skip `\`.
+	if t.pos >= len(t.src) {
+		return errors.New("trailing backslash at end of expression")
+	}
+	c := t.src[t.pos]
+	t.pos++
+
+	switch {
+	case strings.ContainsRune("dDwWtnrfv", c):
+		t.out.WriteRune('\\')
+		t.out.WriteRune(c)
+	case c == 'b' || c == 'B':
+		if inClass {
+			if c == 'B' {
+				return errors.New(`invalid escape \B in character class`)
+			}
+			// A backspace in a character class.
+			t.out.WriteString(`\x08`)
+			return nil
+		}
+		t.out.WriteRune('\\')
+		t.out.WriteRune(c)
+	case c == 's' || c == 'S':
+		if inClass {
+			t.out.WriteString(spaceClass(c == 'S'))
+		} else {
+			fmt.Fprintf(&t.out, "[%s]", spaceClass(c == 'S'))
+		}
+	case c == '0' && (t.pos >= len(t.src) || !isDigit(t.src[t.pos])):
+		t.out.WriteString(`\x00`)
+	case isDigit(c) && !inClass:
+		return fmt.Errorf("%w: backreferences are not supported", ErrUnsupportedPattern)
+	case c == 'k' && t.pos < len(t.src) && t.src[t.pos] == '<':
+		return fmt.Errorf("%w: backreferences are not supported", ErrUnsupportedPattern)
+	case c == 'c' && t.pos < len(t.src) && isASCIILetter(t.src[t.pos]):
+		fmt.Fprintf(&t.out, `\x{%x}`, t.src[t.pos]%32)
+		t.pos++
+	case c == 'x':
+		r, ok := t.hex(2)
+		if !ok {
+			return errors.New(`invalid escape \x`)
+		}
+		fmt.Fprintf(&t.out, `\x{%x}`, r)
+	case c == 'u':
+		r, err := t.unicodeEscape()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&t.out, `\x{%x}`, r)
+	case c == 'p' || c == 'P':
+		return t.property(c)
+	case c < 0x80 && !isASCIILetter(c) && !isDigit(c):
+		// Go's regexp syntax allows escaping any ASCII punctuation.
+		t.out.WriteRune('\\')
+		t.out.WriteRune(c)
+	default:
+		// An identity escape, e.g. `\a` for `a`.
+		t.out.WriteString(regexp.QuoteMeta(string(c)))
+	}
+	return nil
+}
+
+// hex reads n hexadecimal digits.
+func (t *patternTranslator) hex(n int) (rune, bool) {
+	if t.pos+n > len(t.src) {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(t.src[t.pos:t.pos+n]), 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	t.pos += n
+	return rune(v), true
+}
+
+// unicodeEscape reads the code point of a `\uXXXX` or `\u{X...}` escape,
+// after the `u`. A surrogate pair of two escapes is read as one code point.
+func (t *patternTranslator) unicodeEscape() (rune, error) {
+	if t.pos < len(t.src) && t.src[t.pos] == '{' {
+		end := t.pos + 1
+		for end < len(t.src) && t.src[end] != '}' {
+			end++
+		}
+		v, err := strconv.ParseUint(string(t.src[t.pos+1:end]), 16, 32)
+		if end >= len(t.src) || err != nil || v > 0x10ffff {
+			return 0, errors.New(`invalid escape \u`)
+		}
+		t.pos = end + 1
+		return rune(v), nil
+	}
+
+	r, ok := t.hex(4)
+	if !ok {
+		return 0, errors.New(`invalid escape \u`)
+	}
+	if utf16.IsSurrogate(r) && t.peek(`\u`) {
+		start := t.pos
+		t.pos += 2
+		if low, ok := t.hex(4); ok {
+			if combined := utf16.DecodeRune(r, low); combined != unicode.ReplacementChar {
+				return combined, nil
+			}
+		}
+		t.pos = start
+	}
+	return r, nil
+}
+
+// property translates a Unicode property escape, e.g. `\p{L}` or
+// `\p{Script=Greek}`, after the `p` or `P`.
+func (t *patternTranslator) property(c rune) error {
+	end := t.pos
+	for end < len(t.src) && t.src[end] != '}' {
+		end++
+	}
+	if t.pos >= len(t.src) || t.src[t.pos] != '{' || end >= len(t.src) {
+		return fmt.Errorf(`invalid escape \%c`, c)
+	}
+	name := string(t.src[t.pos+1 : end])
+	t.pos = end + 1
+
+	if key, value, ok := strings.Cut(name, "="); ok {
+		switch key {
+		case "General_Category", "gc", "Script", "sc":
+			name = value
+		default:
+			return fmt.Errorf("%w: Unicode property %q is not supported", ErrUnsupportedPattern, key)
+		}
+	}
+	fmt.Fprintf(&t.out, `\%c{%s}`, c, name)
+	return nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isASCIILetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
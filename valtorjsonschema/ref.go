@@ -0,0 +1,372 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dstotijn/valtor"
+)
+
+// ErrRefNotAllowed is returned for a `$ref` to another document if no loader
+// is set, or if the URI of the document is not allowed (see WithLoader).
+var ErrRefNotAllowed = errors.New("reference not allowed")
+
+const (
+	// maxDocumentSize is the maximum size of a document loaded by HTTPLoader.
+	maxDocumentSize = 10 << 20
+	// loadTimeout is the timeout of the default client of HTTPLoader.
+	loadTimeout = 10 * time.Second
+)
+
+// Loader loads the JSON Schema document with an absolute URI, to resolve a
+// `$ref` to another document (see WithLoader). The context is the one set with
+// WithContext.
+type Loader interface {
+	Load(ctx context.Context, uri *url.URL) ([]byte, error)
+}
+
+// LoaderFunc adapts a function to Loader.
+type LoaderFunc func(ctx context.Context, uri *url.URL) ([]byte, error)
+
+// Load calls fn(ctx, uri).
+func (fn LoaderFunc) Load(ctx context.Context, uri *url.URL) ([]byte, error) {
+	return fn(ctx, uri)
+}
+
+// HTTPLoader returns a loader that gets `http` and `https` URIs with client, or
+// a client with a timeout of 10 seconds if client is nil. Requests are
+// canceled with the context of the parse (see WithContext). Documents larger
+// than 10 MiB fail to load.
+func HTTPLoader(client *http.Client) Loader {
+	if client == nil {
+		client = &http.Client{Timeout: loadTimeout}
+	}
+	return LoaderFunc(func(ctx context.Context, uri *url.URL) ([]byte, error) {
+		if uri.Scheme != "http" && uri.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported scheme %q", uri.Scheme)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %q", resp.Status)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxDocumentSize {
+			return nil, fmt.Errorf("document exceeds %d bytes", maxDocumentSize)
+		}
+		return data, nil
+	})
+}
+
+// FSLoader returns a loader that reads the file at the path of a URI, without
+// the leading slash, from fsys. For example, with an embed.FS that contains
+// `schemas/user.json`, it loads `https://example.com/schemas/user.json`. To
+// load `file` URIs from the local file system, use os.DirFS("/").
+func FSLoader(fsys fs.FS) Loader {
+	return LoaderFunc(func(_ context.Context, uri *url.URL) ([]byte, error) {
+		return fs.ReadFile(fsys, strings.TrimPrefix(uri.Path, "/"))
+	})
+}
+
+// CacheLoader returns a loader that caches the documents loaded by loader, so
+// that they are loaded once across parses. Documents that fail to load are not
+// cached. It is safe for concurrent use.
+func CacheLoader(loader Loader) Loader {
+	var (
+		mu    sync.Mutex
+		cache = make(map[string][]byte)
+	)
+	return LoaderFunc(func(ctx context.Context, uri *url.URL) ([]byte, error) {
+		key := uri.String()
+
+		mu.Lock()
+		data, ok := cache[key]
+		mu.Unlock()
+		if ok {
+			return data, nil
+		}
+
+		data, err := loader.Load(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		cache[key] = data
+		mu.Unlock()
+		return data, nil
+	})
+}
+
+// WithLoader sets the loader used to resolve a `$ref` to another document.
+// Only documents of which the URI has the scheme and host of an allowed URI
+// and a path within its path are loaded, e.g. with
+// `https://example.com/schemas/` or `https://example.com/schemas`,
+// `https://example.com/schemas/user.json` is loaded, but
+// `https://example.com/admin.json` and
+// `https://example.com/schemas-private/user.json` are not. Other references
+// fail with ErrRefNotAllowed. Each document is loaded once per parse (see also
+// CacheLoader).
+//
+// By default, no documents are loaded and only references within the parsed
+// schema, such as `#/$defs/user`, are resolved.
+func WithLoader(loader Loader, allowed ...string) Option {
+	return func(cfg *config) {
+		cfg.loader = loader
+		cfg.allowed = allowed
+	}
+}
+
+// WithContext sets the context used to load documents (see WithLoader).
+// Defaults to context.Background().
+func WithContext(ctx context.Context) Option {
+	return func(cfg *config) {
+		cfg.ctx = ctx
+	}
+}
+
+// refs holds the state of resolving references while parsing a schema.
+type refs struct {
+	// root is the JSON encoding of the parsed schema.
+	root    []byte
+	rootURI string
+	// base is the URI of the document that contains the schema being parsed.
+	base *url.URL
+	// documents are the decoded documents, by URI.
+	documents map[string]any
+	// schemas are the parsed targets of references, by URI.
+	schemas map[string]*refSchema
+}
+
+func newRefs(root []byte, id string) *refs {
+	base, err := url.Parse(id)
+	if err != nil {
+		base = &url.URL{}
+	}
+	base.Fragment, base.RawFragment = "", ""
+
+	return &refs{
+		root:      root,
+		rootURI:   base.String(),
+		base:      base,
+		documents: make(map[string]any),
+		schemas:   make(map[string]*refSchema),
+	}
+}
+
+// refSchema is the parsed target of a reference. Its schema is nil while the
+// target is parsed, so that recursive references resolve to it lazily.
+type refSchema struct {
+	schema *valtor.Schema[any]
+}
+
+func (r *refSchema) ValidateContext(ctx context.Context, value any) error {
+	return r.schema.ValidateContext(ctx, value)
+}
+
+// parseRef parses the target of a `$ref`, relative to the document that
+// contains it.
+func (cfg *config) parseRef(ref string) (*refSchema, error) {
+	uri, err := cfg.refs.base.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	if target, ok := cfg.refs.schemas[uri.String()]; ok {
+		return target, nil
+	}
+
+	docURI := *uri
+	docURI.Fragment, docURI.RawFragment = "", ""
+	doc, err := cfg.document(&docURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		node any
+		path string
+	)
+	if uri.Fragment == "" || strings.HasPrefix(uri.Fragment, "/") {
+		node, err = resolvePointer(doc, uri.Fragment)
+		path = uri.Fragment
+	} else {
+		node, path, err = findAnchor(doc, uri.Fragment, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if docURI.String() != cfg.refs.rootURI {
+		path = docURI.String() + "#" + path
+	}
+
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := decodeSchema(data)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &refSchema{}
+	cfg.refs.schemas[uri.String()] = target
+
+	base := cfg.refs.base
+	cfg.refs.base = &docURI
+	defer func() { cfg.refs.base = base }()
+
+	target.schema, err = parseJSONSchema[any](schema, cfg, path)
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// document returns the decoded document with the URI, which is loaded if it is
+// not the parsed schema.
+func (cfg *config) document(uri *url.URL) (any, error) {
+	key := uri.String()
+	if doc, ok := cfg.refs.documents[key]; ok {
+		return doc, nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch {
+	case key == cfg.refs.rootURI:
+		data = cfg.refs.root
+	case !uri.IsAbs():
+		return nil, fmt.Errorf("%w: relative URI %q without base URI", ErrRefNotAllowed, key)
+	case cfg.loader == nil || !cfg.allowedURI(uri):
+		return nil, fmt.Errorf("%w: %q", ErrRefNotAllowed, key)
+	default:
+		data, err = cfg.loader.Load(cfg.ctx, uri)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %q: %w", key, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", key, err)
+	}
+	cfg.refs.documents[key] = doc
+	return doc, nil
+}
+
+// allowedURI reports whether the document with the URI may be loaded.
+func (cfg *config) allowedURI(uri *url.URL) bool {
+	for _, allowed := range cfg.allowed {
+		a, err := url.Parse(allowed)
+		if err != nil || !a.IsAbs() {
+			continue
+		}
+		if a.Scheme == uri.Scheme && a.Host == uri.Host && withinPath(uri.Path, a.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinPath reports whether p is dir or a path below it, after resolving dot
+// segments, so that `/schemas` doesn't match `/schemas-private/user.json` and
+// `/schemas/../admin.json` doesn't match `/schemas/`.
+func withinPath(p, dir string) bool {
+	p = path.Clean("/" + p)
+	dir = strings.TrimSuffix(path.Clean("/"+dir), "/")
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// resolvePointer returns the value of a decoded document at a JSON Pointer
+// (RFC 6901).
+func resolvePointer(doc any, pointer string) (any, error) {
+	if pointer == "" {
+		return doc, nil
+	}
+
+	node := doc
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch v := node.(type) {
+		case map[string]any:
+			value, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("no value at %q", pointer)
+			}
+			node = value
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("no value at %q", pointer)
+			}
+			node = v[i]
+		default:
+			return nil, fmt.Errorf("no value at %q", pointer)
+		}
+	}
+	return node, nil
+}
+
+// findAnchor returns the schema of a decoded document with the `$anchor`, and
+// its JSON Pointer.
+func findAnchor(doc any, anchor, path string) (any, string, error) {
+	switch v := doc.(type) {
+	case map[string]any:
+		if v["$anchor"] == anchor {
+			return v, path, nil
+		}
+		for key, value := range v {
+			if node, p, err := findAnchor(value, anchor, path+"/"+escapePointer(key)); err == nil {
+				return node, p, nil
+			}
+		}
+	case []any:
+		for i, value := range v {
+			if node, p, err := findAnchor(value, anchor, path+"/"+strconv.Itoa(i)); err == nil {
+				return node, p, nil
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("no `$anchor` %q", anchor)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseJSONSchemaRef(t *testing.T) {
+	schemaJSON := `{
+		"$id": "https://example.com/schemas/order.json",
+		"type": "object",
+		"properties": {
+			"customer": {"$ref": "customer.json"},
+			"tags": {"type": "array", "items": {"$ref": "#tag"}},
+			"parent": {"$ref": "#"},
+			"status": {"$ref": "#/$defs/status", "enum": ["open"]}
+		},
+		"$defs": {
+			"tag": {"$anchor": "tag", "type": "string", "minLength": 1},
+			"status": {"type": "string", "enum": ["open", "closed"]}
+		}
+	}`
+	fsys := fstest.MapFS{
+		"schemas/customer.json": {Data: []byte(`{
+			"type": "object",
+			"properties": {"name": {"$ref": "#/$defs/name"}},
+			"required": ["name"],
+			"$defs": {"name": {"type": "string", "maxLength": 5}}
+		}`)},
+	}
+
+	valtorSchema, err := ParseJSONSchemaBytes[any]([]byte(schemaJSON), WithLoader(FSLoader(fsys), "https://example.com/schemas/"))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		value         map[string]any
+		expectedError string
+	}{
+		{name: "valid", value: map[string]any{"customer": map[string]any{"name": "Alice"}, "tags": []any{"a"}, "status": "open"}},
+		{name: "remote", value: map[string]any{"customer": map[string]any{"name": "Alexander"}}, expectedError: `validation failed for field "customer": validation failed for field "name": length must be at most 5, got 9`},
+		{name: "remote required", value: map[string]any{"customer": map[string]any{}}, expectedError: `validation failed for field "customer": validation failed for field "name": value is required`},
+		{name: "anchor", value: map[string]any{"tags": []any{""}}, expectedError: `validation failed for field "tags": invalid item at index 0: length must be at least 1, got 0`},
+		{name: "recursive", value: map[string]any{"parent": map[string]any{"parent": map[string]any{"tags": []any{""}}}}, expectedError: `validation failed for field "parent": validation failed for field "parent": validation failed for field "tags": invalid item at index 0: length must be at least 1, got 0`},
+		{name: "keywords next to ref", value: map[string]any{"status": "closed"}, expectedError: `validation failed for field "status": value must be one of "open"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := valtorSchema.Validate(tt.value)
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaRefErrors(t *testing.T) {
+	fsys := fstest.MapFS{"schemas/name.json": {Data: []byte(`{"type": "string"}`)}}
+
+	tests := []struct {
+		name    string
+		schema  string
+		opts    []Option
+		wantErr error
+	}{
+		{name: "no loader", schema: `{"$ref": "https://example.com/schemas/name.json"}`, wantErr: ErrRefNotAllowed},
+		{name: "not allowed", schema: `{"$ref": "https://example.com/other/name.json"}`, opts: []Option{WithLoader(FSLoader(fsys), "https://example.com/schemas/")}, wantErr: ErrRefNotAllowed},
+		{name: "other host", schema: `{"$ref": "https://example.com.evil/schemas/name.json"}`, opts: []Option{WithLoader(FSLoader(fsys), "https://example.com/schemas/")}, wantErr: ErrRefNotAllowed},
+		{name: "path prefix", schema: `{"$ref": "https://example.com/schemas-private/name.json"}`, opts: []Option{WithLoader(FSLoader(fsys), "https://example.com/schemas")}, wantErr: ErrRefNotAllowed},
+		{name: "dot segments", schema: `{"$ref": "https://example.com/schemas/../other/name.json"}`, opts: []Option{WithLoader(FSLoader(fsys), "https://example.com/schemas/")}, wantErr: ErrRefNotAllowed},
+		{name: "relative without base", schema: `{"$ref": "name.json"}`, opts: []Option{WithLoader(FSLoader(fsys), "https://example.com/schemas/")}, wantErr: ErrRefNotAllowed},
+		{name: "missing pointer", schema: `{"$ref": "#/$defs/missing"}`},
+		{name: "missing anchor", schema: `{"$ref": "#missing"}`},
+		{name: "missing document", schema: `{"$ref": "https://example.com/schemas/missing.json"}`, opts: []Option{WithLoader(FSLoader(fsys), "https://example.com/schemas/")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJSONSchemaBytes[any]([]byte(tt.schema), tt.opts...)
+			if err == nil {
+				t.Fatal("expected error, got no error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHTTPLoader(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/name.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"type": "string", "minLength": 2}`))
+	}))
+	defer srv.Close()
+
+	loader := CacheLoader(HTTPLoader(srv.Client()))
+	schemaJSON := `{
+		"type": "object",
+		"properties": {
+			"first": {"$ref": "` + srv.URL + `/name.json"},
+			"last": {"$ref": "` + srv.URL + `/name.json#"}
+		}
+	}`
+
+	for range 2 {
+		valtorSchema, err := ParseJSONSchemaBytes[any]([]byte(schemaJSON), WithLoader(loader, srv.URL+"/"))
+		if err != nil {
+			t.Fatalf("failed to parse schema: %v", err)
+		}
+		if err := valtorSchema.Validate(map[string]any{"first": "A"}); err == nil {
+			t.Error("expected error for short name, got no error")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+
+	uri, _ := url.Parse(srv.URL + "/missing.json")
+	if _, err := loader.Load(context.Background(), uri); err == nil {
+		t.Error("expected error for missing document, got no error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	schemaJSON = `{"$ref": "` + srv.URL + `/other.json"}`
+	_, err := ParseJSONSchemaBytes[any]([]byte(schemaJSON), WithContext(ctx), WithLoader(HTTPLoader(nil), srv.URL))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
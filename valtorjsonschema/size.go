@@ -0,0 +1,120 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"encoding/json"
+
+	"github.com/invopop/jsonschema"
+)
+
+const (
+	// maxIntegerSize is the length of the longest int64, `-9223372036854775808`.
+	maxIntegerSize = 20
+	// maxNumberSize is the length of the longest float64 in shortest form,
+	// e.g. `-2.2250738585072014e-308`.
+	maxNumberSize = 24
+	// maxRuneSize is the length of the longest JSON encoding of a single
+	// character: a surrogate pair of `\uXXXX` escapes.
+	maxRuneSize = 12
+)
+
+// MaxSize returns the size in bytes of the largest JSON document accepted by
+// the schema, e.g. to derive a request body limit from a validation contract.
+// It reports false if the size is unbounded: for strings without
+// `maxLength`, arrays without `maxItems` or `items`, and objects that allow
+// additional or pattern properties. Insignificant whitespace is not counted,
+// and numbers are assumed to be in their shortest form.
+func MaxSize(schema jsonschema.Schema) (int64, bool) {
+	if len(schema.Enum) > 0 {
+		var size int64
+		for _, v := range schema.Enum {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return 0, false
+			}
+			size = max(size, int64(len(b)))
+		}
+		return size, true
+	}
+
+	switch schema.Type {
+	case "null":
+		return int64(len("null")), true
+	case "boolean":
+		return int64(len("false")), true
+	case "integer":
+		return maxIntegerSize, true
+	case "number":
+		return maxNumberSize, true
+	case "string":
+		if schema.MaxLength == nil {
+			return 0, false
+		}
+		return 2 + maxRuneSize*int64(*schema.MaxLength), true
+	case "array":
+		if schema.MaxItems == nil || schema.Items == nil {
+			return 0, false
+		}
+		itemSize, ok := MaxSize(*schema.Items)
+		if !ok {
+			return 0, false
+		}
+		n := int64(*schema.MaxItems)
+		if n == 0 {
+			return 2, true
+		}
+		// Brackets, items and separating commas.
+		return 2 + n*itemSize + n - 1, true
+	case "object":
+		if !isFalse(schema.AdditionalProperties) || len(schema.PatternProperties) > 0 {
+			return 0, false
+		}
+		size := int64(2)
+		if schema.Properties == nil {
+			return size, true
+		}
+		for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			if pair.Value == nil {
+				return 0, false
+			}
+			key, err := json.Marshal(pair.Key)
+			if err != nil {
+				return 0, false
+			}
+			valueSize, ok := MaxSize(*pair.Value)
+			if !ok {
+				return 0, false
+			}
+			// Key, colon, value and separating comma.
+			size += int64(len(key)) + 1 + valueSize + 1
+		}
+		if schema.Properties.Len() > 0 {
+			size-- // No comma after the last property.
+		}
+		return size, true
+	default:
+		return 0, false
+	}
+}
+
+// isFalse reports whether schema is the boolean schema `false`.
+func isFalse(schema *jsonschema.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	b, err := json.Marshal(schema)
+	return err == nil && string(b) == "false"
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorjsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+// typeUnion returns the types of a schema with a type array, or of a schema
+// without a `type` that has `anyOf` or `oneOf` with only a `type` per
+// subschema, such as a nullable field of a reflected schema.
+func typeUnion(schema schemaNode) ([]string, bool) {
+	if len(schema.Types) > 0 {
+		var types []string
+		for _, typ := range schema.Types {
+			if !slices.Contains(types, typ) {
+				types = append(types, typ)
+			}
+		}
+		return types, true
+	}
+	if schema.Type != "" || (len(schema.AnyOf) > 0) == (len(schema.OneOf) > 0) {
+		return nil, false
+	}
+
+	subschemas := schema.AnyOf
+	if len(schema.OneOf) > 0 {
+		subschemas = schema.OneOf
+	}
+
+	types := make([]string, 0, len(subschemas))
+	for _, subschema := range subschemas {
+		if subschema == nil || subschema.Type == "" || !reflect.DeepEqual(*subschema, schemaNode{Type: subschema.Type}) {
+			return nil, false
+		}
+		if slices.Contains(types, subschema.Type) {
+			continue
+		}
+		types = append(types, subschema.Type)
+	}
+
+	// With `oneOf`, integers are valid for both `integer` and `number`, so
+	// they would be invalid for the union.
+	if len(schema.OneOf) > 0 && slices.Contains(types, "integer") && slices.Contains(types, "number") {
+		return nil, false
+	}
+
+	return types, true
+}
+
+// typeMismatch is never the type of a validated value, so that it can be used
+// to create an error for values of an unexpected type.
+type typeMismatch struct{}
+
+// parseUnion parses a schema for values of any of the given types. All other
+// keywords of the schema apply to each type, as for a type array.
+func parseUnion[T any](schema schemaNode, types []string, cfg *config, path string) (*valtor.Schema[T], error) {
+	branches := make([]*valtor.Schema[any], len(types))
+	for i, typ := range types {
+		typeSchema := schema
+		typeSchema.Type, typeSchema.Types = typ, nil
+		branch, err := parseType[any](typeSchema, cfg, path)
+		if err != nil {
+			return nil, err
+		}
+		branches[i] = branch
+	}
+
+	mismatch := valtor.WhenType(valtor.Any().Expected(strings.Join(types, " or ")), valtor.New[typeMismatch]())
+
+	unionSchema := valtor.New[T]().Rule(contextFunc[T](func(ctx context.Context, value T) error {
+		v := any(value)
+		for i, typ := range types {
+			if hasType(v, typ) {
+				return branches[i].ValidateContext(ctx, v)
+			}
+		}
+		if v == nil {
+			// As for a single type, a nil value (an absent value) is
+			// validated as the zero value of the first type.
+			return branches[0].ValidateContext(ctx, v)
+		}
+		return mismatch.Validate(v)
+	}))
+	unionSchema.TransformContext(func(ctx context.Context, value T) T {
+		if !cfg.coerces(ctx) {
+			return value
+		}
+		return transformAs(value, func(v any) any {
+			for i, typ := range types {
+				if hasType(v, typ) {
+					return branches[i].TransformValueContext(ctx, v)
+				}
+			}
+			return v
+		})
+	})
+	return unionSchema, nil
+}
+
+// hasType reports whether value is of the JSON Schema type typ.
+func hasType(value any, typ string) bool {
+	switch typ {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number", "integer":
+		if n, ok := value.(json.Number); ok {
+			r, ok := new(big.Rat).SetString(n.String())
+			return ok && (typ == "number" || r.IsInt())
+		}
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return true
+		case reflect.Float32, reflect.Float64:
+			f := v.Float()
+			return typ == "number" || (f == math.Trunc(f) && !math.IsInf(f, 0))
+		}
+		return false
+	case "object":
+		kind := reflect.ValueOf(value).Kind()
+		return kind == reflect.Map || kind == reflect.Struct
+	case "array":
+		kind := reflect.ValueOf(value).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorlocale validates BCP 47 language tags and POSIX locale names
+// with the language data of golang.org/x/text. It is a separate module, so that
+// programs that don't validate language tags don't depend on that data.
+//
+// The validators can be used directly, e.g. with
+// `valtor.String().Custom(valtorlocale.LanguageTag)`, or by name once they are
+// registered with RegisterFormats, e.g. with valtor.StringSchema.LanguageTag or
+// the `language-tag` format of a JSON Schema.
+package valtorlocale
+
+import (
+	"strings"
+
+	"github.com/dstotijn/valtor/formats"
+	"golang.org/x/text/language"
+)
+
+// RegisterFormats registers the `language-tag` (see LanguageTag) and `locale`
+// (see Locale) formats in registry, e.g. formats.Default.
+func RegisterFormats(registry *formats.Registry) {
+	registry.Register("language-tag", LanguageTag)
+	registry.Register("locale", Locale)
+}
+
+// LanguageTag validates that s is a BCP 47 language tag, e.g. `en`, `en-US` or
+// `zh-Hant-TW`, as parsed by language.Parse. Subtags are case-insensitive, and
+// extensions, private use subtags and grandfathered tags, such as `i-klingon`,
+// are accepted. Subtags must be registered, e.g. `qq` is not a valid language.
+// Unlike language.Parse, underscores are not accepted as separators.
+func LanguageTag(s string) error {
+	if strings.Contains(s, "_") {
+		return invalid("language tag")
+	}
+	if _, err := language.Parse(s); err != nil {
+		return invalid("language tag")
+	}
+	return nil
+}
+
+// ToCanonical returns the canonical form of the language tag s, e.g. `en-US`
+// for `EN-us` and `tlh` for `i-klingon`. Invalid tags are returned unchanged,
+// so that they fail LanguageTag. It can be used as transform, e.g. with
+// `valtor.String().Transform(valtorlocale.ToCanonical)`.
+func ToCanonical(s string) string {
+	if LanguageTag(s) != nil {
+		return s
+	}
+	return language.Make(s).String()
+}
+
+// Locale validates that s is a POSIX locale name, e.g. `en_US`, `de_DE.UTF-8`
+// or `sr_RS@latin`: a lowercase ISO 639 language code, optionally followed by
+// an uppercase ISO 3166 country code or a UN M.49 area code, a codeset and a
+// modifier. A hyphen is accepted as separator instead of an underscore, e.g.
+// `en-US`. The names `C` and `POSIX` are valid as well.
+func Locale(s string) error {
+	if s == "C" || s == "POSIX" {
+		return nil
+	}
+	rest, modifier, hasModifier := strings.Cut(s, "@")
+	if hasModifier && !isAlnumString(modifier) {
+		return invalid("locale")
+	}
+	rest, codeset, hasCodeset := strings.Cut(rest, ".")
+	if hasCodeset && !isCodeset(codeset) {
+		return invalid("locale")
+	}
+	lang, territory, hasTerritory := strings.Cut(rest, "_")
+	if !hasTerritory {
+		lang, territory, hasTerritory = strings.Cut(rest, "-")
+	}
+	if lang != strings.ToLower(lang) {
+		return invalid("locale")
+	}
+	if _, err := language.ParseBase(lang); err != nil || len(lang) > 3 {
+		return invalid("locale")
+	}
+	if hasTerritory {
+		if territory != strings.ToUpper(territory) {
+			return invalid("locale")
+		}
+		region, err := language.ParseRegion(territory)
+		if err != nil || (!region.IsCountry() && !region.IsGroup()) {
+			return invalid("locale")
+		}
+	}
+	return nil
+}
+
+func invalid(name string) error {
+	return &formats.FormatError{Name: name}
+}
+
+// isAlnumString reports whether s consists of 1 to 32 ASCII letters and
+// digits, as a locale modifier.
+func isAlnumString(s string) bool {
+	if s == "" || len(s) > 32 {
+		return false
+	}
+	for _, c := range s {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isCodeset reports whether s is a codeset of a locale, e.g. `UTF-8`.
+func isCodeset(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
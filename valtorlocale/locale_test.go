@@ -0,0 +1,89 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorlocale
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+	"github.com/dstotijn/valtor/formats"
+)
+
+func TestLanguageTag(t *testing.T) {
+	valid := []string{
+		"en", "en-US", "zh-Hant-TW", "de-CH-1996", "sr-Latn-RS", "es-419", "zh-yue-HK",
+		"en-US-u-ca-gregory", "x-private", "en-x-custom", "i-klingon", "EN-us",
+	}
+	invalid := []string{"", "e", "en_US", "en-", "-en", "en--US", "toolongtag", "en-US-u", "en-a-b", "en-x", "qq"}
+
+	for _, s := range valid {
+		if err := LanguageTag(s); err != nil {
+			t.Errorf("expected %q to be valid, got error: %v", s, err)
+		}
+	}
+	for _, s := range invalid {
+		if err := LanguageTag(s); !errors.Is(err, formats.ErrInvalidFormat) {
+			t.Errorf("expected %q to be invalid, got %v", s, err)
+		}
+	}
+}
+
+func TestToCanonical(t *testing.T) {
+	tests := map[string]string{
+		"EN-us":     "en-US",
+		"i-klingon": "tlh",
+		"zh-yue-HK": "yue-HK",
+		"en_US":     "en_US",
+		"":          "",
+	}
+
+	for s, want := range tests {
+		if got := ToCanonical(s); got != want {
+			t.Errorf("expected %q for %q, got %q", want, s, got)
+		}
+	}
+}
+
+func TestLocale(t *testing.T) {
+	valid := []string{"en", "en_US", "en-US", "de_DE.UTF-8", "sr_RS@latin", "es_419", "C", "POSIX"}
+	invalid := []string{"", "EN_us", "en_us", "en_ZZ", "english", "qq_US", "en_US.", "en_US@", "e"}
+
+	for _, s := range valid {
+		if err := Locale(s); err != nil {
+			t.Errorf("expected %q to be valid, got error: %v", s, err)
+		}
+	}
+	for _, s := range invalid {
+		if err := Locale(s); !errors.Is(err, formats.ErrInvalidFormat) {
+			t.Errorf("expected %q to be invalid, got %v", s, err)
+		}
+	}
+}
+
+func TestRegisterFormats(t *testing.T) {
+	RegisterFormats(formats.Default)
+
+	schema := valtor.String().LanguageTag()
+	if err := schema.Validate("zh-Hant-TW"); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := schema.Validate("en_US"); err == nil || err.Error() != "string must be a valid language tag" {
+		t.Errorf("expected language tag error, got %v", err)
+	}
+	if err := valtor.String().Locale().Validate("de_DE.UTF-8"); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+}
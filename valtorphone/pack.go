@@ -0,0 +1,61 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorphone
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+// Pack publishes the rules of this package as valtor extensions, so that they
+// can be used by name, e.g. as JSON Schema extension keywords, once registered
+// with valtor.Use. The `x-phone` rule checks if string values are phone
+// numbers (see Validator). Its parameters are `true`, or an object with a
+// `defaultRegion`, e.g. `{"defaultRegion": "NL"}`.
+type Pack struct{}
+
+func (Pack) Extensions() []valtor.Extension {
+	return []valtor.Extension{{
+		Name: "x-phone",
+		Parse: func(params json.RawMessage) (func(any) error, error) {
+			var opts []Option
+			if string(params) != "true" {
+				var p struct {
+					DefaultRegion string `json:"defaultRegion"`
+				}
+				if err := json.Unmarshal(params, &p); err != nil {
+					return nil, fmt.Errorf("expected `true` or object: %w", err)
+				}
+				if p.DefaultRegion != "" {
+					// Regions are case-insensitive, as for WithDefaultRegion.
+					if _, ok := regions[strings.ToUpper(p.DefaultRegion)]; !ok {
+						return nil, fmt.Errorf("%w: %q", ErrUnknownRegion, p.DefaultRegion)
+					}
+					opts = append(opts, WithDefaultRegion(p.DefaultRegion))
+				}
+			}
+			validateFn := Validator(opts...)
+			return func(value any) error {
+				if s, ok := value.(string); ok {
+					return validateFn(s)
+				}
+				return nil
+			}, nil
+		},
+	}}
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorphone validates phone numbers and normalizes them to E.164,
+// e.g. `+31612345678`. Numbers in national format, e.g. `06 12345678`, are
+// interpreted in a default region.
+//
+// The lengths of national numbers are checked for the regions in its metadata
+// (see Regions). Numbers with other country calling codes are only checked to
+// be valid E.164. Whether a number is assigned is not checked.
+package valtorphone
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidNumber = errors.New("invalid phone number")
+	ErrUnknownRegion = errors.New("unknown region")
+)
+
+// region holds the numbering plan metadata of a region.
+type region struct {
+	callingCode string
+	trunkPrefix string // Prefix of national numbers, e.g. `0`, if any.
+	idd         string // International direct dialing prefix, e.g. `00`.
+	minLength   int    // Minimum length of the national significant number.
+	maxLength   int    // Maximum length of the national significant number.
+}
+
+// regions are the numbering plans by ISO 3166-1 alpha-2 code.
+var regions = map[string]region{
+	"AT": {callingCode: "43", trunkPrefix: "0", idd: "00", minLength: 4, maxLength: 13},
+	"AU": {callingCode: "61", trunkPrefix: "0", idd: "0011", minLength: 9, maxLength: 9},
+	"BE": {callingCode: "32", trunkPrefix: "0", idd: "00", minLength: 8, maxLength: 9},
+	"BR": {callingCode: "55", trunkPrefix: "0", idd: "00", minLength: 10, maxLength: 11},
+	"CA": {callingCode: "1", trunkPrefix: "1", idd: "011", minLength: 10, maxLength: 10},
+	"CH": {callingCode: "41", trunkPrefix: "0", idd: "00", minLength: 9, maxLength: 9},
+	"DE": {callingCode: "49", trunkPrefix: "0", idd: "00", minLength: 6, maxLength: 13},
+	"DK": {callingCode: "45", idd: "00", minLength: 8, maxLength: 8},
+	"ES": {callingCode: "34", idd: "00", minLength: 9, maxLength: 9},
+	"FR": {callingCode: "33", trunkPrefix: "0", idd: "00", minLength: 9, maxLength: 9},
+	"GB": {callingCode: "44", trunkPrefix: "0", idd: "00", minLength: 9, maxLength: 10},
+	"IE": {callingCode: "353", trunkPrefix: "0", idd: "00", minLength: 7, maxLength: 9},
+	"IN": {callingCode: "91", trunkPrefix: "0", idd: "00", minLength: 10, maxLength: 10},
+	"IT": {callingCode: "39", idd: "00", minLength: 6, maxLength: 11},
+	"JP": {callingCode: "81", trunkPrefix: "0", idd: "010", minLength: 9, maxLength: 10},
+	"MX": {callingCode: "52", idd: "00", minLength: 10, maxLength: 10},
+	"NL": {callingCode: "31", trunkPrefix: "0", idd: "00", minLength: 9, maxLength: 9},
+	"NO": {callingCode: "47", idd: "00", minLength: 8, maxLength: 8},
+	"PL": {callingCode: "48", idd: "00", minLength: 9, maxLength: 9},
+	"PT": {callingCode: "351", idd: "00", minLength: 9, maxLength: 9},
+	"SE": {callingCode: "46", trunkPrefix: "0", idd: "00", minLength: 7, maxLength: 9},
+	"US": {callingCode: "1", trunkPrefix: "1", idd: "011", minLength: 10, maxLength: 10},
+}
+
+// Regions returns the codes of the regions with numbering plan metadata, in
+// sorted order.
+func Regions() []string {
+	return slices.Sorted(maps.Keys(regions))
+}
+
+type config struct {
+	defaultRegion string
+}
+
+// Option configures Normalize and Validator.
+type Option func(*config)
+
+// WithDefaultRegion sets the ISO 3166-1 alpha-2 code of the region in which
+// numbers without a country calling code are interpreted, e.g. `NL`. Without
+// a default region, numbers must be in international format, e.g. starting
+// with `+`.
+func WithDefaultRegion(region string) Option {
+	return func(cfg *config) {
+		cfg.defaultRegion = strings.ToUpper(region)
+	}
+}
+
+// Normalize returns the phone number in E.164 format, e.g. `+31612345678` for
+// `+31 (0)6-1234 5678`. Spaces, hyphens, dots, slashes and parentheses are
+// ignored. Numbers in national format, or that start with the international
+// dialing prefix of the default region (e.g. `00`), are interpreted in the
+// default region. Errors wrap ErrInvalidNumber, or ErrUnknownRegion for a
+// default region without metadata.
+func Normalize(s string, opts ...Option) (string, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var defaultRegion region
+	if cfg.defaultRegion != "" {
+		var ok bool
+		if defaultRegion, ok = regions[cfg.defaultRegion]; !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnknownRegion, cfg.defaultRegion)
+		}
+	}
+
+	international := strings.HasPrefix(strings.TrimSpace(s), "+")
+	// A trunk prefix in parentheses after the country calling code, e.g.
+	// `+31 (0)6`, is a common way of writing national and international
+	// formats at once.
+	s = strings.Replace(s, "(0)", "", 1)
+	digits, ok := stripFormatting(strings.TrimPrefix(strings.TrimSpace(s), "+"))
+	if !ok || digits == "" {
+		return "", fmt.Errorf("%w: must only contain digits and formatting characters", ErrInvalidNumber)
+	}
+
+	if !international {
+		if cfg.defaultRegion == "" {
+			return "", fmt.Errorf("%w: must start with + and a country calling code", ErrInvalidNumber)
+		}
+		if rest, ok := strings.CutPrefix(digits, defaultRegion.idd); ok {
+			digits, international = rest, true
+		}
+	}
+
+	if international {
+		return normalizeInternational(digits)
+	}
+
+	national := digits
+	if defaultRegion.trunkPrefix != "" {
+		national = strings.TrimPrefix(national, defaultRegion.trunkPrefix)
+	}
+	if err := checkLength(national, defaultRegion); err != nil {
+		return "", err
+	}
+	return "+" + defaultRegion.callingCode + national, nil
+}
+
+// normalizeInternational normalizes a number that starts with a country
+// calling code.
+func normalizeInternational(digits string) (string, error) {
+	if digits[0] == '0' {
+		return "", fmt.Errorf("%w: country calling code must not start with 0", ErrInvalidNumber)
+	}
+	if len(digits) < 8 || len(digits) > 15 {
+		return "", fmt.Errorf("%w: must have 8 to 15 digits, got %d", ErrInvalidNumber, len(digits))
+	}
+	// Country calling codes are prefix-free, so at most one matches.
+	for _, code := range Regions() {
+		r := regions[code]
+		if national, ok := strings.CutPrefix(digits, r.callingCode); ok {
+			if err := checkLength(national, r); err != nil {
+				return "", err
+			}
+			break
+		}
+	}
+	return "+" + digits, nil
+}
+
+func checkLength(national string, r region) error {
+	if len(national) < r.minLength || len(national) > r.maxLength {
+		if r.minLength == r.maxLength {
+			return fmt.Errorf("%w: national number must have %d digits, got %d", ErrInvalidNumber, r.minLength, len(national))
+		}
+		return fmt.Errorf("%w: national number must have %d to %d digits, got %d", ErrInvalidNumber, r.minLength, r.maxLength, len(national))
+	}
+	return nil
+}
+
+// stripFormatting returns the digits of s, and whether all other characters
+// are formatting characters.
+func stripFormatting(s string) (string, bool) {
+	var sb strings.Builder
+	for i := range len(s) {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			sb.WriteByte(c)
+		case strings.IndexByte(" -./()", c) >= 0:
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+// Validator returns a validator that checks if the string is a phone number
+// that can be normalized with Normalize and the options, e.g. to add to a
+// schema with Custom. Use Normalize to obtain the normalized number, e.g. to
+// store it.
+func Validator(opts ...Option) func(string) error {
+	return func(s string) error {
+		_, err := Normalize(s, opts...)
+		return err
+	}
+}
+
+// Transform returns a transformation that normalizes phone numbers with
+// Normalize and the options, e.g. to add to a schema with Transform, so that
+// valtor.Run returns numbers in E.164 format. Numbers that can't be
+// normalized are returned as is, to be rejected by Validator.
+func Transform(opts ...Option) func(string) string {
+	return func(s string) string {
+		if normalized, err := Normalize(s, opts...); err == nil {
+			return normalized
+		}
+		return s
+	}
+}
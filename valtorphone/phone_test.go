@@ -0,0 +1,150 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorphone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		opts    []Option
+		want    string
+		wantErr error
+	}{
+		{name: "international", input: "+31612345678", want: "+31612345678"},
+		{name: "international formatted", input: "+31 (0)6-1234 5678", want: "+31612345678"},
+		{name: "international NANP", input: "+1 (415) 555-2671", want: "+14155552671"},
+		{name: "international unknown region", input: "+886 912 345 678", want: "+886912345678"},
+		{name: "national", input: "06 12345678", opts: []Option{WithDefaultRegion("NL")}, want: "+31612345678"},
+		{name: "national lowercase region", input: "020-1234567", opts: []Option{WithDefaultRegion("nl")}, want: "+31201234567"},
+		{name: "national NANP", input: "(415) 555-2671", opts: []Option{WithDefaultRegion("US")}, want: "+14155552671"},
+		{name: "national NANP with trunk prefix", input: "1 415 555 2671", opts: []Option{WithDefaultRegion("US")}, want: "+14155552671"},
+		{name: "national without trunk prefix", input: "06 1234 5678", opts: []Option{WithDefaultRegion("IT")}, want: "+390612345678"},
+		{name: "international dialing prefix", input: "0044 20 7183 8750", opts: []Option{WithDefaultRegion("NL")}, want: "+442071838750"},
+		{name: "international dialing prefix NANP", input: "011 31 6 12345678", opts: []Option{WithDefaultRegion("US")}, want: "+31612345678"},
+		{name: "international with default region", input: "+442071838750", opts: []Option{WithDefaultRegion("NL")}, want: "+442071838750"},
+		{name: "national without default region", input: "0612345678", wantErr: ErrInvalidNumber},
+		{name: "unknown default region", input: "0612345678", opts: []Option{WithDefaultRegion("ZZ")}, wantErr: ErrUnknownRegion},
+		{name: "national too short", input: "06 1234567", opts: []Option{WithDefaultRegion("NL")}, wantErr: ErrInvalidNumber},
+		{name: "international too long for region", input: "+31 6 123456789", wantErr: ErrInvalidNumber},
+		{name: "international too long", input: "+886 1234 5678 90123", wantErr: ErrInvalidNumber},
+		{name: "international too short", input: "+886 123", wantErr: ErrInvalidNumber},
+		{name: "country calling code with zero", input: "+031612345678", wantErr: ErrInvalidNumber},
+		{name: "letters", input: "+31 6 CALL NOW", wantErr: ErrInvalidNumber},
+		{name: "empty", input: "", opts: []Option{WithDefaultRegion("NL")}, wantErr: ErrInvalidNumber},
+		{name: "plus only", input: "+", wantErr: ErrInvalidNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.input, tt.opts...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if err := Validator(tt.opts...)(tt.input); err != nil {
+				t.Errorf("expected validator to accept %q, got %q", tt.input, err)
+			}
+			if got := Transform(tt.opts...)(tt.input); got != tt.want {
+				t.Errorf("expected transform to return %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	type contact struct {
+		Phone string
+	}
+	phoneSchema := valtor.String().Required()
+	phoneSchema.Transform(Transform(WithDefaultRegion("NL")))
+	phoneSchema.Custom(Validator(WithDefaultRegion("NL")))
+	schema := valtor.Object[contact]()
+	valtor.FieldRef(schema, "phone", func(c *contact) *string { return &c.Phone }, phoneSchema)
+
+	out := valtor.Run(context.Background(), schema, contact{Phone: "06-1234 5678"})
+	if out.Err != nil {
+		t.Fatalf("expected no error, got %q", out.Err)
+	}
+	if want := "+31612345678"; out.Value.Phone != want {
+		t.Errorf("expected %q, got %q", want, out.Value.Phone)
+	}
+
+	out = valtor.Run(context.Background(), schema, contact{Phone: "12"})
+	if !errors.Is(out.Err, ErrInvalidNumber) {
+		t.Errorf("expected error %q, got %v", ErrInvalidNumber, out.Err)
+	}
+	if want := "12"; out.Value.Phone != want {
+		t.Errorf("expected %q, got %q", want, out.Value.Phone)
+	}
+}
+
+func TestPack(t *testing.T) {
+	registry := valtor.NewExtensionRegistry()
+	if err := registry.Use(Pack{}); err != nil {
+		t.Fatalf("failed to register pack: %v", err)
+	}
+	ext, ok := registry.Lookup("x-phone")
+	if !ok {
+		t.Fatal("expected extension x-phone to be registered")
+	}
+
+	tests := []struct {
+		name     string
+		params   string
+		value    any
+		wantErr  bool
+		parseErr bool
+	}{
+		{name: "international", params: `true`, value: "+31612345678"},
+		{name: "national without region", params: `true`, value: "0612345678", wantErr: true},
+		{name: "national with region", params: `{"defaultRegion": "NL"}`, value: "0612345678"},
+		{name: "lowercase region", params: `{"defaultRegion": "nl"}`, value: "0612345678"},
+		{name: "not a string", params: `true`, value: 42},
+		{name: "unknown region", params: `{"defaultRegion": "ZZ"}`, parseErr: true},
+		{name: "invalid params", params: `"NL"`, parseErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateFn, err := ext.Parse(json.RawMessage(tt.params))
+			if (err != nil) != tt.parseErr {
+				t.Fatalf("expected parse error: %v, got %v", tt.parseErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if err := validateFn(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
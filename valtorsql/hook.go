@@ -0,0 +1,126 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorsql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/dstotijn/valtor"
+)
+
+var (
+	modelSchemasMu sync.RWMutex
+	modelSchemas   = make(map[reflect.Type]func(any) error)
+)
+
+// Register registers a schema for model type T, to be used by ValidateModel.
+// Registering a schema for a type that already has one replaces it.
+func Register[T any](schema valtor.Validator[T]) {
+	modelSchemasMu.Lock()
+	defer modelSchemasMu.Unlock()
+
+	modelSchemas[reflect.TypeFor[T]()] = func(value any) error {
+		return schema.Validate(value.(T))
+	}
+}
+
+// ValidateModel validates a model against the schema registered for its type.
+// The model can be a value or pointer of a registered type, or a slice (or
+// pointer to a slice) of those, as passed to GORM's Create and Save. Models
+// without a registered schema are considered valid.
+//
+// It is intended to be called from GORM hooks:
+//
+//	func (p *Product) BeforeCreate(tx *gorm.DB) error {
+//		return valtorsql.ValidateModel(p)
+//	}
+//
+// Or from a callback, to validate all writes:
+//
+//	db.Callback().Create().Before("gorm:create").Register("valtor:validate", func(tx *gorm.DB) {
+//		if err := valtorsql.ValidateModel(tx.Statement.Dest); err != nil {
+//			tx.AddError(err)
+//		}
+//	})
+func ValidateModel(model any) error {
+	v := reflect.ValueOf(model)
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := range v.Len() {
+			if err := ValidateModel(v.Index(i).Interface()); err != nil {
+				return fmt.Errorf("invalid model at index %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	modelSchemasMu.RLock()
+	validateFn, ok := modelSchemas[v.Type()]
+	modelSchemasMu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return validateFn(v.Interface())
+}
+
+// Query wraps a query function (e.g. a method generated by sqlc) so that its
+// params are validated before the query is executed. The query's context is
+// passed to schemas that implement valtor.ContextValidator.
+//
+//	createUser := valtorsql.Query(userSchema, queries.CreateUser)
+//	user, err := createUser(ctx, params)
+func Query[P, R any](schema valtor.Validator[P], fn func(context.Context, P) (R, error)) func(context.Context, P) (R, error) {
+	return func(ctx context.Context, params P) (R, error) {
+		if err := validate(ctx, schema, params); err != nil {
+			var zero R
+			return zero, err
+		}
+		return fn(ctx, params)
+	}
+}
+
+// Exec wraps a query function that only returns an error (e.g. a `:exec`
+// method generated by sqlc) so that its params are validated before the query
+// is executed.
+func Exec[P any](schema valtor.Validator[P], fn func(context.Context, P) error) func(context.Context, P) error {
+	return func(ctx context.Context, params P) error {
+		if err := validate(ctx, schema, params); err != nil {
+			return err
+		}
+		return fn(ctx, params)
+	}
+}
+
+// validate validates the value with the validator, passing the context if the
+// validator implements valtor.ContextValidator.
+func validate[T any](ctx context.Context, validator valtor.Validator[T], value T) error {
+	if cv, ok := validator.(valtor.ContextValidator[T]); ok {
+		return cv.ValidateContext(ctx, value)
+	}
+	return validator.Validate(value)
+}
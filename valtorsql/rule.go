@@ -0,0 +1,120 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/valtor"
+)
+
+var (
+	ErrNotFound  = errors.New("value does not exist")
+	ErrNotUnique = errors.New("value already exists")
+)
+
+// Executor runs the queries of ExistsIn and UniqueIn. Implement it to use a
+// driver other than database/sql, e.g. pgx, or to rewrite the `$1`
+// placeholder for drivers that use a different placeholder syntax.
+type Executor interface {
+	// Exists reports whether the query returns at least one row.
+	Exists(ctx context.Context, query string, args ...any) (bool, error)
+}
+
+// ExecutorFunc is an adapter to use a function as an Executor.
+type ExecutorFunc func(ctx context.Context, query string, args ...any) (bool, error)
+
+func (f ExecutorFunc) Exists(ctx context.Context, query string, args ...any) (bool, error) {
+	return f(ctx, query, args...)
+}
+
+// Querier is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// DB returns an Executor that runs queries with database/sql.
+func DB(q Querier) Executor {
+	return ExecutorFunc(func(ctx context.Context, query string, args ...any) (bool, error) {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+
+		exists := rows.Next()
+		return exists, rows.Err()
+	})
+}
+
+// ExistsIn creates a rule that fails with ErrNotFound if the query returns no
+// rows, e.g. to check that a referenced record exists. The value is passed as
+// the query's only argument:
+//
+//	valtorsql.ExistsIn[int64](valtorsql.DB(db), "SELECT 1 FROM teams WHERE id = $1")
+//
+// The query runs with the context passed to ValidateContext. Add the rule to a
+// schema with Rule, and the schema to an object schema with valtor.FieldOf, so
+// that the context is passed on. Errors of the executor are returned as is.
+func ExistsIn[T any](exec Executor, query string) *valtor.Rule[T] {
+	return valtor.NewContextRule(func(ctx context.Context, value T) error {
+		exists, err := exec.Exists(ctx, query, value)
+		if err != nil {
+			return fmt.Errorf("failed to check existence: %w", err)
+		}
+		if !exists {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// UniqueIn creates a rule that fails with ErrNotUnique if a row of the table
+// has the value in the column, e.g. to check that an email address is not
+// taken:
+//
+//	email := valtor.String().Required()
+//	email.Rule(valtorsql.UniqueIn[string](valtorsql.DB(db), "users", "email"))
+//
+// The table and column names are quoted as identifiers, and may be qualified,
+// e.g. `auth.users`. The query uses a `$1` placeholder for the value. See
+// ExistsIn for how the query is run.
+func UniqueIn[T any](exec Executor, table, column string) *valtor.Rule[T] {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE %s = $1 LIMIT 1", quoteIdentifier(table), quoteIdentifier(column))
+	return valtor.NewContextRule(func(ctx context.Context, value T) error {
+		exists, err := exec.Exists(ctx, query, value)
+		if err != nil {
+			return fmt.Errorf("failed to check uniqueness: %w", err)
+		}
+		if exists {
+			return ErrNotUnique
+		}
+		return nil
+	})
+}
+
+// quoteIdentifier quotes the parts of a (possibly qualified) identifier with
+// double quotes, as defined by standard SQL.
+func quoteIdentifier(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorsql derives valtor schemas from database column metadata, so
+// that values accepted by application validation are also accepted by the
+// database.
+package valtorsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dstotijn/valtor"
+)
+
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// InformationSchemaQuery selects column metadata in the order expected by
+// ScanColumns. It uses `$1` (table schema) and `$2` (table name) placeholders;
+// rewrite them for drivers that use a different placeholder syntax.
+const InformationSchemaQuery = `SELECT column_name, data_type, is_nullable, character_maximum_length, numeric_precision, numeric_scale
+FROM information_schema.columns
+WHERE table_schema = $1 AND table_name = $2
+ORDER BY ordinal_position`
+
+// Column describes the constraints of a database column.
+type Column struct {
+	Name             string
+	DataType         string
+	Nullable         bool
+	MaxLength        int64 // Maximum length in characters, or 0 if unbounded.
+	NumericPrecision int64 // Total number of digits, or 0 if not applicable.
+	NumericScale     int64 // Number of digits after the decimal point.
+}
+
+// ScanColumns reads columns from rows returned by InformationSchemaQuery.
+func ScanColumns(rows *sql.Rows) ([]Column, error) {
+	defer rows.Close()
+
+	var columns []Column
+	for rows.Next() {
+		var (
+			col                 Column
+			isNullable          string
+			maxLength           sql.NullInt64
+			precision, numScale sql.NullInt64
+		)
+		if err := rows.Scan(&col.Name, &col.DataType, &isNullable, &maxLength, &precision, &numScale); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		col.Nullable = strings.EqualFold(isNullable, "YES")
+		col.MaxLength = maxLength.Int64
+		col.NumericPrecision = precision.Int64
+		col.NumericScale = numScale.Int64
+		columns = append(columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	return columns, nil
+}
+
+// Schema creates an object schema for map values keyed by column name.
+func Schema(columns []Column) (*valtor.ObjectSchema[any], error) {
+	objSchema := valtor.Object[any]()
+
+	for _, col := range columns {
+		validateFn, err := ColumnValidator(col)
+		if err != nil {
+			return nil, fmt.Errorf("invalid column %q: %w", col.Name, err)
+		}
+		objSchema.Field(col.Name, validateFn)
+	}
+
+	return objSchema, nil
+}
+
+// ColumnValidator creates a validator for a single column value. Values
+// implementing driver.Valuer and pointers are resolved before validation.
+func ColumnValidator(col Column) (func(any) error, error) {
+	var validateFn func(any) error
+
+	dataType, unsigned := normalizeDataType(col.DataType)
+	switch dataType {
+	case "char", "character", "varchar", "character varying", "nchar", "nvarchar", "text", "tinytext", "mediumtext", "longtext":
+		validateFn = stringValidator(col.MaxLength)
+	case "smallint", "int2":
+		validateFn = integerValidator(16, unsigned)
+	case "mediumint":
+		validateFn = integerValidator(24, unsigned)
+	case "integer", "int", "int4":
+		validateFn = integerValidator(32, unsigned)
+	case "bigint", "int8":
+		validateFn = integerValidator(64, unsigned)
+	case "tinyint":
+		validateFn = integerValidator(8, unsigned)
+	case "numeric", "decimal":
+		validateFn = decimalValidator(col.NumericPrecision, col.NumericScale)
+	case "real", "float4", "double precision", "float8", "float", "double", "boolean", "bool":
+		validateFn = func(any) error { return nil }
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, col.DataType)
+	}
+
+	return func(value any) error {
+		value, err := resolveValue(value)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			if !col.Nullable {
+				return valtor.ErrValueRequired
+			}
+			return nil
+		}
+		return validateFn(value)
+	}, nil
+}
+
+// normalizeDataType lowercases a data type and strips any type modifiers,
+// e.g. `VARCHAR(255)` becomes `varchar`. It reports whether the type is
+// unsigned, e.g. `TINYINT(3) UNSIGNED` in MySQL.
+func normalizeDataType(dataType string) (string, bool) {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	dataType, unsigned := strings.CutSuffix(dataType, " unsigned")
+	if i := strings.IndexByte(dataType, '('); i >= 0 {
+		dataType = strings.TrimSpace(dataType[:i])
+	}
+	return dataType, unsigned
+}
+
+func resolveValue(value any) (any, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v := reflect.ValueOf(valuer)
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return nil, nil
+		}
+		resolved, err := valuer.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve value: %w", err)
+		}
+		return resolved, nil
+	}
+
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	return v.Interface(), nil
+}
+
+func stringValidator(maxLength int64) func(any) error {
+	return func(value any) error {
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("expected string value, got %T", value)
+		}
+		if maxLength > 0 && int64(utf8.RuneCountInString(s)) > maxLength {
+			return fmt.Errorf("length must be at most %d", maxLength)
+		}
+		return nil
+	}
+}
+
+// integerValidator returns a validator for integers of the number of bits,
+// from 0 to 2^bits-1 if unsigned, or else from -2^(bits-1) to 2^(bits-1)-1.
+func integerValidator(bits int, unsigned bool) func(any) error {
+	var (
+		min int64
+		max uint64 = math.MaxUint64 >> (64 - bits)
+	)
+	if !unsigned {
+		min, max = -1<<(bits-1), max>>1
+	}
+	rangeErr := fmt.Errorf("value must be between %d and %d", min, max)
+
+	return func(value any) error {
+		if n, ok := value.(json.Number); ok {
+			i, err := strconv.ParseInt(string(n), 10, 64)
+			if errors.Is(err, strconv.ErrRange) && !strings.HasPrefix(string(n), "-") {
+				value, err = strconv.ParseUint(string(n), 10, 64)
+			} else {
+				value = i
+			}
+			if errors.Is(err, strconv.ErrRange) {
+				return rangeErr
+			}
+			if err != nil {
+				return fmt.Errorf("expected integer value, got %q", n)
+			}
+		}
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n := v.Int(); n < min || (n > 0 && uint64(n) > max) {
+				return rangeErr
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if n := v.Uint(); n > max {
+				return rangeErr
+			}
+		default:
+			return fmt.Errorf("expected integer value, got %T", value)
+		}
+		return nil
+	}
+}
+
+var decimalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+func decimalValidator(precision, scale int64) func(any) error {
+	return func(value any) error {
+		var s string
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(v.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			s = strconv.FormatUint(v.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			f := v.Float()
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return fmt.Errorf("value must be a finite number")
+			}
+			s = strconv.FormatFloat(f, 'f', -1, v.Type().Bits())
+		case reflect.String:
+			// Digits are counted as written, so exponents, e.g. `1e10`, and
+			// special values, e.g. `NaN`, are not accepted.
+			s = v.String()
+			if !decimalPattern.MatchString(s) {
+				return fmt.Errorf("expected numeric value in decimal notation, got %q", s)
+			}
+		default:
+			return fmt.Errorf("expected numeric value, got %T", value)
+		}
+		if precision == 0 {
+			return nil
+		}
+
+		intPart, fracPart, _ := strings.Cut(strings.TrimLeft(s, "+-"), ".")
+		intPart = strings.TrimLeft(intPart, "0")
+		fracPart = strings.TrimRight(fracPart, "0")
+
+		if int64(len(intPart)) > precision-scale {
+			return fmt.Errorf("value must have at most %d digits before the decimal point", precision-scale)
+		}
+		if int64(len(fracPart)) > scale {
+			return fmt.Errorf("value must have at most %d digits after the decimal point", scale)
+		}
+		return nil
+	}
+}
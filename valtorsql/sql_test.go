@@ -0,0 +1,369 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorsql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+func TestSchema(t *testing.T) {
+	columns := []Column{
+		{Name: "name", DataType: "character varying", MaxLength: 5},
+		{Name: "nickname", DataType: "varchar", MaxLength: 5, Nullable: true},
+		{Name: "age", DataType: "smallint"},
+		{Name: "price", DataType: "numeric", NumericPrecision: 5, NumericScale: 2},
+		{Name: "quantity", DataType: "TINYINT(3) UNSIGNED", Nullable: true},
+		{Name: "views", DataType: "bigint unsigned", Nullable: true},
+	}
+
+	schema, err := Schema(columns)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: map[string]any{"name": "héllo", "age": 30, "price": 999.99},
+		},
+		{
+			name:    "too long",
+			data:    map[string]any{"name": "hello!", "age": 30, "price": 1.0},
+			wantErr: true,
+		},
+		{
+			name:    "missing not null",
+			data:    map[string]any{"age": 30, "price": 1.0},
+			wantErr: true,
+		},
+		{
+			name:    "integer overflow",
+			data:    map[string]any{"name": "John", "age": 40000, "price": 1.0},
+			wantErr: true,
+		},
+		{
+			name:    "too many integer digits",
+			data:    map[string]any{"name": "John", "age": 30, "price": 1000.0},
+			wantErr: true,
+		},
+		{
+			name:    "too many fractional digits",
+			data:    map[string]any{"name": "John", "age": 30, "price": 1.001},
+			wantErr: true,
+		},
+		{
+			name:    "wrong type",
+			data:    map[string]any{"name": 1, "age": 30, "price": 1.0},
+			wantErr: true,
+		},
+		{
+			name: "json numbers",
+			data: map[string]any{"name": "John", "age": json.Number("30"), "price": json.Number("999.99")},
+		},
+		{
+			name:    "json number integer overflow",
+			data:    map[string]any{"name": "John", "age": json.Number("40000"), "price": json.Number("1")},
+			wantErr: true,
+		},
+		{
+			name: "unsigned",
+			data: map[string]any{"name": "John", "age": 30, "price": 1.0, "quantity": 200, "views": json.Number("18446744073709551615")},
+		},
+		{
+			name:    "negative unsigned",
+			data:    map[string]any{"name": "John", "age": 30, "price": 1.0, "quantity": -5},
+			wantErr: true,
+		},
+		{
+			name:    "unsigned overflow",
+			data:    map[string]any{"name": "John", "age": 30, "price": 1.0, "quantity": uint8(255), "views": json.Number("18446744073709551616")},
+			wantErr: true,
+		},
+		{
+			name: "decimal string",
+			data: map[string]any{"name": "John", "age": 30, "price": "-999.99"},
+		},
+		{
+			name:    "decimal string with exponent",
+			data:    map[string]any{"name": "John", "age": 30, "price": "1e2"},
+			wantErr: true,
+		},
+		{
+			name:    "json number with fractional part",
+			data:    map[string]any{"name": "John", "age": json.Number("30.5"), "price": json.Number("1")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.data)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got no error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestSchemaUnsupportedType(t *testing.T) {
+	_, err := Schema([]Column{{Name: "data", DataType: "jsonb"}})
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected error %q, got %v", ErrUnsupportedType, err)
+	}
+}
+
+func TestStructSchema(t *testing.T) {
+	type Model struct {
+		ID uint
+	}
+	type Product struct {
+		Model
+		Code     string         `gorm:"size:4;not null"`
+		Name     *string        `gorm:"type:varchar(8);not null"`
+		Price    float64        `gorm:"type:decimal(5,2)"`
+		Note     sql.NullString `gorm:"size:3"`
+		Data     []byte         `gorm:"type:jsonb"`
+		internal string
+		Ignored  string `gorm:"-"`
+	}
+
+	columns := StructColumns[Product]()
+	var names []string
+	for _, col := range columns {
+		names = append(names, col.Name)
+	}
+	if got, want := len(names), 6; got != want {
+		t.Fatalf("expected %d columns, got %d (%v)", want, got, names)
+	}
+
+	schema, err := StructSchema[Product]()
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	name := "Widget"
+	valid := Product{Code: "W001", Name: &name, Price: 12.5}
+	if err := schema.Validate(valid); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+
+	invalid := []Product{
+		{Code: "W0001", Name: &name},
+		{Code: "W001"},
+		{Code: "W001", Name: &name, Price: 1234},
+		{Code: "W001", Name: &name, Note: sql.NullString{String: "long", Valid: true}},
+	}
+	for _, p := range invalid {
+		if err := schema.Validate(p); err == nil {
+			t.Errorf("expected error for %+v, got no error", p)
+		}
+	}
+
+	if err := schema.Validate(Product{Code: "W001"}); !errors.Is(err, valtor.ErrValueRequired) {
+		t.Errorf("expected error %q, got %v", valtor.ErrValueRequired, err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"UserID":     "user_id",
+		"CreatedAt":  "created_at",
+		"HTTPServer": "http_server",
+		"Address2":   "address2",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	type Order struct {
+		Quantity int
+	}
+
+	Register(valtor.FieldOf(valtor.Object[Order](), "quantity",
+		func(o Order) int { return o.Quantity },
+		valtor.Number[int]().Min(1),
+	))
+
+	tests := []struct {
+		name    string
+		model   any
+		wantErr bool
+	}{
+		{name: "value", model: Order{Quantity: 1}},
+		{name: "pointer", model: &Order{Quantity: 0}, wantErr: true},
+		{name: "slice", model: []Order{{Quantity: 1}, {Quantity: 0}}, wantErr: true},
+		{name: "pointer to slice", model: &[]*Order{{Quantity: 2}}},
+		{name: "nil pointer", model: (*Order)(nil)},
+		{name: "unregistered", model: struct{ Quantity int }{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateModel(tt.model)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got no error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	type CreateUserParams struct {
+		Name string
+	}
+
+	called := false
+	createUser := Query(
+		valtor.FieldOf(valtor.Object[CreateUserParams](), "name",
+			func(p CreateUserParams) string { return p.Name },
+			valtor.String().Required(),
+		),
+		func(_ context.Context, p CreateUserParams) (int64, error) {
+			called = true
+			return 1, nil
+		},
+	)
+
+	if _, err := createUser(context.Background(), CreateUserParams{}); !errors.Is(err, valtor.ErrValueRequired) {
+		t.Errorf("expected error %q, got %v", valtor.ErrValueRequired, err)
+	}
+	if called {
+		t.Error("expected query not to be called for invalid params")
+	}
+
+	id, err := createUser(context.Background(), CreateUserParams{Name: "John"})
+	if err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if !called || id != 1 {
+		t.Error("expected query to be called for valid params")
+	}
+}
+
+func TestExistsIn(t *testing.T) {
+	var gotQuery string
+	var gotArgs []any
+	exec := ExecutorFunc(func(_ context.Context, query string, args ...any) (bool, error) {
+		gotQuery, gotArgs = query, args
+		return args[0] == int64(1), nil
+	})
+	rule := ExistsIn[int64](exec, "SELECT 1 FROM teams WHERE id = $1")
+
+	if err := rule.Validate(1); err != nil {
+		t.Errorf("expected no error, got %q", err)
+	}
+	if err := rule.Validate(2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected error %q, got %v", ErrNotFound, err)
+	}
+	if gotQuery != "SELECT 1 FROM teams WHERE id = $1" {
+		t.Errorf("unexpected query %q", gotQuery)
+	}
+	if len(gotArgs) != 1 || gotArgs[0] != int64(2) {
+		t.Errorf("unexpected args %v", gotArgs)
+	}
+}
+
+func TestUniqueIn(t *testing.T) {
+	type SignUp struct {
+		Email string
+	}
+
+	taken := map[any]bool{"jane@example.com": true}
+	var gotQuery string
+	exec := ExecutorFunc(func(ctx context.Context, query string, args ...any) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		gotQuery = query
+		return taken[args[0]], nil
+	})
+
+	email := valtor.String().Required()
+	email.Rule(UniqueIn[string](exec, "auth.users", "email"))
+	schema := valtor.Object[SignUp]()
+	valtor.FieldOf(schema, "email", func(s SignUp) string { return s.Email }, email)
+
+	tests := []struct {
+		name    string
+		ctx     func() context.Context
+		email   string
+		wantErr error
+	}{
+		{
+			name:  "unique",
+			ctx:   context.Background,
+			email: "john@example.com",
+		},
+		{
+			name:    "taken",
+			ctx:     context.Background,
+			email:   "jane@example.com",
+			wantErr: ErrNotUnique,
+		},
+		{
+			name:    "required before query",
+			ctx:     context.Background,
+			wantErr: valtor.ErrValueRequired,
+		},
+		{
+			name: "canceled",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			email:   "john@example.com",
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.ValidateContext(tt.ctx(), SignUp{Email: tt.email})
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	wantQuery := `SELECT 1 FROM "auth"."users" WHERE "email" = $1 LIMIT 1`
+	if gotQuery != wantQuery {
+		t.Errorf("expected query %q, got %q", wantQuery, gotQuery)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorsql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dstotijn/valtor"
+)
+
+// StructColumn is a column derived from a struct field.
+type StructColumn struct {
+	Column
+	Index []int // Field index sequence, for use with reflect.Value.FieldByIndex.
+}
+
+// StructColumns derives columns from the exported fields of struct type T.
+// Column names and constraints are read from GORM struct tags (`column`,
+// `type`, `size`, `precision`, `scale`, `not null`), falling back to the `db`
+// tag (as used by sqlc) and the snake cased field name. Pointer fields and
+// `sql.Null*` types are nullable. Fields of types without a known column type
+// are omitted.
+func StructColumns[T any]() []StructColumn {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return structColumns(typ, nil)
+}
+
+func structColumns(typ reflect.Type, index []int) []StructColumn {
+	var columns []StructColumn
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tags := parseGORMTag(field.Tag.Get("gorm"))
+		if _, ok := tags["-"]; ok {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, structColumns(field.Type, fieldIndex)...)
+			continue
+		}
+
+		col, ok := fieldColumn(field, tags)
+		if !ok {
+			continue
+		}
+		columns = append(columns, StructColumn{Column: col, Index: fieldIndex})
+	}
+
+	return columns
+}
+
+func fieldColumn(field reflect.StructField, tags map[string]string) (Column, bool) {
+	col := Column{Name: tags["column"]}
+	if col.Name == "" {
+		col.Name, _, _ = strings.Cut(field.Tag.Get("db"), ",")
+	}
+	if col.Name == "" {
+		col.Name = snakeCase(field.Name)
+	}
+
+	typ := field.Type
+	if typ.Kind() == reflect.Pointer {
+		col.Nullable = true
+		typ = typ.Elem()
+	}
+
+	switch typ {
+	case reflect.TypeFor[sql.NullString]():
+		col.DataType, col.Nullable = "varchar", true
+	case reflect.TypeFor[sql.NullInt16]():
+		col.DataType, col.Nullable = "smallint", true
+	case reflect.TypeFor[sql.NullInt32]():
+		col.DataType, col.Nullable = "integer", true
+	case reflect.TypeFor[sql.NullInt64]():
+		col.DataType, col.Nullable = "bigint", true
+	case reflect.TypeFor[sql.NullFloat64]():
+		col.DataType, col.Nullable = "double precision", true
+	case reflect.TypeFor[sql.NullBool]():
+		col.DataType, col.Nullable = "boolean", true
+	default:
+		switch typ.Kind() {
+		case reflect.String:
+			col.DataType = "varchar"
+		case reflect.Int8:
+			col.DataType = "tinyint"
+		case reflect.Int16, reflect.Uint8:
+			col.DataType = "smallint"
+		case reflect.Int32, reflect.Uint16:
+			col.DataType = "integer"
+		case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+			col.DataType = "bigint"
+		case reflect.Float32, reflect.Float64:
+			col.DataType = "double precision"
+		case reflect.Bool:
+			col.DataType = "boolean"
+		}
+	}
+
+	if dataType, ok := tags["type"]; ok {
+		col.DataType = dataType
+		if mods, ok := typeModifiers(dataType); ok {
+			switch typ, _ := normalizeDataType(dataType); typ {
+			case "numeric", "decimal":
+				col.NumericPrecision = mods[0]
+				if len(mods) > 1 {
+					col.NumericScale = mods[1]
+				}
+			default:
+				col.MaxLength = mods[0]
+			}
+		}
+	}
+	if col.DataType == "" {
+		return Column{}, false
+	}
+
+	if size, err := strconv.ParseInt(tags["size"], 10, 64); err == nil {
+		col.MaxLength = size
+	}
+	if precision, err := strconv.ParseInt(tags["precision"], 10, 64); err == nil {
+		col.NumericPrecision = precision
+	}
+	if scale, err := strconv.ParseInt(tags["scale"], 10, 64); err == nil {
+		col.NumericScale = scale
+	}
+	if _, ok := tags["not null"]; ok {
+		col.Nullable = false
+	}
+
+	return col, true
+}
+
+// StructSchema creates an object schema for struct type T from the columns
+// returned by StructColumns. Columns with an unsupported data type (e.g. an
+// explicit `type:jsonb` tag) are skipped.
+func StructSchema[T any]() (*valtor.ObjectSchema[T], error) {
+	objSchema := valtor.Object[T]()
+
+	for _, col := range StructColumns[T]() {
+		validateFn, err := ColumnValidator(col.Column)
+		if errors.Is(err, ErrUnsupportedType) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid column %q: %w", col.Name, err)
+		}
+
+		index := col.Index
+		objSchema.Field(col.Name, func(value T) error {
+			return validateFn(reflect.ValueOf(value).FieldByIndex(index).Interface())
+		})
+	}
+
+	return objSchema, nil
+}
+
+// parseGORMTag parses a GORM struct tag (e.g. `column:name;size:255;not null`)
+// into a map of lowercased keys to values.
+func parseGORMTag(tag string) map[string]string {
+	tags := make(map[string]string)
+	for _, part := range strings.Split(tag, ";") {
+		key, value, _ := strings.Cut(part, ":")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags
+}
+
+// typeModifiers parses the modifiers of a data type, e.g. `decimal(10,2)`.
+func typeModifiers(dataType string) ([]int64, bool) {
+	_, mods, ok := strings.Cut(dataType, "(")
+	if !ok {
+		return nil, false
+	}
+	mods, _, ok = strings.Cut(mods, ")")
+	if !ok {
+		return nil, false
+	}
+
+	var values []int64
+	for _, mod := range strings.Split(mods, ",") {
+		v, err := strconv.ParseInt(strings.TrimSpace(mod), 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, v)
+	}
+	return values, true
+}
+
+// snakeCase converts a Go field name to snake case, keeping initialisms
+// together (e.g. `UserID` becomes `user_id`).
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
@@ -0,0 +1,8 @@
+module github.com/dstotijn/valtor/valtorunicode
+
+go 1.24.0
+
+require (
+	github.com/dstotijn/valtor v0.0.0-20261018044750-95b85c532fb9
+	golang.org/x/text v0.30.0
+)
@@ -0,0 +1,228 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valtorunicode validates and normalizes the Unicode normalization of
+// strings and detects homoglyph abuse, e.g. a username that mixes Latin and
+// Cyrillic letters to impersonate another user. Its validators can be added to
+// schemas with Custom, e.g. `valtor.String().Custom(valtorunicode.NFC)`, and
+// its normalizations with Transform, e.g.
+// `valtor.String().Transform(valtorunicode.ToNFC)`, so that valtor.Run
+// returns normalized strings.
+//
+// Confusable characters are detected with a table of characters that look
+// like ASCII letters, e.g. Cyrillic `а` and Greek `ο`, and of invisible
+// characters. It is a subset of the Unicode confusables data (see Unicode
+// Technical Standard #39), which covers the most common homoglyph attacks on
+// ASCII names.
+//
+// The package is a module of its own, as it depends on the normalization
+// tables of golang.org/x/text.
+package valtorunicode
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrNotNormalized = errors.New("string is not normalized")
+	ErrMixedScript   = errors.New("string mixes scripts")
+	ErrConfusable    = errors.New("string contains confusable characters")
+)
+
+// confusables maps characters to the ASCII text they can be confused with. An
+// empty string is used for invisible characters.
+var confusables = map[rune]string{
+	// Cyrillic.
+	'а': "a", 'в': "B", 'е': "e", 'о': "o", 'р': "p", 'с': "c", 'у': "y",
+	'х': "x", 'і': "i", 'ј': "j", 'ѕ': "s", 'ԁ': "d", 'ԛ': "q", 'ԝ': "w",
+	'һ': "h", 'ӏ': "l", 'А': "A", 'В': "B", 'Е': "E", 'К': "K", 'М': "M",
+	'Н': "H", 'О': "O", 'Р': "P", 'С': "C", 'Т': "T", 'Х': "X", 'Ѕ': "S",
+	'І': "I", 'Ј': "J", 'Ү': "Y", 'Ԛ': "Q", 'Ԝ': "W",
+	// Greek.
+	'α': "a", 'ι': "i", 'κ': "k", 'ν': "v", 'ο': "o", 'ρ': "p", 'υ': "u",
+	'Α': "A", 'Β': "B", 'Ε': "E", 'Ζ': "Z", 'Η': "H", 'Ι': "I", 'Κ': "K",
+	'Μ': "M", 'Ν': "N", 'Ο': "O", 'Ρ': "P", 'Τ': "T", 'Υ': "Y", 'Χ': "X",
+	// Armenian.
+	'հ': "h", 'ո': "n", 'ս': "u", 'օ': "o",
+	// Latin letters that aren't ASCII.
+	'ı': "i", 'ǀ': "l", 'ɑ': "a", 'ɡ': "g", 'ɩ': "i",
+	// Invisible characters.
+	'\u00AD': "", '\u034F': "", '\u200B': "", '\u200C': "", '\u200D': "",
+	'\u2060': "", '\uFEFF': "",
+}
+
+// commonScripts are the scripts that are checked first to find the script of
+// a character, as most text is written in them.
+var commonScripts = []string{"Latin", "Cyrillic", "Greek", "Han", "Arabic", "Hebrew", "Hiragana", "Katakana", "Hangul"}
+
+// otherScripts are the other scripts in sorted order, so that lookups are
+// deterministic.
+var otherScripts = slices.DeleteFunc(slices.Sorted(maps.Keys(unicode.Scripts)), func(name string) bool {
+	return name == "Common" || name == "Inherited" || slices.Contains(commonScripts, name)
+})
+
+// cjkScripts are the scripts that are mixed in Chinese, Japanese and Korean
+// text.
+var cjkScripts = map[string]bool{"Han": true, "Hiragana": true, "Katakana": true, "Hangul": true, "Bopomofo": true}
+
+// NFC validates that s is in Unicode Normalization Form C, so that e.g. `é`
+// is a single code point rather than `e` followed by a combining accent. Use
+// ToNFC to normalize strings. Errors wrap ErrNotNormalized.
+func NFC(s string) error {
+	if !norm.NFC.IsNormalString(s) {
+		return fmt.Errorf("%w to NFC", ErrNotNormalized)
+	}
+	return nil
+}
+
+// NFKC validates that s is in Unicode Normalization Form KC, which, unlike
+// NFC, also maps compatibility characters such as the ligature `ﬁ` and
+// fullwidth letters to their plain equivalents. Use ToNFKC to normalize
+// strings. Errors wrap ErrNotNormalized.
+func NFKC(s string) error {
+	if !norm.NFKC.IsNormalString(s) {
+		return fmt.Errorf("%w to NFKC", ErrNotNormalized)
+	}
+	return nil
+}
+
+// ToNFC returns s in Unicode Normalization Form C (see NFC), e.g. to add to a
+// schema with Transform.
+func ToNFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// ToNFKC returns s in Unicode Normalization Form KC (see NFKC), e.g. to add to
+// a schema with Transform.
+func ToNFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// Scripts returns the names of the scripts of the letters and other
+// characters in s, e.g. `Latin` and `Cyrillic`, in sorted order. Characters
+// that are common to scripts, such as digits and punctuation, or inherit the
+// script of the preceding character, such as combining marks, are ignored.
+func Scripts(s string) []string {
+	seen := make(map[string]bool)
+	for _, r := range s {
+		if script := scriptOf(r); script != "" {
+			seen[script] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// MixedScript validates that s is written in a single script (see Scripts),
+// except for mixes of Han, Hiragana, Katakana, Hangul and Bopomofo, as in
+// Chinese, Japanese and Korean text. Errors wrap ErrMixedScript.
+func MixedScript(s string) error {
+	scripts := Scripts(s)
+	if len(scripts) <= 1 {
+		return nil
+	}
+	for _, script := range scripts {
+		if !cjkScripts[script] {
+			return fmt.Errorf("%w: %s", ErrMixedScript, strings.Join(scripts, ", "))
+		}
+	}
+	return nil
+}
+
+// Confusables validates that s doesn't contain invisible characters, such as
+// a zero width space, and that it doesn't look like ASCII text it isn't: it
+// fails if s mixes scripts (see Scripts) and contains characters that can be
+// confused with ASCII letters, such as Cyrillic `а` in `pаypal`, or if its
+// Skeleton is ASCII text while s isn't, such as Cyrillic `раура`. Text in a
+// single script, such as the Cyrillic name `Олег`, is valid, even if some of
+// its letters look like ASCII letters. Errors wrap ErrConfusable.
+func Confusables(s string) error {
+	first := rune(-1)
+	for _, r := range s {
+		target, ok := confusables[r]
+		if !ok {
+			continue
+		}
+		if target == "" {
+			return fmt.Errorf("%w: invisible character %U", ErrConfusable, r)
+		}
+		if first < 0 {
+			first = r
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+	if len(Scripts(s)) > 1 {
+		return fmt.Errorf("%w: %q (%U) looks like %q", ErrConfusable, first, first, confusables[first])
+	}
+	if skeleton := Skeleton(s); isASCII(skeleton) {
+		return fmt.Errorf("%w: %q looks like %q", ErrConfusable, s, skeleton)
+	}
+	return nil
+}
+
+// isASCII reports whether s only contains ASCII characters.
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Skeleton returns the skeleton of s, in which confusable characters (see
+// Confusables) are replaced with the ASCII letters they look like and
+// invisible characters are removed, after NFKD normalization. Strings that
+// look alike have the same skeleton, e.g. `pаypal` with a Cyrillic `а` and
+// `paypal`, so that e.g. usernames can be checked for uniqueness by skeleton.
+// Skeletons are meant for comparison only, not for display.
+func Skeleton(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFKD.String(s) {
+		if target, ok := confusables[r]; ok {
+			sb.WriteString(target)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return norm.NFKD.String(sb.String())
+}
+
+// scriptOf returns the name of the script of r, or an empty string if r is
+// common to scripts or inherits its script.
+func scriptOf(r rune) string {
+	if unicode.Is(unicode.Common, r) || unicode.Is(unicode.Inherited, r) {
+		return ""
+	}
+	for _, name := range commonScripts {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	for _, name := range otherScripts {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtorunicode
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dstotijn/valtor"
+)
+
+func TestNormalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		validate func(string) error
+		wantErr  error
+	}{
+		{name: "NFC composed", input: "café", validate: NFC},
+		{name: "NFC decomposed", input: "cafe\u0301", validate: NFC, wantErr: ErrNotNormalized},
+		{name: "NFC compatibility character", input: "ﬁle", validate: NFC},
+		{name: "NFKC composed", input: "café", validate: NFKC},
+		{name: "NFKC decomposed", input: "cafe\u0301", validate: NFKC, wantErr: ErrNotNormalized},
+		{name: "NFKC compatibility character", input: "ﬁle", validate: NFKC, wantErr: ErrNotNormalized},
+		{name: "NFKC fullwidth", input: "ａdmin", validate: NFKC, wantErr: ErrNotNormalized},
+		{name: "ASCII", input: "admin", validate: NFKC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validate(tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScripts(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr error
+	}{
+		{name: "Latin", input: "gopher_42", want: []string{"Latin"}},
+		{name: "Latin with accents", input: "Zoë Ångström", want: []string{"Latin"}},
+		{name: "Cyrillic", input: "Привет", want: []string{"Cyrillic"}},
+		{name: "Latin and Cyrillic", input: "pаypal", want: []string{"Cyrillic", "Latin"}, wantErr: ErrMixedScript},
+		{name: "Latin and Greek", input: "gοpher", want: []string{"Greek", "Latin"}, wantErr: ErrMixedScript},
+		{name: "Japanese", input: "東京タワーへ", want: []string{"Han", "Hiragana", "Katakana"}},
+		{name: "Korean", input: "韓國어", want: []string{"Han", "Hangul"}},
+		{name: "Han and Latin", input: "東京 Tokyo", want: []string{"Han", "Latin"}, wantErr: ErrMixedScript},
+		{name: "common only", input: "123-456", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Scripts(tt.input); !slices.Equal(got, tt.want) {
+				t.Errorf("Scripts() = %v, want %v", got, tt.want)
+			}
+			if err := MixedScript(tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("MixedScript() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfusables(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantErr      error
+		wantSkeleton string
+	}{
+		{name: "ASCII", input: "paypal", wantSkeleton: "paypal"},
+		{name: "Cyrillic a", input: "pаypal", wantErr: ErrConfusable, wantSkeleton: "paypal"},
+		{name: "whole script Cyrillic", input: "раураl", wantErr: ErrConfusable, wantSkeleton: "paypal"},
+		{name: "Greek omicron", input: "gοpher", wantErr: ErrConfusable, wantSkeleton: "gopher"},
+		{name: "zero width space", input: "ad\u200bmin", wantErr: ErrConfusable, wantSkeleton: "admin"},
+		{name: "fullwidth", input: "ａdmin", wantSkeleton: "admin"},
+		{name: "accent", input: "café", wantSkeleton: "cafe\u0301"},
+		{name: "Cyrillic without lookalikes", input: "жизнь", wantSkeleton: "жизнь"},
+		{name: "Cyrillic name", input: "Олег", wantSkeleton: "Oлeг"},
+		{name: "Greek name", input: "Μαρία", wantSkeleton: "Mapi\u0301a"},
+		{name: "whole script Cyrillic lookalike", input: "раура", wantErr: ErrConfusable, wantSkeleton: "paypa"},
+		{name: "Cyrillic name with Latin", input: "Олег Smith", wantErr: ErrConfusable, wantSkeleton: "Oлeг Smith"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Confusables(tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Confusables() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := Skeleton(tt.input); got != tt.wantSkeleton {
+				t.Errorf("Skeleton() = %q, want %q", got, tt.wantSkeleton)
+			}
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(string) string
+		input     string
+		want      string
+	}{
+		{name: "NFC decomposed", transform: ToNFC, input: "cafe\u0301", want: "caf\u00e9"},
+		{name: "NFC compatibility character", transform: ToNFC, input: "ﬁle", want: "ﬁle"},
+		{name: "NFKC compatibility character", transform: ToNFKC, input: "ﬁle", want: "file"},
+		{name: "NFKC fullwidth", transform: ToNFKC, input: "ａdmin", want: "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := valtor.String().Required().Custom(NFKC).Transform(tt.transform)
+			out := valtor.Run(context.Background(), schema, tt.input)
+			if out.Value != tt.want {
+				t.Errorf("got %q, want %q", out.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema := valtor.String().Required().Custom(NFKC).Custom(MixedScript).Custom(Confusables)
+
+	if err := schema.Validate("gopher"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	err := schema.Validate("gοpher")
+	if !errors.Is(err, ErrMixedScript) {
+		t.Errorf("expected ErrMixedScript, got %v", err)
+	}
+	if want := `string mixes scripts: Greek, Latin`; err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
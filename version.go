@@ -0,0 +1,205 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+var (
+	ErrUnknownVersion = errors.New("unknown schema version")
+	ErrNoMigration    = errors.New("no migration")
+)
+
+// schemaVersion is a version of a SchemaSet. Values are kept as any, so that
+// versions can have different types.
+type schemaVersion struct {
+	typ        reflect.Type
+	decodeFn   func(data []byte) (any, error)
+	validateFn func(ctx context.Context, value any) error
+}
+
+// migration upgrades values of type from to the shape of version to, of type
+// typ.
+type migration struct {
+	to        string
+	from      reflect.Type
+	typ       reflect.Type
+	migrateFn func(value any) (any, error)
+}
+
+// SchemaSet is a set of versions of a payload schema, e.g. of an API that
+// accepts multiple payload versions, with migrations that upgrade payloads of
+// older versions to the shape of the next version, up to the current version
+// of type T. A SchemaSet is meant to be set up once; it is not safe to add
+// versions or migrations concurrently with validation.
+type SchemaSet[T any] struct {
+	current    string
+	versions   map[string]schemaVersion
+	migrations map[string]migration
+}
+
+// NewSchemaSet creates a new schema set with the current version and its
+// schema.
+func NewSchemaSet[T any](current string, schema Validator[T]) *SchemaSet[T] {
+	s := &SchemaSet[T]{
+		current:    current,
+		versions:   make(map[string]schemaVersion),
+		migrations: make(map[string]migration),
+	}
+	AddVersion(s, current, schema)
+	return s
+}
+
+// AddVersion adds a version with a schema for payloads of type V to the set
+// and returns the set for chaining. Adding a version that already exists
+// replaces its schema.
+func AddVersion[T, V any](s *SchemaSet[T], version string, schema Validator[V]) *SchemaSet[T] {
+	s.versions[version] = schemaVersion{
+		typ: reflect.TypeFor[V](),
+		decodeFn: func(data []byte) (any, error) {
+			return DecodeJSON[V](bytes.NewReader(data))
+		},
+		validateFn: func(ctx context.Context, value any) error {
+			v, ok := value.(V)
+			if !ok {
+				return fmt.Errorf("expected value of type %v, got %T", reflect.TypeFor[V](), value)
+			}
+			return validateContext(ctx, schema, v)
+		},
+	}
+	return s
+}
+
+// AddMigration adds a migration that upgrades payloads of version from, of
+// type V, to the shape of version to, of type N, and returns the set for
+// chaining. Payloads are upgraded one migration at a time, so that e.g. a
+// payload of version 1 is upgraded to version 3 by migrations from 1 to 2 and
+// from 2 to 3. A version has at most one migration; adding another replaces
+// it. Migrations from a version of another type than V, to unknown versions,
+// or to a version of another type than N, fail when they are applied.
+func AddMigration[T, V, N any](s *SchemaSet[T], from, to string, fn func(V) (N, error)) *SchemaSet[T] {
+	s.migrations[from] = migration{
+		to:   to,
+		from: reflect.TypeFor[V](),
+		typ:  reflect.TypeFor[N](),
+		migrateFn: func(value any) (any, error) {
+			v, ok := value.(V)
+			if !ok {
+				return nil, fmt.Errorf("expected value of type %v, got %T", reflect.TypeFor[V](), value)
+			}
+			return fn(v)
+		},
+	}
+	return s
+}
+
+// Current returns the current version of the set.
+func (s *SchemaSet[T]) Current() string {
+	return s.current
+}
+
+// Versions returns the versions of the set, in sorted order.
+func (s *SchemaSet[T]) Versions() []string {
+	return slices.Sorted(maps.Keys(s.versions))
+}
+
+// Validate decodes a JSON payload of the version and validates it against the
+// schema of the version, without upgrading it. It fails with
+// ErrUnknownVersion if the set has no such version, and with a DecodeError if
+// the payload can't be decoded.
+func (s *SchemaSet[T]) Validate(version string, data []byte) error {
+	return s.ValidateContext(context.Background(), version, data)
+}
+
+// ValidateContext validates a JSON payload of the version with the given
+// context. See Validate.
+func (s *SchemaSet[T]) ValidateContext(ctx context.Context, version string, data []byte) error {
+	_, err := s.decode(ctx, version, data)
+	return err
+}
+
+// Upgrade decodes a JSON payload of the version, validates it and applies the
+// migrations to the current version, validating the result of each migration
+// against the schema of its version. Errors of the payload itself are returned
+// as is, as by Validate. Errors of a migration, or of its result, are wrapped
+// with the versions of the migration, and a payload that can't be upgraded to
+// the current version fails with ErrNoMigration.
+func (s *SchemaSet[T]) Upgrade(ctx context.Context, version string, data []byte) (T, error) {
+	var zero T
+	value, err := s.decode(ctx, version, data)
+	if err != nil {
+		return zero, err
+	}
+
+	seen := map[string]bool{version: true}
+	for version != s.current {
+		m, ok := s.migrations[version]
+		if !ok {
+			return zero, fmt.Errorf("%w from version %q to %q", ErrNoMigration, version, s.current)
+		}
+		if seen[m.to] {
+			return zero, fmt.Errorf("%w from version %q to %q: migrations form a cycle", ErrNoMigration, version, s.current)
+		}
+		seen[m.to] = true
+		next, ok := s.versions[m.to]
+		if !ok {
+			return zero, fmt.Errorf("%w %q", ErrUnknownVersion, m.to)
+		}
+		if from := s.versions[version].typ; m.from != from {
+			return zero, fmt.Errorf("failed to migrate from version %q to %q: expected migration of value of type %v, got %v",
+				version, m.to, from, m.from)
+		}
+		if m.typ != next.typ {
+			return zero, fmt.Errorf("failed to migrate from version %q to %q: expected value of type %v, got %v",
+				version, m.to, next.typ, m.typ)
+		}
+
+		if value, err = m.migrateFn(value); err != nil {
+			return zero, fmt.Errorf("failed to migrate from version %q to %q: %w", version, m.to, err)
+		}
+		if err := next.validateFn(ctx, value); err != nil {
+			return zero, fmt.Errorf("invalid result of migration from version %q to %q: %w", version, m.to, err)
+		}
+		version = m.to
+	}
+	result, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("invalid value of version %q: expected value of type %v, got %T", version, reflect.TypeFor[T](), value)
+	}
+	return result, nil
+}
+
+// decode decodes and validates a payload of the version.
+func (s *SchemaSet[T]) decode(ctx context.Context, version string, data []byte) (any, error) {
+	v, ok := s.versions[version]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownVersion, version)
+	}
+	value, err := v.decodeFn(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.validateFn(ctx, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
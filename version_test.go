@@ -0,0 +1,94 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestSchemaSetUpgradeTypes(t *testing.T) {
+	type v1 struct {
+		N string `json:"n"`
+	}
+	type v2 struct {
+		N int `json:"n"`
+	}
+	toV2 := func(v v1) (v2, error) {
+		n, err := strconv.Atoi(v.N)
+		return v2{N: n}, err
+	}
+
+	tests := []struct {
+		name    string
+		set     func() *SchemaSet[v2]
+		wantErr string
+	}{
+		{
+			name: "valid",
+			set: func() *SchemaSet[v2] {
+				set := NewSchemaSet("2", New[v2]())
+				AddVersion(set, "1", New[v1]())
+				return AddMigration(set, "1", "2", toV2)
+			},
+		},
+		{
+			name: "migration from other type",
+			set: func() *SchemaSet[v2] {
+				set := NewSchemaSet("2", New[v2]())
+				AddVersion(set, "1", New[v1]())
+				return AddMigration(set, "1", "2", func(v v2) (v2, error) { return v, nil })
+			},
+			wantErr: `failed to migrate from version "1" to "2": expected migration of value of type valtor.v1, got valtor.v2`,
+		},
+		{
+			name: "migration to other type",
+			set: func() *SchemaSet[v2] {
+				set := NewSchemaSet("2", New[v2]())
+				AddVersion(set, "1", New[v1]())
+				return AddMigration(set, "1", "2", func(v v1) (v1, error) { return v, nil })
+			},
+			wantErr: `failed to migrate from version "1" to "2": expected value of type valtor.v2, got valtor.v1`,
+		},
+		{
+			name: "current version of other type",
+			set: func() *SchemaSet[v2] {
+				set := NewSchemaSet("2", New[v2]())
+				AddVersion(set, "1", New[v1]())
+				AddVersion(set, "2", New[v1]())
+				return AddMigration(set, "1", "2", func(v v1) (v1, error) { return v, nil })
+			},
+			wantErr: `invalid value of version "2": expected value of type valtor.v2, got valtor.v1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.set().Upgrade(context.Background(), "1", []byte(`{"n": "42"}`))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if got.N != 42 {
+				t.Errorf("got %+v, want N 42", got)
+			}
+		})
+	}
+}
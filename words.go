@@ -0,0 +1,130 @@
+// Copyright 2025 David Stotijn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valtor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"unicode"
+)
+
+// WordList is a list of words, e.g. of reserved usernames or profanity, that
+// is used by StringSchema.InList, NotInList and DenyWords. Implement it to
+// provide lists from other sources, e.g. a database. Implementations must be
+// safe for concurrent use.
+type WordList interface {
+	// Contains reports whether the word, or value, is in the list.
+	Contains(word string) bool
+}
+
+// wordSet is a WordList of words that are compared case-insensitively.
+type wordSet map[string]struct{}
+
+func (s wordSet) Contains(word string) bool {
+	_, ok := s[strings.ToLower(word)]
+	return ok
+}
+
+// Words returns a list of the words, which are compared case-insensitively.
+func Words(words ...string) WordList {
+	s := make(wordSet, len(words))
+	for _, word := range words {
+		s[strings.ToLower(word)] = struct{}{}
+	}
+	return s
+}
+
+// ReadWords reads a list of words from r, with a word or value per line.
+// Surrounding whitespace, empty lines and lines that start with `#` are
+// ignored. Words are compared case-insensitively.
+func ReadWords(r io.Reader) (WordList, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read words: %w", err)
+	}
+	return Words(words...), nil
+}
+
+// ReadWordsFile reads a list of words from the named file in fsys (see
+// ReadWords), e.g. from an embed.FS for lists that are embedded in the binary,
+// or from os.DirFS for lists that are deployed separately.
+func ReadWordsFile(fsys fs.FS, name string) (WordList, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer f.Close()
+	return ReadWords(f)
+}
+
+// InList adds a validator that checks if the string is in the list, e.g. of
+// allowed values that are too many or too dynamic for OneOf, and returns the
+// schema for chaining.
+func (s *StringSchema) InList(list WordList) *StringSchema {
+	s.describe(CodeOneOf, true)
+	s.addValidator(func(v string) error {
+		if !list.Contains(v) {
+			return constraintError(CodeOneOf, nil, "value must be one of the allowed values")
+		}
+		return nil
+	})
+	return s
+}
+
+// NotInList adds a validator that checks if the string is not in the list,
+// e.g. of reserved usernames such as `admin`, and returns the schema for
+// chaining.
+func (s *StringSchema) NotInList(list WordList) *StringSchema {
+	s.describe(CodeDenied, true)
+	s.addValidator(func(v string) error {
+		if list.Contains(v) {
+			return constraintError(CodeDenied, nil, "value is not allowed")
+		}
+		return nil
+	})
+	return s
+}
+
+// DenyWords adds a validator that checks if none of the words in the string
+// are in the list, e.g. of profanity, and returns the schema for chaining.
+// Words are sequences of letters and digits, so that e.g. `darn-it` consists
+// of `darn` and `it`, and phrases in the list never match. The denied word is
+// not included in the error message, so that it isn't shown back to users, but
+// is available as the `word` parameter of the ConstraintError.
+func (s *StringSchema) DenyWords(list WordList) *StringSchema {
+	s.describe(CodeDeniedWord, true)
+	s.addValidator(func(v string) error {
+		for word := range strings.FieldsFuncSeq(v, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if list.Contains(word) {
+				return constraintError(CodeDeniedWord, map[string]any{"word": word}, "string contains a word that is not allowed")
+			}
+		}
+		return nil
+	})
+	return s
+}